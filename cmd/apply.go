@@ -6,7 +6,7 @@ import (
 	"os"
 	"path/filepath"
 
-	"github.com/richq/m2cv/internal/extractor"
+	"github.com/richq/m2cv/internal/application"
 	"github.com/richq/m2cv/internal/filesystem"
 	"github.com/spf13/cobra"
 )
@@ -95,23 +95,10 @@ func runApply(jobInput, jobName, applicationsDir string, fileFlag bool, stdin io
 		return fmt.Errorf("job posting content is empty")
 	}
 
-	// Sanitize the provided job name
-	folderName := extractor.SanitizeFilename(jobName)
-
-	// Build application path
-	appPath := filepath.Join(applicationsDir, folderName)
-
-	// Initialize filesystem operations
-	fs := filesystem.NewOperations()
-
-	// Check if folder already exists
-	if fs.Exists(appPath) {
-		return fmt.Errorf("application folder already exists: %s. Provide a different job-name", appPath)
-	}
-
-	// Create application folder
-	if err := fs.CreateDir(appPath, 0755); err != nil {
-		return fmt.Errorf("failed to create application folder: %w", err)
+	// Create the application folder under a sanitized name
+	appPath, err := application.Create(applicationsDir, jobName)
+	if err != nil {
+		return err
 	}
 
 	// Write job description to folder
@@ -119,12 +106,12 @@ func runApply(jobInput, jobName, applicationsDir string, fileFlag bool, stdin io
 	if input.filePath != "" {
 		// Copy original file if input was from a file
 		destFile = filepath.Join(appPath, filepath.Base(input.filePath))
-		if err := fs.CopyFile(input.filePath, destFile); err != nil {
+		if err := filesystem.NewOperations().CopyFile(input.filePath, destFile); err != nil {
 			return fmt.Errorf("failed to copy job description: %w", err)
 		}
 	} else {
 		// Write content to job-description.txt if input was direct content or stdin
-		destFile = filepath.Join(appPath, "job-description.txt")
+		destFile = filepath.Join(appPath, application.JobDescriptionFile)
 		if err := os.WriteFile(destFile, []byte(input.content), 0644); err != nil {
 			return fmt.Errorf("failed to write job description: %w", err)
 		}
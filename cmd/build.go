@@ -0,0 +1,297 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"slices"
+	"strings"
+	"syscall"
+	"text/tabwriter"
+	"time"
+
+	"github.com/richq/m2cv/internal/application"
+	"github.com/richq/m2cv/internal/batch"
+	"github.com/richq/m2cv/internal/config"
+	"github.com/richq/m2cv/internal/executor"
+	"github.com/richq/m2cv/internal/generator"
+	"github.com/richq/m2cv/internal/preflight"
+	"github.com/richq/m2cv/internal/progress"
+	"github.com/spf13/cobra"
+)
+
+// buildLogFile is the per-application log written by m2cv build.
+const buildLogFile = "build.log"
+
+// Build steps, in the order they run.
+const (
+	stepOptimize = "optimize"
+	stepGenerate = "generate"
+)
+
+// buildStepOrder lists the valid --steps values in run order.
+var buildStepOrder = []string{stepOptimize, stepGenerate}
+
+// buildOptions are the m2cv build flags that shape each application's run.
+type buildOptions struct {
+	steps    []string
+	model    string
+	theme    string
+	ats      bool
+	force    bool
+	jobs     int
+	failFast bool
+}
+
+// buildStep runs one step for one application, writing its output to
+// log; swapped out in tests.
+var buildStep = runBuildStep
+
+// newBuildCommand creates the build subcommand.
+func newBuildCommand() *cobra.Command {
+	var (
+		all      bool
+		statuses []string
+		opts     buildOptions
+	)
+
+	cmd := &cobra.Command{
+		Use:   "build [application-name|glob...]",
+		Short: "Optimize and/or generate many applications concurrently",
+		Long: `Run optimize and/or generate for a batch of applications, several at a time.
+
+Select applications by name or glob (quote globs so the shell leaves them
+alone), by --status, or with --all. Names and statuses combine: "acme-*"
+with --status draft builds the acme drafts only.
+
+--steps chooses what runs for each application, in order: "generate"
+(the default) rebuilds PDFs, skipping up-to-date steps as generate does;
+"optimize,generate" tailors a new CV version first, e.g. after the base
+CV changed. Optimize always writes a new version.
+
+Up to --jobs applications run at once. Each application's output goes to
+its own applications/<name>/build.log, while the terminal shows one line
+as each starts and finishes. Ctrl-C cancels the running steps and skips
+the rest; with --fail-fast the first failure does the same. A report is
+printed at the end with the number that succeeded, failed and were
+skipped, and the command fails unless every application succeeded.
+
+Examples:
+  m2cv build --all
+  m2cv build --status draft,applied --steps optimize,generate
+  m2cv build "acme-*" --jobs 4
+  m2cv build --all --theme stackoverflow --force`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runBuild(cmd.Context(), cmd.OutOrStdout(), args, all, statuses, opts)
+		},
+	}
+
+	cmd.Flags().BoolVar(&all, "all", false, "build every application")
+	cmd.Flags().StringSliceVar(&statuses, "status", nil, "only build applications with these statuses (comma-separated)")
+	cmd.Flags().StringSliceVar(&opts.steps, "steps", []string{stepGenerate}, "steps to run: optimize, generate")
+	cmd.Flags().IntVarP(&opts.jobs, "jobs", "j", 2, "number of applications to build at once")
+	cmd.Flags().BoolVar(&opts.failFast, "fail-fast", false, "stop starting applications after the first failure")
+	cmd.Flags().StringVarP(&opts.model, "model", "m", "", "override Claude model")
+	cmd.Flags().StringVar(&opts.theme, "theme", "", "override JSON Resume theme (generate)")
+	cmd.Flags().BoolVar(&opts.ats, "ats", false, "optimize for ATS (optimize)")
+	cmd.Flags().BoolVarP(&opts.force, "force", "f", false, "rebuild even if resume.json and resume.pdf are up to date (generate)")
+
+	return cmd
+}
+
+// runBuild executes the build command logic.
+func runBuild(ctx context.Context, out io.Writer, patterns []string, all bool, statusNames []string, opts buildOptions) error {
+	switch {
+	case all && len(patterns) > 0:
+		return fmt.Errorf("--all cannot be combined with application names")
+	case !all && len(patterns) == 0 && len(statusNames) == 0:
+		return fmt.Errorf("select applications by name or glob, with --status, or with --all")
+	case opts.jobs < 1:
+		return fmt.Errorf("--jobs must be at least 1")
+	}
+
+	steps, err := parseBuildSteps(opts.steps)
+	if err != nil {
+		return err
+	}
+	opts.steps = steps
+
+	var statuses []application.Status
+	for _, name := range statusNames {
+		status, err := application.ParseStatus(name)
+		if err != nil {
+			return err
+		}
+		statuses = append(statuses, status)
+	}
+
+	summaries, err := application.Summarize("applications")
+	if err != nil {
+		return err
+	}
+	names, err := batch.Select(summaries, patterns, statuses)
+	if err != nil {
+		return err
+	}
+	if len(names) == 0 {
+		fmt.Fprintln(out, "No applications selected.")
+		return nil
+	}
+
+	configPath, err := config.FindWithOverrides(cfgFile, ".")
+	if err != nil {
+		return fmt.Errorf("m2cv.yml not found: %w. Run 'm2cv init' first", err)
+	}
+	if slices.Contains(steps, stepGenerate) {
+		if err := preflight.CheckResumed(filepath.Dir(configPath)); err != nil {
+			return err
+		}
+	}
+
+	ctx, stop := signal.NotifyContext(ctx, os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	fmt.Fprintf(out, "Building %d application(s) (%s, %d at a time)\n", len(names), joinSteps(steps), opts.jobs)
+	results := batch.Run(ctx, names, func(ctx context.Context, name string) error {
+		return buildApplication(ctx, name, opts)
+	}, batch.Options{
+		Jobs:     opts.jobs,
+		FailFast: opts.failFast,
+		OnStart: func(name string) {
+			fmt.Fprintf(out, "[%s] started\n", name)
+		},
+		OnDone: func(r batch.Result) {
+			if r.Err != nil {
+				fmt.Fprintf(out, "[%s] failed after %s\n", r.Name, r.Duration.Round(time.Second))
+				return
+			}
+			fmt.Fprintf(out, "[%s] done in %s\n", r.Name, r.Duration.Round(time.Second))
+		},
+	})
+
+	printBuildReport(out, results)
+	failed, skipped := batch.Failed(results), batch.Skipped(results)
+	switch {
+	case failed > 0 && skipped > 0:
+		return fmt.Errorf("%d of %d applications failed, %d skipped", failed, len(results), skipped)
+	case failed > 0:
+		return fmt.Errorf("%d of %d applications failed", failed, len(results))
+	case skipped > 0:
+		return fmt.Errorf("%d of %d applications skipped", skipped, len(results))
+	}
+	return nil
+}
+
+// parseBuildSteps validates --steps and puts them in run order.
+func parseBuildSteps(values []string) ([]string, error) {
+	for _, value := range values {
+		if !slices.Contains(buildStepOrder, value) {
+			return nil, fmt.Errorf("invalid step %q (valid: %s)", value, joinSteps(buildStepOrder))
+		}
+	}
+	var steps []string
+	for _, step := range buildStepOrder {
+		if slices.Contains(values, step) {
+			steps = append(steps, step)
+		}
+	}
+	if len(steps) == 0 {
+		return nil, fmt.Errorf("--steps needs at least one of: %s", joinSteps(buildStepOrder))
+	}
+	return steps, nil
+}
+
+// joinSteps formats steps for messages: "optimize, generate".
+func joinSteps(steps []string) string {
+	return strings.Join(steps, ", ")
+}
+
+// buildApplication runs the steps for one application, logging to its
+// build.log. The first failing step stops the application.
+func buildApplication(ctx context.Context, name string, opts buildOptions) error {
+	logPath := filepath.Join("applications", name, buildLogFile)
+	log, err := os.Create(logPath)
+	if err != nil {
+		return fmt.Errorf("failed to create %s: %w", logPath, err)
+	}
+	defer log.Close()
+
+	fmt.Fprintf(log, "m2cv build %s: %s, started %s\n", name, joinSteps(opts.steps), time.Now().Format(time.RFC3339))
+	for _, step := range opts.steps {
+		fmt.Fprintf(log, "\n== %s\n", step)
+		if err := buildStep(ctx, name, step, opts, log); err != nil {
+			fmt.Fprintf(log, "error: %v\n", err)
+			return fmt.Errorf("%s: %w", step, err)
+		}
+	}
+	fmt.Fprintf(log, "\nfinished %s\n", time.Now().Format(time.RFC3339))
+	return nil
+}
+
+// runBuildStep runs optimize or generate for one application the way the
+// standalone commands do, with progress and results going to log.
+func runBuildStep(ctx context.Context, name, step string, opts buildOptions, log io.Writer) error {
+	switch step {
+	case stepOptimize:
+		req, err := newOptimizeRequest(name, opts.model, opts.ats)
+		if err != nil {
+			return err
+		}
+		req.ExecuteOptions = []executor.ExecuteOption{
+			executor.WithProgress(progress.New(log, "Optimizing CV")),
+		}
+		result, err := generator.Optimize(ctx, req)
+		if err != nil {
+			return err
+		}
+		for _, warning := range result.Warnings {
+			fmt.Fprintf(log, "warning: %s\n", warning)
+		}
+		fmt.Fprintf(log, "Optimized CV written to: %s\n", result.OutputPath)
+
+	case stepGenerate:
+		req, err := newGenerateRequest(name, opts.theme, opts.model)
+		if err != nil {
+			return err
+		}
+		req.Force = opts.force
+		req.ExecuteOptions = []executor.ExecuteOption{
+			executor.WithProgress(progress.New(log, "Converting CV to JSON Resume")),
+		}
+		result, err := generator.Generate(ctx, req)
+		if err != nil {
+			return err
+		}
+		printGenerateResult(log, log, result)
+
+	default:
+		return fmt.Errorf("unknown step %q", step)
+	}
+	return nil
+}
+
+// printBuildReport prints one line per application and the totals.
+func printBuildReport(out io.Writer, results []batch.Result) {
+	fmt.Fprintln(out)
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	for _, r := range results {
+		switch {
+		case r.Err == nil:
+			fmt.Fprintf(w, "ok\t%s\t%s\t\n", r.Name, r.Duration.Round(time.Second))
+		case !r.Started:
+			fmt.Fprintf(w, "skipped\t%s\t%v\t\n", r.Name, r.Err)
+		case errors.Is(r.Err, context.Canceled):
+			fmt.Fprintf(w, "cancelled\t%s\tsee %s\t\n", r.Name, filepath.Join("applications", r.Name, buildLogFile))
+		default:
+			fmt.Fprintf(w, "FAILED\t%s\t%v (see %s)\t\n", r.Name, r.Err, filepath.Join("applications", r.Name, buildLogFile))
+		}
+	}
+	w.Flush()
+
+	failed, skipped := batch.Failed(results), batch.Skipped(results)
+	fmt.Fprintf(out, "\n%d succeeded, %d failed, %d skipped\n", len(results)-failed-skipped, failed, skipped)
+}
@@ -0,0 +1,155 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/richq/m2cv/internal/application"
+)
+
+func TestBuildCommand_Structure(t *testing.T) {
+	t.Parallel()
+
+	cmd := newBuildCommand()
+	for _, flag := range []string{"all", "status", "steps", "jobs", "fail-fast", "model", "theme", "ats", "force"} {
+		if cmd.Flags().Lookup(flag) == nil {
+			t.Errorf("missing --%s flag", flag)
+		}
+	}
+	if got := cmd.Flags().Lookup("steps").DefValue; got != "[generate]" {
+		t.Errorf("--steps default = %s", got)
+	}
+}
+
+// setupBuildTest creates a project whose applications have the given
+// statuses, and replaces buildStep with a stub that fails for "globex".
+func setupBuildTest(t *testing.T, apps map[string]application.Status) (*[]string, func()) {
+	t.Helper()
+	tmpDir, cleanup := setupOptimizeTest(t)
+	os.WriteFile(filepath.Join(tmpDir, "m2cv.yml"), []byte("version: 2\n"), 0644)
+	// resumed is checked before generate steps
+	os.MkdirAll(filepath.Join(tmpDir, "node_modules", "resumed"), 0755)
+	for name, status := range apps {
+		appDir := filepath.Join(tmpDir, "applications", name)
+		os.MkdirAll(appDir, 0755)
+		if _, err := application.WriteStatus(appDir, status, ""); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	var (
+		mu  sync.Mutex
+		ran []string
+	)
+	orig := buildStep
+	buildStep = func(ctx context.Context, name, step string, opts buildOptions, log io.Writer) error {
+		mu.Lock()
+		ran = append(ran, name+":"+step)
+		mu.Unlock()
+		fmt.Fprintf(log, "running %s\n", step)
+		if name == "globex" {
+			return errors.New("claude down")
+		}
+		return nil
+	}
+	return &ran, func() {
+		buildStep = orig
+		cleanup()
+	}
+}
+
+func TestRunBuild(t *testing.T) {
+	ran, cleanup := setupBuildTest(t, map[string]application.Status{
+		"acme":    application.StatusDraft,
+		"globex":  application.StatusDraft,
+		"initech": application.StatusApplied,
+	})
+	defer cleanup()
+
+	var out bytes.Buffer
+	err := runBuild(context.Background(), &out, nil, false, []string{"draft"}, buildOptions{steps: []string{"generate", "optimize"}, jobs: 2})
+	if err == nil || err.Error() != "1 of 2 applications failed" {
+		t.Errorf("runBuild() error = %v", err)
+	}
+
+	// Steps run in order; a failed step stops the application
+	got := strings.Join(*ran, " ")
+	for _, want := range []string{"acme:optimize", "acme:generate", "globex:optimize"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("steps run = %s, missing %s", got, want)
+		}
+	}
+	if strings.Contains(got, "globex:generate") || strings.Contains(got, "initech") {
+		t.Errorf("steps run = %s", got)
+	}
+
+	for _, want := range []string{"Building 2 application(s) (optimize, generate, 2 at a time)", "[acme] started", "ok", "FAILED", "optimize: claude down", "1 succeeded, 1 failed, 0 skipped"} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("output missing %q:\n%s", want, out.String())
+		}
+	}
+
+	log, err := os.ReadFile(filepath.Join("applications", "globex", buildLogFile))
+	if err != nil || !strings.Contains(string(log), "running optimize") || !strings.Contains(string(log), "error: claude down") {
+		t.Errorf("globex build.log = %q, %v", log, err)
+	}
+}
+
+func TestRunBuild_FailFast(t *testing.T) {
+	_, cleanup := setupBuildTest(t, map[string]application.Status{
+		"globex":  application.StatusDraft,
+		"initech": application.StatusDraft,
+	})
+	defer cleanup()
+
+	var out bytes.Buffer
+	err := runBuild(context.Background(), &out, nil, true, nil, buildOptions{steps: []string{"generate"}, jobs: 1, failFast: true})
+	if err == nil || err.Error() != "1 of 2 applications failed, 1 skipped" {
+		t.Errorf("runBuild() error = %v", err)
+	}
+	if !strings.Contains(out.String(), "skipped  initech") || !strings.Contains(out.String(), "0 succeeded, 1 failed, 1 skipped") {
+		t.Errorf("initech should be skipped:\n%s", out.String())
+	}
+}
+
+func TestRunBuild_Errors(t *testing.T) {
+	_, cleanup := setupBuildTest(t, map[string]application.Status{"acme": application.StatusDraft})
+	defer cleanup()
+
+	tests := []struct {
+		name     string
+		patterns []string
+		all      bool
+		statuses []string
+		opts     buildOptions
+		want     string
+	}{
+		{name: "no selection", opts: buildOptions{steps: []string{"generate"}, jobs: 1}, want: "select applications"},
+		{name: "all and names", patterns: []string{"acme"}, all: true, opts: buildOptions{steps: []string{"generate"}, jobs: 1}, want: "--all cannot be combined"},
+		{name: "bad step", all: true, opts: buildOptions{steps: []string{"export"}, jobs: 1}, want: `invalid step "export"`},
+		{name: "bad jobs", all: true, opts: buildOptions{steps: []string{"generate"}}, want: "--jobs must be at least 1"},
+		{name: "bad status", statuses: []string{"hired"}, opts: buildOptions{steps: []string{"generate"}, jobs: 1}, want: "invalid status"},
+		{name: "unknown application", patterns: []string{"acne"}, opts: buildOptions{steps: []string{"generate"}, jobs: 1}, want: "application not found: acne"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := runBuild(context.Background(), io.Discard, tt.patterns, tt.all, tt.statuses, tt.opts)
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("runBuild() error = %v, want %q", err, tt.want)
+			}
+		})
+	}
+
+	var out bytes.Buffer
+	if err := runBuild(context.Background(), &out, []string{"zeta-*"}, false, nil, buildOptions{steps: []string{"generate"}, jobs: 1}); err != nil || !strings.Contains(out.String(), "No applications selected") {
+		t.Errorf("empty glob: %q, %v", out.String(), err)
+	}
+}
@@ -0,0 +1,378 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/richq/m2cv/internal/config"
+	themepkg "github.com/richq/m2cv/internal/theme"
+	"github.com/spf13/cobra"
+)
+
+// newConfigCommand creates the config command group. Without a
+// subcommand it lists the effective settings.
+func newConfigCommand() *cobra.Command {
+	var (
+		showOrigin bool
+		appName    string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "config",
+		Short: "Read and edit m2cv.yml",
+		Long: `Read and edit settings without losing the files' comments.
+
+Settings are layered, each layer overriding the one before:
+  1. the user file ($XDG_CONFIG_HOME/m2cv/config.yml, ~/.config/m2cv/config.yml)
+  2. the project's m2cv.yml
+  3. the application's application.yml (for commands working on one)
+  4. M2CV_* environment variables, e.g. M2CV_DEFAULT_MODEL or
+     M2CV_THEME_OPTIONS_ACCENT_COLOR
+
+get and list show the effective values; --show-origin adds the layer each
+came from. set and unset edit m2cv.yml, or the user file with --global.
+
+Keys are dotted paths into the file, e.g. default_theme or
+theme_options.accent_color. List settings such as themes take a
+comma-separated value; keys under theme_options.meta take any YAML value.
+Unknown keys are rejected with a suggestion, and after a change the
+settings are checked (base CV present, themes installed, model name
+well-formed) and any problems printed as warnings.`,
+		Example: `  m2cv config --show-origin
+  m2cv config --app acme-software-engineer get default_theme`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runConfigList(cmd.OutOrStdout(), cmd.ErrOrStderr(), appName, showOrigin)
+		},
+	}
+
+	cmd.PersistentFlags().BoolVar(&showOrigin, "show-origin", false, "show the layer each value comes from")
+	cmd.PersistentFlags().StringVar(&appName, "app", "", "include an application's application.yml layer")
+
+	cmd.AddCommand(newConfigGetCommand(&appName, &showOrigin))
+	cmd.AddCommand(newConfigSetCommand())
+	cmd.AddCommand(newConfigUnsetCommand())
+	cmd.AddCommand(newConfigListCommand(&appName, &showOrigin))
+	cmd.AddCommand(newConfigMigrateCommand())
+	return cmd
+}
+
+// newConfigGetCommand creates the config get subcommand.
+func newConfigGetCommand(appName *string, showOrigin *bool) *cobra.Command {
+	return &cobra.Command{
+		Use:     "get <key>",
+		Short:   "Print the effective value of a setting",
+		Example: `  m2cv config get default_theme`,
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runConfigGet(cmd.OutOrStdout(), args[0], *appName, *showOrigin)
+		},
+	}
+}
+
+// newConfigSetCommand creates the config set subcommand.
+func newConfigSetCommand() *cobra.Command {
+	var global bool
+
+	cmd := &cobra.Command{
+		Use:   "set <key> <value>",
+		Short: "Change a setting",
+		Example: `  m2cv config set default_model sonnet
+  m2cv config set --global default_theme flat
+  m2cv config set themes even,flat
+  m2cv config set theme_options.accent_color "#0a7"
+  m2cv config set theme_options.meta.colors "{background: '#fff'}"`,
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runConfigEdit(cmd.ErrOrStderr(), global, func(doc *config.Document) error {
+				return doc.Set(args[0], args[1])
+			})
+		},
+	}
+
+	cmd.Flags().BoolVar(&global, "global", false, "edit the user config file instead of m2cv.yml")
+	return cmd
+}
+
+// newConfigUnsetCommand creates the config unset subcommand.
+func newConfigUnsetCommand() *cobra.Command {
+	var global bool
+
+	cmd := &cobra.Command{
+		Use:     "unset <key>",
+		Short:   "Remove a setting",
+		Example: `  m2cv config unset theme_options.margin`,
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runConfigEdit(cmd.ErrOrStderr(), global, func(doc *config.Document) error {
+				return doc.Unset(args[0])
+			})
+		},
+	}
+
+	cmd.Flags().BoolVar(&global, "global", false, "edit the user config file instead of m2cv.yml")
+	return cmd
+}
+
+// newConfigListCommand creates the config list subcommand.
+func newConfigListCommand(appName *string, showOrigin *bool) *cobra.Command {
+	return &cobra.Command{
+		Use:   "list",
+		Short: "List the effective settings and check them",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runConfigList(cmd.OutOrStdout(), cmd.ErrOrStderr(), *appName, *showOrigin)
+		},
+	}
+}
+
+// newConfigMigrateCommand creates the config migrate subcommand.
+func newConfigMigrateCommand() *cobra.Command {
+	var global, dryRun bool
+
+	cmd := &cobra.Command{
+		Use:   "migrate",
+		Short: "Upgrade m2cv.yml to the current format version",
+		Long: `Upgrade m2cv.yml (or the user file with --global) to the current format
+version, applying each version's migration in turn. The original is kept
+next to it as m2cv.yml.v<N>.bak and every change is reported.
+
+Commands warn when they load an outdated file.`,
+		Example: `  m2cv config migrate --dry-run
+  m2cv config migrate --global`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runConfigMigrate(cmd.OutOrStdout(), global, dryRun)
+		},
+	}
+
+	cmd.Flags().BoolVar(&global, "global", false, "migrate the user config file instead of m2cv.yml")
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "report the changes without writing")
+	return cmd
+}
+
+// findConfig locates m2cv.yml for the config commands.
+func findConfig() (string, error) {
+	configPath, err := config.FindWithOverrides(cfgFile, ".")
+	if err != nil {
+		return "", fmt.Errorf("m2cv.yml not found: %w. Run 'm2cv init' first", err)
+	}
+	return configPath, nil
+}
+
+// loadConfig loads the settings in effect for the project at configPath:
+// the user file, m2cv.yml, appDir's application.yml (if appDir is set)
+// and M2CV_* environment variables.
+func loadConfig(configPath, appDir string) (*config.Layered, error) {
+	var opts []config.LayerOption
+	if appDir != "" {
+		opts = append(opts, config.WithApplication(appDir))
+	}
+	layered, err := config.LoadLayered(configPath, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load config: %w", err)
+	}
+	return layered, nil
+}
+
+// loadConfigForApp finds m2cv.yml and loads the settings in effect for
+// the named application ("" for the project alone).
+func loadConfigForApp(appName string) (string, *config.Layered, error) {
+	configPath, err := findConfig()
+	if err != nil {
+		return "", nil, err
+	}
+	appDir := ""
+	if appName != "" {
+		appDir = filepath.Join("applications", appName)
+		if _, err := os.Stat(appDir); err != nil {
+			return "", nil, fmt.Errorf("application folder not found: %s", appDir)
+		}
+	}
+	layered, err := loadConfig(configPath, appDir)
+	if err != nil {
+		return "", nil, err
+	}
+	return configPath, layered, nil
+}
+
+// runConfigGet prints the effective value of key.
+func runConfigGet(out io.Writer, key, appName string, showOrigin bool) error {
+	_, layered, err := loadConfigForApp(appName)
+	if err != nil {
+		return err
+	}
+	value, origin, err := layered.Get(key)
+	if err != nil {
+		return err
+	}
+	if showOrigin {
+		fmt.Fprintf(out, "%s\t%s\n", origin, value)
+		return nil
+	}
+	fmt.Fprintln(out, value)
+	return nil
+}
+
+// runConfigEdit applies edit to m2cv.yml (or the user file with global),
+// saves it and warns about any problems in the resulting settings.
+func runConfigEdit(errOut io.Writer, global bool, edit func(doc *config.Document) error) error {
+	configPath, err := findConfig()
+	if err != nil {
+		return err
+	}
+
+	path := configPath
+	if global {
+		if path = config.UserConfigPath(); path == "" {
+			return fmt.Errorf("cannot determine the user config directory; set XDG_CONFIG_HOME")
+		}
+	}
+	doc, err := config.LoadDocument(path)
+	if global && errors.Is(err, fs.ErrNotExist) {
+		doc, err = config.NewDocument(), nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+	if err := edit(doc); err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("failed to save config: %w", err)
+	}
+	if err := doc.Save(path); err != nil {
+		return fmt.Errorf("failed to save config: %w", err)
+	}
+
+	layered, err := loadConfig(configPath, "")
+	if err != nil {
+		return err
+	}
+	printConfigProblems(errOut, configPath, validateConfig(configPath, layered))
+	return nil
+}
+
+// runConfigMigrate migrates m2cv.yml (or the user file) and reports the changes.
+func runConfigMigrate(out io.Writer, global, dryRun bool) error {
+	var path string
+	if global {
+		if path = config.UserConfigPath(); path == "" {
+			return fmt.Errorf("cannot determine the user config directory; set XDG_CONFIG_HOME")
+		}
+	} else {
+		configPath, err := findConfig()
+		if err != nil {
+			return err
+		}
+		path = configPath
+	}
+
+	var (
+		report *config.MigrationReport
+		backup string
+		err    error
+	)
+	if dryRun {
+		var doc *config.Document
+		if doc, err = config.LoadDocument(path); err == nil {
+			report, err = doc.Migrate()
+		}
+	} else {
+		report, backup, err = config.MigrateFile(path)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to migrate %s: %w", path, err)
+	}
+
+	if report.From == report.To {
+		fmt.Fprintf(out, "%s is up to date (version %d)\n", path, report.To)
+		return nil
+	}
+	switch {
+	case dryRun:
+		fmt.Fprintf(out, "Would migrate %s from version %d to %d:\n", path, report.From, report.To)
+	default:
+		fmt.Fprintf(out, "Migrated %s from version %d to %d (backup: %s):\n", path, report.From, report.To, backup)
+	}
+	for _, change := range report.Changes {
+		fmt.Fprintf(out, "  - %s\n", change)
+	}
+	return nil
+}
+
+// runConfigList prints every effective setting, then warns about unknown
+// keys and invalid settings.
+func runConfigList(out, errOut io.Writer, appName string, showOrigin bool) error {
+	configPath, layered, err := loadConfigForApp(appName)
+	if err != nil {
+		return err
+	}
+
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	for _, s := range layered.Settings {
+		if showOrigin {
+			fmt.Fprintf(w, "%s\t%s\t%s\n", layered.Origins[s.Key], s.Key, s.Value)
+			continue
+		}
+		fmt.Fprintf(w, "%s\t%s\n", s.Key, s.Value)
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+
+	printConfigProblems(errOut, configPath, append(layered.Problems, validateConfig(configPath, layered)...))
+	return nil
+}
+
+// validateConfig checks the effective settings against the project next
+// to configPath, attributing each problem to the layer that set the key.
+func validateConfig(configPath string, layered *config.Layered) []config.Problem {
+	projectDir := filepath.Dir(configPath)
+	problems := layered.Config.Validate(projectDir, func(ref string) bool {
+		return themepkg.IsInstalled(projectDir, ref)
+	})
+	for i, p := range problems {
+		problems[i].File = layered.Origins[p.Key].Path
+	}
+	return problems
+}
+
+// printConfigProblems prints problems as warnings, attributing those not
+// tied to a file or variable to configPath.
+func printConfigProblems(errOut io.Writer, configPath string, problems []config.Problem) {
+	for _, p := range problems {
+		if p.File == "" {
+			p.File = filepath.Base(configPath)
+		}
+		fmt.Fprintf(errOut, "warning: %s\n", p)
+	}
+}
+
+// warnConfig prints warnings for unknown keys and malformed model
+// settings, so typos don't go unnoticed. Errors are left to the command,
+// which reports a missing or unparseable config itself.
+func warnConfig(errOut io.Writer) {
+	configPath, err := config.FindWithOverrides(cfgFile, ".")
+	if err != nil {
+		return
+	}
+	layered, err := config.LoadLayered(configPath)
+	if err != nil {
+		return
+	}
+	problems := layered.Problems
+	for _, p := range layered.Config.Validate(filepath.Dir(configPath), nil) {
+		if p.Key == "default_model" || strings.HasPrefix(p.Key, "models.") {
+			p.File = layered.Origins[p.Key].Path
+			problems = append(problems, p)
+		}
+	}
+	printConfigProblems(errOut, configPath, problems)
+}
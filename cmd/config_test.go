@@ -0,0 +1,165 @@
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/richq/m2cv/internal/config"
+)
+
+func TestConfigCommand_Subcommands(t *testing.T) {
+	cmd := newConfigCommand()
+	for _, name := range []string{"get", "set", "unset", "list"} {
+		if sub, _, err := cmd.Find([]string{name}); err != nil || sub.Name() != name {
+			t.Errorf("missing subcommand %s", name)
+		}
+	}
+}
+
+func TestRunConfig_EditKeepsComments(t *testing.T) {
+	tmpDir, cleanup := setupOptimizeTest(t)
+	defer cleanup()
+
+	configPath := filepath.Join(tmpDir, "m2cv.yml")
+	os.WriteFile(configPath, []byte("# project settings\nbase_cv_path: ./base-cv.md # my CV\ndefault_theme: even\n"), 0644)
+
+	var errOut bytes.Buffer
+	if err := runConfigEdit(&errOut, false, func(doc *config.Document) error {
+		return doc.Set("default_model", "not a model")
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(errOut.String(), `default_model "not a model"`) || !strings.Contains(errOut.String(), "base CV not found") {
+		t.Errorf("warnings = %s", errOut.String())
+	}
+
+	data, _ := os.ReadFile(configPath)
+	for _, want := range []string{"# project settings", "base_cv_path: ./base-cv.md # my CV", "default_model: not a model"} {
+		if !strings.Contains(string(data), want) {
+			t.Errorf("m2cv.yml missing %q:\n%s", want, data)
+		}
+	}
+
+	var out bytes.Buffer
+	if err := runConfigGet(&out, "default_model", "", false); err != nil || out.String() != "not a model\n" {
+		t.Errorf("get = %q, %v", out.String(), err)
+	}
+
+	if err := runConfigEdit(&errOut, false, func(doc *config.Document) error {
+		return doc.Unset("default_model")
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if err := runConfigGet(&out, "default_model", "", false); err == nil {
+		t.Error("get of an unset key should fail")
+	}
+}
+
+func TestRunConfigList_WarnsUnknownKeys(t *testing.T) {
+	tmpDir, cleanup := setupOptimizeTest(t)
+	defer cleanup()
+
+	os.WriteFile(filepath.Join(tmpDir, "m2cv.yml"), []byte("default_them: even\ntheme_options:\n  margin: 1cm\n"), 0644)
+
+	var out, errOut bytes.Buffer
+	if err := runConfigList(&out, &errOut, "", false); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(out.String(), "theme_options.margin  1cm") {
+		t.Errorf("list output:\n%s", out.String())
+	}
+	if !strings.Contains(errOut.String(), `did you mean "default_theme"`) {
+		t.Errorf("warnings:\n%s", errOut.String())
+	}
+}
+
+func TestRunConfig_NoConfig(t *testing.T) {
+	_, cleanup := setupOptimizeTest(t)
+	defer cleanup()
+
+	var out bytes.Buffer
+	if err := runConfigGet(&out, "default_theme", "", false); err == nil || !strings.Contains(err.Error(), "m2cv init") {
+		t.Errorf("error = %v", err)
+	}
+}
+
+func TestRunConfig_GlobalAndShowOrigin(t *testing.T) {
+	tmpDir, cleanup := setupOptimizeTest(t)
+	defer cleanup()
+
+	os.WriteFile(filepath.Join(tmpDir, "m2cv.yml"), []byte("default_theme: even\n"), 0644)
+	os.MkdirAll(filepath.Join(tmpDir, "applications", "acme"), 0755)
+	os.WriteFile(filepath.Join(tmpDir, "applications", "acme", "application.yml"), []byte("default_theme: flat\n"), 0644)
+	t.Setenv("M2CV_THEME_OPTIONS_MARGIN", "1cm")
+
+	var errOut bytes.Buffer
+	if err := runConfigEdit(&errOut, true, func(doc *config.Document) error {
+		return doc.Set("default_model", "opus")
+	}); err != nil {
+		t.Fatal(err)
+	}
+	userPath := filepath.Join(tmpDir, ".config", "m2cv", "config.yml")
+	want := fmt.Sprintf("version: %d\ndefault_model: opus\n", config.CurrentVersion)
+	if data, err := os.ReadFile(userPath); err != nil || string(data) != want {
+		t.Errorf("user config = %q, %v; want %q", data, err, want)
+	}
+	// A file m2cv just created must not be reported as outdated
+	if strings.Contains(errOut.String(), "outdated") {
+		t.Errorf("new user config reported as outdated: %s", errOut.String())
+	}
+
+	var out bytes.Buffer
+	if err := runConfigList(&out, &errOut, "acme", true); err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{
+		"user " + userPath + ":2",
+		"application " + filepath.Join("applications", "acme", "application.yml") + ":1",
+		"env M2CV_THEME_OPTIONS_MARGIN",
+	} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("list missing %q:\n%s", want, out.String())
+		}
+	}
+
+	out.Reset()
+	if err := runConfigGet(&out, "default_theme", "", true); err != nil || !strings.HasPrefix(out.String(), "project ") || !strings.HasSuffix(out.String(), "\teven\n") {
+		t.Errorf("get = %q, %v", out.String(), err)
+	}
+}
+
+func TestRunConfigMigrate(t *testing.T) {
+	tmpDir, cleanup := setupOptimizeTest(t)
+	defer cleanup()
+
+	configPath := filepath.Join(tmpDir, "m2cv.yml")
+	os.WriteFile(configPath, []byte("default_theme: even\n"), 0644)
+
+	var out bytes.Buffer
+	if err := runConfigMigrate(&out, false, true); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(out.String(), "Would migrate") {
+		t.Errorf("dry run output:\n%s", out.String())
+	}
+	if _, err := os.Stat(configPath + ".v1.bak"); !os.IsNotExist(err) {
+		t.Error("dry run should not write a backup")
+	}
+
+	out.Reset()
+	if err := runConfigMigrate(&out, false, false); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(out.String(), "from version 1 to 2") || !strings.Contains(out.String(), `added default theme "even"`) {
+		t.Errorf("output:\n%s", out.String())
+	}
+
+	out.Reset()
+	if err := runConfigMigrate(&out, false, false); err != nil || !strings.Contains(out.String(), "up to date") {
+		t.Errorf("second run: %q, %v", out.String(), err)
+	}
+}
@@ -0,0 +1,104 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/richq/m2cv/internal/config"
+	"github.com/richq/m2cv/internal/doctor"
+	"github.com/richq/m2cv/internal/generator"
+	"github.com/spf13/cobra"
+)
+
+// newDoctorCommand creates the doctor subcommand.
+func newDoctorCommand() *cobra.Command {
+	var (
+		jsonOutput bool
+		noExport   bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "doctor",
+		Short: "Check the environment and project for problems",
+		Long: `Check everything m2cv depends on and report each item as pass, warn or
+fail with a suggested fix:
+
+  - claude, node, npm and npx (with versions)
+  - m2cv.yml is found and valid
+  - the base CV exists and follows the markdown conventions
+  - configured themes are installed
+  - resumed is installed and can export a test PDF
+  - the applications directory is writable
+
+The test export starts a headless browser and takes a few seconds; skip it
+with --no-export. Exits with an error if any check fails.`,
+		Example: `  m2cv doctor
+  m2cv doctor --json --no-export`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runDoctor(cmd.Context(), cmd.OutOrStdout(), jsonOutput, noExport)
+		},
+	}
+
+	cmd.Flags().BoolVar(&jsonOutput, "json", false, "print the report as JSON")
+	cmd.Flags().BoolVar(&noExport, "no-export", false, "skip the test PDF export")
+	return cmd
+}
+
+// doctorExport is the test export used by doctor; swapped out in tests.
+var doctorExport doctor.ExportFunc = func(ctx context.Context, jsonPath, outputPath, theme, projectDir string) error {
+	exporter, err := generator.NewExporter()
+	if err != nil {
+		return err
+	}
+	return exporter.ExportPDF(ctx, jsonPath, outputPath, theme, projectDir)
+}
+
+// runDoctor runs the checks and prints the report.
+func runDoctor(ctx context.Context, out io.Writer, jsonOutput, noExport bool) error {
+	opts := doctor.Options{BaseCVPath: baseCVPath, Export: doctorExport}
+	if configPath, err := config.FindWithOverrides(cfgFile, "."); err == nil {
+		opts.ConfigPath = configPath
+	}
+	if noExport {
+		opts.Export = nil
+	}
+
+	report := doctor.Run(ctx, opts)
+
+	if jsonOutput {
+		enc := json.NewEncoder(out)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(report); err != nil {
+			return err
+		}
+	} else {
+		printDoctorReport(out, report)
+	}
+
+	if report.Failed() {
+		return fmt.Errorf("doctor found problems; see the suggested fixes above")
+	}
+	return nil
+}
+
+// printDoctorReport prints one line per check, with the fix indented below.
+func printDoctorReport(out io.Writer, report *doctor.Report) {
+	var warned, failed int
+	for _, c := range report.Checks {
+		fmt.Fprintf(out, "%-5s %-13s %s\n", strings.ToUpper(string(c.Status)), c.Name, c.Detail)
+		if c.Fix != "" {
+			fmt.Fprintf(out, "%-19s fix: %s\n", "", c.Fix)
+		}
+		switch c.Status {
+		case doctor.Warn:
+			warned++
+		case doctor.Fail:
+			failed++
+		}
+	}
+	fmt.Fprintf(out, "\n%d check(s): %d passed, %d warning(s), %d failed\n", len(report.Checks), len(report.Checks)-warned-failed, warned, failed)
+}
@@ -0,0 +1,75 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/richq/m2cv/internal/doctor"
+)
+
+func TestDoctorCommand_Structure(t *testing.T) {
+	cmd := newDoctorCommand()
+	if cmd.Use != "doctor" {
+		t.Errorf("Use = %q, want doctor", cmd.Use)
+	}
+	for _, flag := range []string{"json", "no-export"} {
+		if cmd.Flags().Lookup(flag) == nil {
+			t.Errorf("missing --%s flag", flag)
+		}
+	}
+}
+
+func TestRunDoctor_JSON(t *testing.T) {
+	tmpDir, cleanup := setupOptimizeTest(t)
+	defer cleanup()
+
+	os.WriteFile(filepath.Join(tmpDir, "m2cv.yml"), []byte("version: 2\nbase_cv_path: ./base-cv.md\ndefault_theme: even\n"), 0644)
+	os.WriteFile(filepath.Join(tmpDir, "base-cv.md"), []byte("---\nname: Jane\nemail: jane@example.com\n---\n\n# Experience\n\n## Engineer | Acme\n*2020-01 - present*\n"), 0644)
+	os.MkdirAll(filepath.Join(tmpDir, "applications"), 0755)
+
+	var out bytes.Buffer
+	err := runDoctor(context.Background(), &out, true, true)
+	// Theme and resumed are not installed in the temp project
+	if err == nil {
+		t.Error("expected an error for failing checks")
+	}
+
+	var report doctor.Report
+	if err := json.Unmarshal(out.Bytes(), &report); err != nil {
+		t.Fatalf("invalid JSON: %v\n%s", err, out.String())
+	}
+	status := make(map[string]doctor.Status)
+	for _, c := range report.Checks {
+		status[c.Name] = c.Status
+	}
+	for name, want := range map[string]doctor.Status{
+		"config":       doctor.Pass,
+		"base cv":      doctor.Pass,
+		"themes":       doctor.Fail,
+		"applications": doctor.Pass,
+	} {
+		if status[name] != want {
+			t.Errorf("%s = %q, want %q", name, status[name], want)
+		}
+	}
+}
+
+func TestRunDoctor_Text(t *testing.T) {
+	_, cleanup := setupOptimizeTest(t)
+	defer cleanup()
+
+	var out bytes.Buffer
+	if err := runDoctor(context.Background(), &out, false, true); err == nil {
+		t.Error("expected an error without m2cv.yml")
+	}
+	for _, want := range []string{"FAIL  config", "fix: Run 'm2cv init'", "failed"} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("output missing %q:\n%s", want, out.String())
+		}
+	}
+}
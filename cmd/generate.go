@@ -3,16 +3,17 @@ package cmd
 import (
 	"context"
 	"fmt"
+	"io"
 	"os"
+	"os/signal"
 	"path/filepath"
-	"strings"
+	"syscall"
 
-	"github.com/richq/m2cv/internal/application"
-	"github.com/richq/m2cv/internal/assets"
 	"github.com/richq/m2cv/internal/config"
 	"github.com/richq/m2cv/internal/executor"
 	"github.com/richq/m2cv/internal/generator"
 	"github.com/richq/m2cv/internal/preflight"
+	"github.com/richq/m2cv/internal/progress"
 	"github.com/spf13/cobra"
 )
 
@@ -21,6 +22,8 @@ func newGenerateCommand() *cobra.Command {
 	var (
 		theme string
 		model string
+		watch bool
+		force bool
 	)
 
 	cmd := &cobra.Command{
@@ -33,14 +36,30 @@ converts it to JSON Resume format via Claude, validates the schema,
 and exports a professionally themed PDF using resumed.
 
 The --theme flag overrides the default theme from config.
-The -m/--model flag overrides the default Claude model from config.
+The -m/--model flag overrides the model from config: the "md-to-json-resume"
+or "generate" entry of the models block, or default_model.
 
 Output files written to the application folder:
   - resume.json (intermediate, useful for debugging)
   - resume.pdf (final output)
 
+Builds are incremental: the inputs of each step are recorded in
+resume.meta.json, and a step whose inputs are unchanged is skipped. The
+Claude conversion runs again only when the optimized CV, the prompt or
+the model changed; the PDF export only when resume.json, the theme, the
+installed theme version or the theme options changed. --force runs both
+steps regardless. 'm2cv list' shows which applications are out of date.
+
+With --watch, m2cv keeps running after the first build and rebuilds when
+the latest optimized CV or the theme changes. Edits are debounced and
+compared by content, so saving without changes does nothing. A changed
+CV is converted again via Claude; a changed theme only re-exports the PDF
+from resume.json. Errors are printed and watching continues; Ctrl-C stops.
+
 Examples:
   m2cv generate acme-software-engineer
+  m2cv generate --watch acme-software-engineer
+  m2cv generate --force acme-software-engineer
   m2cv generate --theme stackoverflow my-app
   m2cv generate -m claude-sonnet-4-20250514 my-dream-job`,
 		Args: cobra.ExactArgs(1),
@@ -55,35 +74,63 @@ Examples:
 			return preflight.CheckResumed(projectDir)
 		},
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return runGenerate(cmd.Context(), args[0], theme, model)
+			if watch {
+				return runGenerateWatch(cmd.Context(), args[0], theme, model, force)
+			}
+			return runGenerate(cmd.Context(), args[0], theme, model, force)
 		},
 	}
 
 	cmd.Flags().StringVar(&theme, "theme", "", "override JSON Resume theme")
 	cmd.Flags().StringVarP(&model, "model", "m", "", "override Claude model")
+	cmd.Flags().BoolVarP(&watch, "watch", "w", false, "rebuild when the latest optimized CV or the theme changes")
+	cmd.Flags().BoolVarP(&force, "force", "f", false, "rebuild even if resume.json and resume.pdf are up to date")
 
 	return cmd
 }
 
 // runGenerate executes the generate command logic.
-func runGenerate(ctx context.Context, applicationName, themeOverride, modelOverride string) error {
+func runGenerate(ctx context.Context, applicationName, themeOverride, modelOverride string, force bool) error {
+	// Cancel rather than die on Ctrl-C, so claude is stopped and the
+	// partial output path is reported
+	ctx, stop := signal.NotifyContext(ctx, os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	req, err := newGenerateRequest(applicationName, themeOverride, modelOverride)
+	if err != nil {
+		return err
+	}
+	req.Force = force
+
+	req.ExecuteOptions = generateProgress()
+	result, err := generator.Generate(ctx, req)
+	if err != nil {
+		return err
+	}
+	printGenerateResult(os.Stdout, os.Stderr, result)
+	return nil
+}
+
+// newGenerateRequest validates the application and config and builds the
+// pipeline request.
+func newGenerateRequest(applicationName, themeOverride, modelOverride string) (generator.GenerateRequest, error) {
 	// 1. Validate application folder exists
 	appDir := filepath.Join("applications", applicationName)
 	if _, err := os.Stat(appDir); os.IsNotExist(err) {
-		return fmt.Errorf("application folder not found: %s. Run 'm2cv apply' first", appDir)
+		return generator.GenerateRequest{}, fmt.Errorf("application folder not found: %s. Run 'm2cv apply' first", appDir)
 	}
 
 	// 2. Load config
 	configPath, err := config.FindWithOverrides(cfgFile, ".")
 	if err != nil {
-		return fmt.Errorf("m2cv.yml not found: %w. Run 'm2cv init' first", err)
+		return generator.GenerateRequest{}, fmt.Errorf("m2cv.yml not found: %w. Run 'm2cv init' first", err)
 	}
 
-	configRepo := config.NewRepository()
-	cfg, err := configRepo.Load(configPath)
+	layered, err := loadConfig(configPath, appDir)
 	if err != nil {
-		return fmt.Errorf("failed to load config: %w", err)
+		return generator.GenerateRequest{}, err
 	}
+	cfg := layered.Config
 
 	// 3. Determine theme: flag > config.DefaultTheme
 	theme := cfg.DefaultTheme
@@ -94,86 +141,38 @@ func runGenerate(ctx context.Context, applicationName, themeOverride, modelOverr
 		theme = "even" // Fallback default
 	}
 
-	// 4. Determine model: flag > config.DefaultModel
-	model := cfg.DefaultModel
-	if modelOverride != "" {
-		model = modelOverride
-	}
-
-	// 5. Find latest optimized CV
-	latestCVPath, err := application.LatestVersionPath(appDir)
-	if err != nil {
-		return fmt.Errorf("failed to find optimized CV: %w", err)
-	}
-	if latestCVPath == "" {
-		return fmt.Errorf("no optimized CV found in %s. Run 'm2cv optimize %s' first", appDir, applicationName)
-	}
-
-	// 6. Read CV content
-	cvContent, err := os.ReadFile(latestCVPath)
-	if err != nil {
-		return fmt.Errorf("failed to read optimized CV at %s: %w", latestCVPath, err)
-	}
-
-	// 7. Load md-to-json-resume prompt
-	promptTemplate, err := assets.GetPrompt("md-to-json-resume")
-	if err != nil {
-		return fmt.Errorf("failed to load prompt template: %w", err)
-	}
-
-	// 8. Substitute {{.CV}} with content
-	prompt := strings.ReplaceAll(promptTemplate, "{{.CV}}", string(cvContent))
-
-	// 9. Execute Claude
-	exec := executor.NewClaudeExecutor()
-	var opts []executor.ExecuteOption
-	if model != "" {
-		opts = append(opts, executor.WithModel(model))
-	}
-
-	result, err := exec.Execute(ctx, prompt, opts...)
-	if err != nil {
-		return fmt.Errorf("failed to convert CV to JSON Resume: %w", err)
-	}
-
-	// 10. Extract JSON from Claude output
-	jsonResume, err := generator.ExtractJSON([]byte(result))
-	if err != nil {
-		return fmt.Errorf("failed to extract JSON from Claude output: %w", err)
-	}
-
-	// 11. Validate against JSON Resume schema
-	validator, err := generator.NewValidator()
-	if err != nil {
-		return fmt.Errorf("failed to initialize validator: %w", err)
-	}
+	return generator.GenerateRequest{
+		AppDir:       appDir,
+		ProjectDir:   filepath.Dir(configPath),
+		Theme:        theme,
+		ThemeOptions: cfg.ThemeOptions,
+		Model:        modelOverride,
+		Models:       cfg.ModelSettings(),
+		ToolVersion:  version,
+		ToolCommit:   commit,
+	}, nil
+}
 
-	if err := validator.Validate(jsonResume); err != nil {
-		return fmt.Errorf("JSON Resume validation failed: %w. Try running 'm2cv generate' again or check the optimized CV", err)
+// generateProgress streams conversion progress to stderr so long runs
+// aren't silent. Reporters are single-use, so call it once per run.
+func generateProgress() []executor.ExecuteOption {
+	return []executor.ExecuteOption{
+		executor.WithProgress(progress.New(os.Stderr, "Converting CV to JSON Resume")),
 	}
+}
 
-	// 12. Write resume.json to appDir (for debugging)
-	jsonPath := filepath.Join(appDir, "resume.json")
-	if err := os.WriteFile(jsonPath, jsonResume, 0644); err != nil {
-		return fmt.Errorf("failed to write resume.json: %w", err)
+// printGenerateResult prints warnings to errOut, and the skipped steps
+// and the written files to out.
+func printGenerateResult(out, errOut io.Writer, result *generator.GenerateResult) {
+	for _, warning := range result.Warnings {
+		fmt.Fprintf(errOut, "warning: %s\n", warning)
 	}
-
-	// 13. Export PDF via resumed
-	projectDir := filepath.Dir(configPath)
-	pdfPath := filepath.Join(appDir, "resume.pdf")
-
-	exporter, err := generator.NewExporter()
-	if err != nil {
-		return fmt.Errorf("failed to initialize exporter: %w", err)
+	switch len(result.Skipped) {
+	case 1:
+		fmt.Fprintln(out, "resume.json is up to date; skipped the conversion and re-exported the PDF.")
+	case 2:
+		fmt.Fprintln(out, "resume.json and resume.pdf are up to date; nothing to do (use --force to rebuild).")
 	}
-
-	if err := exporter.ExportPDF(ctx, jsonPath, pdfPath, theme, projectDir); err != nil {
-		return fmt.Errorf("failed to export PDF: %w", err)
-	}
-
-	// 14. Print success
-	fmt.Printf("JSON written to: %s\n", jsonPath)
-	fmt.Printf("PDF written to: %s\n", pdfPath)
-
-	return nil
+	fmt.Fprintf(out, "JSON written to: %s\n", result.JSONPath)
+	fmt.Fprintf(out, "PDF written to: %s\n", result.PDFPath)
 }
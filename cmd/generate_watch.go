@@ -0,0 +1,96 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"slices"
+	"syscall"
+	"time"
+
+	"github.com/richq/m2cv/internal/application"
+	"github.com/richq/m2cv/internal/generator"
+	themepkg "github.com/richq/m2cv/internal/theme"
+	"github.com/richq/m2cv/internal/watch"
+)
+
+// buildFunc runs one generate or export; swapped out in tests.
+type buildFunc func(ctx context.Context, req generator.GenerateRequest) (*generator.GenerateResult, error)
+
+// runGenerateWatch builds once, then rebuilds whenever the latest optimized
+// CV or the theme changes, until SIGINT or SIGTERM. With force, every
+// build runs in full rather than skipping unchanged steps.
+func runGenerateWatch(ctx context.Context, applicationName, themeOverride, modelOverride string, force bool) error {
+	req, err := newGenerateRequest(applicationName, themeOverride, modelOverride)
+	if err != nil {
+		return err
+	}
+	req.Force = force
+
+	ctx, stop := signal.NotifyContext(ctx, os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	w := watch.New(watchPaths(req))
+	if err := watchGenerate(ctx, w, req, generator.Generate, generator.Export); err != nil {
+		return err
+	}
+	fmt.Fprintln(os.Stderr, "Stopped watching.")
+	return nil
+}
+
+// watchPaths returns the files a watched build depends on: the latest
+// optimized CV (re-resolved on every poll), the installed theme and the
+// theme option files.
+func watchPaths(req generator.GenerateRequest) func() []string {
+	themeDir := themepkg.Dir(req.ProjectDir, req.Theme)
+	return func() []string {
+		paths := append([]string{themeDir}, themepkg.StylePaths(req.ProjectDir, req.ThemeOptions, req.AppDir)...)
+		if latest, err := application.LatestVersionPath(req.AppDir); err == nil && latest != "" {
+			paths = append(paths, latest)
+		}
+		return paths
+	}
+}
+
+// watchGenerate runs the initial build and then rebuilds on change. Only
+// the export is repeated when just the theme or its options changed. Build
+// errors are printed and watching continues.
+func watchGenerate(ctx context.Context, w *watch.Watcher, req generator.GenerateRequest, generate, export buildFunc) error {
+	themeDir := themepkg.Dir(req.ProjectDir, req.Theme)
+
+	build := func(run buildFunc, reason string) {
+		fmt.Fprintf(os.Stderr, "[%s] %s\n", time.Now().Format("15:04:05"), reason)
+		runReq := req
+		runReq.ExecuteOptions = generateProgress()
+		result, err := run(ctx, runReq)
+		if ctx.Err() != nil {
+			return
+		}
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "error: %v\n", err)
+		} else {
+			printGenerateResult(os.Stdout, os.Stderr, result)
+		}
+		fmt.Fprintln(os.Stderr, "Watching for changes (Ctrl-C to stop)...")
+	}
+
+	// Snapshot before building so edits made during the first build count
+	since := watch.Take(watchPaths(req)())
+	build(generate, "Building "+filepath.Base(req.AppDir))
+
+	return w.Run(ctx, since, func(changed []string) {
+		styleOnly := true
+		for _, path := range changed {
+			if path != themeDir && !slices.Contains(themepkg.StylePaths(req.ProjectDir, req.ThemeOptions, req.AppDir), path) {
+				styleOnly = false
+			}
+		}
+		if styleOnly {
+			build(export, "Theme changed, re-exporting PDF")
+			return
+		}
+		build(generate, "CV changed, converting again")
+	})
+}
@@ -0,0 +1,88 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/richq/m2cv/internal/generator"
+	"github.com/richq/m2cv/internal/watch"
+)
+
+func TestWatchGenerate(t *testing.T) {
+	projectDir := t.TempDir()
+	appDir := filepath.Join(projectDir, "applications", "acme")
+	themeDir := filepath.Join(projectDir, "node_modules", "jsonresume-theme-even")
+	for _, dir := range []string{appDir, themeDir} {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	write := func(path, content string) {
+		t.Helper()
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	write(filepath.Join(appDir, "optimized-cv-1.md"), "# v1")
+	write(filepath.Join(themeDir, "index.js"), "render()")
+
+	builds := make(chan string, 10)
+	generated := 0
+	generate := func(ctx context.Context, req generator.GenerateRequest) (*generator.GenerateResult, error) {
+		builds <- "generate"
+		generated++
+		if generated == 1 {
+			// The first build fails; watching must carry on
+			return nil, errors.New("claude down")
+		}
+		return &generator.GenerateResult{}, nil
+	}
+	export := func(ctx context.Context, req generator.GenerateRequest) (*generator.GenerateResult, error) {
+		builds <- "export"
+		return &generator.GenerateResult{}, nil
+	}
+
+	req := generator.GenerateRequest{AppDir: appDir, ProjectDir: projectDir, Theme: "even"}
+	w := watch.New(watchPaths(req), watch.WithInterval(5*time.Millisecond), watch.WithDebounce(20*time.Millisecond))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() { done <- watchGenerate(ctx, w, req, generate, export) }()
+
+	expect := func(want string) {
+		t.Helper()
+		select {
+		case got := <-builds:
+			if got != want {
+				t.Errorf("build = %s, want %s", got, want)
+			}
+		case <-time.After(2 * time.Second):
+			t.Fatalf("timed out waiting for %s", want)
+		}
+	}
+
+	expect("generate")
+
+	write(filepath.Join(themeDir, "style.css"), "body{}")
+	expect("export")
+
+	// Theme options are applied at export time too
+	write(filepath.Join(appDir, "application.yml"), "theme_options:\n  accent_color: teal\n")
+	expect("export")
+
+	// A new version becomes the watched CV
+	write(filepath.Join(appDir, "optimized-cv-2.md"), "# v2")
+	expect("generate")
+
+	cancel()
+	if err := <-done; err != nil {
+		t.Errorf("watchGenerate() = %v, want nil on cancel", err)
+	}
+	if len(builds) != 0 {
+		t.Errorf("unexpected extra builds: %d", len(builds))
+	}
+}
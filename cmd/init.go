@@ -11,6 +11,7 @@ import (
 	"github.com/richq/m2cv/internal/config"
 	"github.com/richq/m2cv/internal/executor"
 	initpkg "github.com/richq/m2cv/internal/init"
+	themepkg "github.com/richq/m2cv/internal/theme"
 	"github.com/spf13/cobra"
 )
 
@@ -42,7 +43,9 @@ This command will:
 3. Install resumed and the selected theme package
 
 If no theme is specified via --theme flag, an interactive theme selector
-will be shown (requires a terminal).`,
+will be shown (requires a terminal). --theme accepts any JSON Resume theme:
+a name (even), a package (@acme/jsonresume-theme-brand), npm:<package> or a
+local theme directory (./themes/brand).`,
 		Example: `  # Interactive mode - shows theme selector
   m2cv init
 
@@ -100,9 +103,10 @@ func runInit(ctx context.Context, themeName, baseCVPath string, force bool) erro
 		themeName = selected
 	}
 
-	// Validate theme
-	if !initpkg.IsValidTheme(themeName) {
-		return fmt.Errorf("invalid theme %q; available themes: %v", themeName, initpkg.AvailableThemes)
+	// Validate theme: any npm theme works, not only the curated list
+	themeName, err = themepkg.Normalize(themeName)
+	if err != nil {
+		return err
 	}
 
 	// Validate base CV path if provided
@@ -0,0 +1,150 @@
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/richq/m2cv/internal/application"
+	"github.com/richq/m2cv/internal/generator"
+	"github.com/spf13/cobra"
+)
+
+// Build states shown by m2cv list.
+const (
+	buildUpToDate     = "up to date"
+	buildStale        = "stale"
+	buildNotBuilt     = "not built"
+	buildNotOptimized = "not optimized"
+	buildError        = "error"
+)
+
+// listEntry is one application in the list output.
+type listEntry struct {
+	application.Summary
+	// Build is one of the build states above.
+	Build string `json:"build"`
+	// Reasons say why a stale build is out of date.
+	Reasons []string `json:"reasons,omitempty"`
+	// Error says why the build state could not be determined.
+	Error string `json:"error,omitempty"`
+}
+
+// newListCommand creates the list subcommand.
+func newListCommand() *cobra.Command {
+	var (
+		jsonOutput bool
+		staleOnly  bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "List applications and whether their PDFs are up to date",
+		Long: `List every application with its status, optimized versions and build state.
+
+The build state compares the inputs recorded by the last 'm2cv generate'
+with the current ones, the same way generate decides what to skip:
+
+  up to date      resume.json and resume.pdf match the latest optimized CV,
+                  prompt, model, theme, theme version and theme options
+  stale           something changed; the reasons are listed
+  not built       the application has never been generated
+  not optimized   there is no optimized CV yet
+  error           the build could not be planned (e.g. a broken
+                  application.yml); the other applications are still listed
+
+Examples:
+  m2cv list
+  m2cv list --stale
+  m2cv list --json`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runList(cmd.OutOrStdout(), jsonOutput, staleOnly)
+		},
+	}
+
+	cmd.Flags().BoolVar(&jsonOutput, "json", false, "print the list as JSON")
+	cmd.Flags().BoolVar(&staleOnly, "stale", false, "only list applications that are stale or not built")
+
+	return cmd
+}
+
+// runList executes the list command logic.
+func runList(out io.Writer, jsonOutput, staleOnly bool) error {
+	summaries, err := application.Summarize("applications")
+	if err != nil {
+		return err
+	}
+
+	entries := make([]listEntry, 0, len(summaries))
+	for _, s := range summaries {
+		entry := newListEntry(s)
+		if staleOnly && entry.Build != buildStale && entry.Build != buildNotBuilt && entry.Build != buildError {
+			continue
+		}
+		entries = append(entries, entry)
+	}
+
+	if jsonOutput {
+		data, err := json.MarshalIndent(entries, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to marshal applications: %w", err)
+		}
+		fmt.Fprintln(out, string(data))
+		return nil
+	}
+
+	if len(entries) == 0 {
+		if staleOnly {
+			fmt.Fprintln(out, "All applications are up to date.")
+		} else {
+			fmt.Fprintln(out, "No applications yet. Run 'm2cv apply' to create one.")
+		}
+		return nil
+	}
+
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "Application\tStatus\tVersions\tBuild\t")
+	for _, e := range entries {
+		build := e.Build
+		if len(e.Reasons) > 0 {
+			build += " (" + strings.Join(e.Reasons, ", ") + ")"
+		}
+		if e.Error != "" {
+			build += " (" + e.Error + ")"
+		}
+		fmt.Fprintf(w, "%s\t%s\t%d\t%s\t\n", e.Name, e.Status, len(e.Versions), build)
+	}
+	return w.Flush()
+}
+
+// newListEntry plans a generate of the application with its effective
+// config to find its build state. An application that can't be planned
+// gets the error state rather than failing the whole list.
+func newListEntry(s application.Summary) listEntry {
+	entry := listEntry{Summary: s}
+
+	req, err := newGenerateRequest(s.Name, "", "")
+	var plan *generator.BuildPlan
+	if err == nil {
+		plan, err = generator.Plan(req)
+	}
+	switch {
+	case errors.Is(err, generator.ErrNoOptimizedCV):
+		entry.Build = buildNotOptimized
+	case err != nil:
+		entry.Build = buildError
+		entry.Error = err.Error()
+	case plan.UpToDate():
+		entry.Build = buildUpToDate
+	case !s.HasPDF:
+		entry.Build = buildNotBuilt
+	default:
+		entry.Build = buildStale
+		entry.Reasons = plan.Reasons()
+	}
+	return entry
+}
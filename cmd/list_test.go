@@ -0,0 +1,127 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/richq/m2cv/internal/generator"
+	"github.com/richq/m2cv/internal/provenance"
+)
+
+func TestListCommand_Structure(t *testing.T) {
+	t.Parallel()
+
+	cmd := newListCommand()
+	if cmd.Use != "list" {
+		t.Errorf("wrong Use: %q", cmd.Use)
+	}
+	for _, flag := range []string{"json", "stale"} {
+		if cmd.Flags().Lookup(flag) == nil {
+			t.Errorf("missing --%s flag", flag)
+		}
+	}
+}
+
+func TestRunList(t *testing.T) {
+	tmpDir, cleanup := setupOptimizeTest(t)
+	defer cleanup()
+
+	os.WriteFile(filepath.Join(tmpDir, "m2cv.yml"), []byte("version: 2\nbase_cv_path: ./base-cv.md\ndefault_theme: even\n"), 0644)
+	files := map[string]string{
+		"acme/job.txt":              "Go developer",
+		"globex/optimized-cv-1.md":  "# Jane",
+		"initech/optimized-cv-1.md": "# Jane",
+		"initech/resume.json":       `{"basics": {"name": "Jane"}}`,
+		"initech/resume.pdf":        "%PDF",
+	}
+	for name, content := range files {
+		path := filepath.Join(tmpDir, "applications", filepath.FromSlash(name))
+		os.MkdirAll(filepath.Dir(path), 0755)
+		os.WriteFile(path, []byte(content), 0644)
+	}
+
+	// Record a build of initech with the current inputs
+	req, err := newGenerateRequest("initech", "", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	plan, err := generator.Plan(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	meta := &provenance.Metadata{Command: "generate"}
+	meta.SetStep(generator.StepConvert, plan.Convert.Inputs)
+	meta.SetStep(generator.StepExport, plan.Export.Inputs)
+	if err := provenance.Write(filepath.Join(req.AppDir, "resume.pdf"), meta); err != nil {
+		t.Fatal(err)
+	}
+
+	var out bytes.Buffer
+	if err := runList(&out, false, false); err != nil {
+		t.Fatalf("runList() error = %v", err)
+	}
+	for _, want := range []string{"acme         draft   0         not optimized", "globex       draft   1         not built", "initech      draft   1         up to date"} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("output missing %q:\n%s", want, out.String())
+		}
+	}
+
+	// A new optimized version makes initech stale
+	os.WriteFile(filepath.Join(req.AppDir, "optimized-cv-2.md"), []byte("# Jane Doe"), 0644)
+	out.Reset()
+	if err := runList(&out, true, true); err != nil {
+		t.Fatalf("runList(--json --stale) error = %v", err)
+	}
+	var entries []listEntry
+	if err := json.Unmarshal(out.Bytes(), &entries); err != nil {
+		t.Fatalf("invalid JSON: %v\n%s", err, out.String())
+	}
+	if len(entries) != 2 || entries[1].Name != "initech" || entries[1].Build != buildStale ||
+		len(entries[1].Reasons) != 1 || entries[1].Reasons[0] != "optimized CV changed" {
+		t.Errorf("stale entries = %+v", entries)
+	}
+}
+
+func TestRunList_BrokenApplication(t *testing.T) {
+	tmpDir, cleanup := setupOptimizeTest(t)
+	defer cleanup()
+
+	os.WriteFile(filepath.Join(tmpDir, "m2cv.yml"), []byte("version: 2\nbase_cv_path: ./base-cv.md\ndefault_theme: even\n"), 0644)
+	files := map[string]string{
+		"acme/optimized-cv-1.md":   "# Jane",
+		"acme/application.yml":     "theme: [unclosed\n",
+		"globex/optimized-cv-1.md": "# Jane",
+	}
+	for name, content := range files {
+		path := filepath.Join(tmpDir, "applications", filepath.FromSlash(name))
+		os.MkdirAll(filepath.Dir(path), 0755)
+		os.WriteFile(path, []byte(content), 0644)
+	}
+
+	var out bytes.Buffer
+	if err := runList(&out, true, false); err != nil {
+		t.Fatalf("runList() error = %v", err)
+	}
+	var entries []listEntry
+	if err := json.Unmarshal(out.Bytes(), &entries); err != nil {
+		t.Fatalf("invalid JSON: %v\n%s", err, out.String())
+	}
+	if len(entries) != 2 || entries[0].Build != buildError || entries[0].Error == "" || entries[1].Build != buildNotBuilt {
+		t.Errorf("entries = %+v", entries)
+	}
+}
+
+func TestRunList_Empty(t *testing.T) {
+	tmpDir, cleanup := setupOptimizeTest(t)
+	defer cleanup()
+	os.WriteFile(filepath.Join(tmpDir, "m2cv.yml"), []byte("version: 2\n"), 0644)
+
+	var out bytes.Buffer
+	if err := runList(&out, false, false); err != nil || !strings.Contains(out.String(), "No applications yet") {
+		t.Errorf("runList() = %q, %v", out.String(), err)
+	}
+}
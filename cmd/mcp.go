@@ -10,20 +10,20 @@ import (
 // newMCPCommand creates the hidden mcp subcommand for running as an MCP server.
 // This is used internally by the optimize --interactive command.
 func newMCPCommand() *cobra.Command {
-	var contextData string
+	var contextFile string
 
 	cmd := &cobra.Command{
 		Use:    "mcp",
 		Short:  "Run as MCP server (internal use)",
 		Hidden: true,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			if contextData == "" {
-				return fmt.Errorf("--context is required")
+			if contextFile == "" {
+				return fmt.Errorf("--context-file is required")
 			}
 
-			ctx, err := mcp.DecodeContext(contextData)
+			ctx, err := mcp.LoadContextFile(contextFile)
 			if err != nil {
-				return fmt.Errorf("failed to decode context: %w", err)
+				return fmt.Errorf("failed to load context: %w", err)
 			}
 
 			server := mcp.NewServer(ctx)
@@ -31,8 +31,8 @@ func newMCPCommand() *cobra.Command {
 		},
 	}
 
-	cmd.Flags().StringVar(&contextData, "context", "", "base64-encoded context data")
-	_ = cmd.MarkFlagRequired("context")
+	cmd.Flags().StringVar(&contextFile, "context-file", "", "path to the session context file")
+	_ = cmd.MarkFlagRequired("context-file")
 
 	return cmd
 }
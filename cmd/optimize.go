@@ -3,42 +3,51 @@ package cmd
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"os"
+	"os/signal"
 	"path/filepath"
-	"strings"
+	"syscall"
+	"time"
 
 	"github.com/richq/m2cv/internal/application"
-	"github.com/richq/m2cv/internal/assets"
 	"github.com/richq/m2cv/internal/config"
 	"github.com/richq/m2cv/internal/executor"
+	"github.com/richq/m2cv/internal/generator"
 	"github.com/richq/m2cv/internal/mcp"
+	"github.com/richq/m2cv/internal/progress"
+	"github.com/richq/m2cv/internal/provenance"
+	"github.com/richq/m2cv/internal/session"
 	"github.com/spf13/cobra"
 )
 
 // interactivePromptTemplate is the system prompt for interactive mode.
+// The base CV and job description are not embedded: Claude reads them through
+// the MCP tools, which keeps personal data out of the claude command line.
 const interactivePromptTemplate = `You are helping optimize a resume for a job application.
 
 I've loaded:
 - Application: %s
 
 Your task:
-1. Summarize the key requirements from the job description
-2. Discuss optimization strategy with the user
-3. When the user is satisfied, use the write_optimized_resume tool to save the final version
+1. Read the job description and base CV with get_job_description and get_base_cv
+2. Summarize the key requirements from the job description
+3. Discuss optimization strategy with the user
+4. When the user is satisfied, use the write_optimized_resume tool to save the final version
 
-%s
----
-BASE CV:
-%s
+Tools are available to iterate on real artifacts: list_versions, read_version
+and diff_versions for earlier versions, score_version to check keyword gaps
+against the job description, and generate_pdf to export a version as a PDF.
 
----
-JOB DESCRIPTION:
 %s
-
----
 Please start by summarizing the key requirements from the job description.`
 
+// resumePromptTemplate is the first message when continuing a saved session.
+const resumePromptTemplate = `We're continuing our earlier session on application %s.
+Use list_versions and read_version to catch up on the latest saved version,
+then ask me what to polish next.`
+
 // atsInstructions provides additional guidance for ATS optimization.
 const atsInstructions = `ATS OPTIMIZATION MODE:
 - Use standard section headings (Summary, Experience, Skills, Education)
@@ -55,6 +64,8 @@ func newOptimizeCommand() *cobra.Command {
 		model       string
 		atsMode     bool
 		interactive bool
+		resume      bool
+		rebase      bool
 	)
 
 	cmd := &cobra.Command{
@@ -69,7 +80,15 @@ Use --ats flag for ATS (Applicant Tracking Systems) optimization which uses
 standard section headings and includes keywords from the job description.
 
 Use --interactive flag to launch Claude in conversation mode where you can
-discuss the optimization strategy before generating the final resume.
+discuss the optimization strategy before generating the final resume. The
+session transcript is saved to the application's sessions/ folder; add
+--resume to continue the last conversation with the same tools.
+
+Use --rebase to keep your manual edits: the latest version is merged with a
+fresh optimization, using the output Claude last wrote as the common base.
+The merge is section-aware; where you and Claude changed the same bullet,
+conflict markers (<<<<<<< ||||||| ======= >>>>>>>) are written to resolve
+by hand.
 
 Output is written to a versioned file (optimized-cv-N.md) in the application folder.
 
@@ -77,119 +96,115 @@ Examples:
   m2cv optimize acme-software-engineer
   m2cv optimize --ats google-sre
   m2cv optimize --interactive my-dream-job
+  m2cv optimize --interactive --resume my-dream-job
+  m2cv optimize --rebase acme-software-engineer
   m2cv optimize -m claude-sonnet-4-20250514 my-dream-job`,
 		Args: cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
+			if resume && !interactive {
+				return fmt.Errorf("--resume requires --interactive")
+			}
+			if rebase && interactive {
+				return fmt.Errorf("--rebase cannot be combined with --interactive")
+			}
 			if interactive {
-				return runOptimizeInteractive(cmd.Context(), args[0], model, atsMode)
+				return runOptimizeInteractive(cmd.Context(), args[0], model, atsMode, resume)
 			}
-			return runOptimize(cmd.Context(), args[0], model, atsMode)
+			return runOptimize(cmd.Context(), args[0], model, atsMode, rebase)
 		},
 	}
 
 	cmd.Flags().StringVarP(&model, "model", "m", "", "override Claude model")
 	cmd.Flags().BoolVar(&atsMode, "ats", false, "optimize for ATS (Applicant Tracking Systems)")
 	cmd.Flags().BoolVarP(&interactive, "interactive", "i", false, "launch Claude in conversation mode")
+	cmd.Flags().BoolVar(&resume, "resume", false, "continue the last interactive session (with --interactive)")
+	cmd.Flags().BoolVar(&rebase, "rebase", false, "merge manual edits of the latest version into a fresh optimization")
 
 	return cmd
 }
 
 // runOptimize executes the optimize command logic.
-func runOptimize(ctx context.Context, applicationName, modelOverride string, atsMode bool) error {
-	// Validate application folder exists
-	appDir := filepath.Join("applications", applicationName)
-	if _, err := os.Stat(appDir); os.IsNotExist(err) {
-		return fmt.Errorf("application folder not found: %s. Run 'm2cv apply' first", appDir)
-	}
-
-	// Load config (required for base CV path)
-	configPath, err := config.FindWithOverrides(cfgFile, ".")
-	if err != nil {
-		return fmt.Errorf("m2cv.yml not found: %w. Run 'm2cv init' first", err)
-	}
+func runOptimize(ctx context.Context, applicationName, modelOverride string, atsMode, rebase bool) error {
+	// Cancel rather than die on Ctrl-C, so claude is stopped and the
+	// partial output path is reported
+	ctx, stop := signal.NotifyContext(ctx, os.Interrupt, syscall.SIGTERM)
+	defer stop()
 
-	configRepo := config.NewRepository()
-	cfg, err := configRepo.Load(configPath)
+	req, err := newOptimizeRequest(applicationName, modelOverride, atsMode)
 	if err != nil {
-		return fmt.Errorf("failed to load config: %w", err)
-	}
-
-	// Resolve and read base CV
-	cvPath := cfg.BaseCVPath
-	if baseCVPath != "" {
-		// Persistent flag override
-		cvPath = baseCVPath
+		return err
 	}
+	req.Rebase = rebase
 
-	// Resolve relative paths against config directory
-	if !filepath.IsAbs(cvPath) {
-		configDir := filepath.Dir(configPath)
-		cvPath = filepath.Join(configDir, cvPath)
-	}
-
-	baseCV, err := os.ReadFile(cvPath)
-	if err != nil {
-		return fmt.Errorf("failed to read base CV at %s: %w", cvPath, err)
+	// Run the pipeline: tailor via Claude, write the next version, record
+	// usage and provenance. Progress goes to stderr so long runs aren't silent.
+	req.ExecuteOptions = []executor.ExecuteOption{
+		executor.WithProgress(progress.New(os.Stderr, "Optimizing CV")),
 	}
-
-	// Find and read job description
-	txtFiles, err := filepath.Glob(filepath.Join(appDir, "*.txt"))
+	result, err := generator.Optimize(ctx, req)
 	if err != nil {
-		return fmt.Errorf("failed to search for job description: %w", err)
+		return err
 	}
-	if len(txtFiles) == 0 {
-		return fmt.Errorf("no .txt file found in %s. Job description required", appDir)
+	for _, warning := range result.Warnings {
+		fmt.Fprintf(os.Stderr, "warning: %s\n", warning)
 	}
 
-	jobDescription, err := os.ReadFile(txtFiles[0])
-	if err != nil {
-		return fmt.Errorf("failed to read job description at %s: %w", txtFiles[0], err)
+	if rebase {
+		fmt.Printf("Rebased CV written to: %s\n", result.OutputPath)
+		if result.Conflicts > 0 {
+			fmt.Printf("%d conflict(s): resolve the <<<<<<< markers in %s before generating.\n", result.Conflicts, result.OutputPath)
+		}
+		return nil
 	}
+	fmt.Printf("Optimized CV written to: %s\n", result.OutputPath)
+	return nil
+}
 
-	// Select and build prompt
-	promptName := "optimize"
-	if atsMode {
-		promptName = "optimize-ats"
+// newOptimizeRequest validates the application and config and builds the
+// pipeline request.
+func newOptimizeRequest(applicationName, modelOverride string, atsMode bool) (generator.OptimizeRequest, error) {
+	// Validate application folder exists
+	appDir := filepath.Join("applications", applicationName)
+	if _, err := os.Stat(appDir); os.IsNotExist(err) {
+		return generator.OptimizeRequest{}, fmt.Errorf("application folder not found: %s. Run 'm2cv apply' first", appDir)
 	}
 
-	promptTemplate, err := assets.GetPrompt(promptName)
+	// Load config (required for base CV path)
+	configPath, err := config.FindWithOverrides(cfgFile, ".")
 	if err != nil {
-		return fmt.Errorf("failed to load prompt template: %w", err)
-	}
-
-	prompt := strings.ReplaceAll(promptTemplate, "{{.BaseCV}}", string(baseCV))
-	prompt = strings.ReplaceAll(prompt, "{{.JobDescription}}", string(jobDescription))
-
-	// Determine model
-	model := cfg.DefaultModel
-	if modelOverride != "" {
-		model = modelOverride
+		return generator.OptimizeRequest{}, fmt.Errorf("m2cv.yml not found: %w. Run 'm2cv init' first", err)
 	}
 
-	// Execute Claude
-	exec := executor.NewClaudeExecutor()
-	var opts []executor.ExecuteOption
-	if model != "" {
-		opts = append(opts, executor.WithModel(model))
-	}
-
-	result, err := exec.Execute(ctx, prompt, opts...)
+	layered, err := loadConfig(configPath, appDir)
 	if err != nil {
-		return fmt.Errorf("failed to optimize CV: %w", err)
-	}
+		return generator.OptimizeRequest{}, err
+	}
+	cfg := layered.Config
+
+	return generator.OptimizeRequest{
+		AppDir:      appDir,
+		BaseCVPath:  resolveBaseCVPath(cfg, configPath),
+		ProjectDir:  filepath.Dir(configPath),
+		Model:       modelOverride,
+		Models:      cfg.ModelSettings(),
+		ATSMode:     atsMode,
+		ToolVersion: version,
+		ToolCommit:  commit,
+	}, nil
+}
 
-	// Write versioned output
-	outputPath, err := application.NextVersionPath(appDir)
-	if err != nil {
-		return fmt.Errorf("failed to determine output path: %w", err)
+// resolveBaseCVPath returns the base CV path: the --base-cv flag overrides
+// the config, and relative paths are resolved against the config directory.
+func resolveBaseCVPath(cfg *config.Config, configPath string) string {
+	cvPath := cfg.BaseCVPath
+	if baseCVPath != "" {
+		// Persistent flag override
+		cvPath = baseCVPath
 	}
-
-	if err := os.WriteFile(outputPath, []byte(result), 0644); err != nil {
-		return fmt.Errorf("failed to write optimized CV: %w", err)
+	if !filepath.IsAbs(cvPath) {
+		cvPath = filepath.Join(filepath.Dir(configPath), cvPath)
 	}
-
-	fmt.Printf("Optimized CV written to: %s\n", outputPath)
-	return nil
+	return cvPath
 }
 
 // mcpConfig represents the MCP configuration JSON structure.
@@ -204,7 +219,8 @@ type mcpServerConfig struct {
 }
 
 // runOptimizeInteractive runs the optimize command in interactive mode.
-func runOptimizeInteractive(ctx context.Context, applicationName, modelOverride string, atsMode bool) error {
+// With resume set, the last recorded session for the application is continued.
+func runOptimizeInteractive(ctx context.Context, applicationName, modelOverride string, atsMode, resume bool) error {
 	// Validate application folder exists
 	appDir := filepath.Join("applications", applicationName)
 	if _, err := os.Stat(appDir); os.IsNotExist(err) {
@@ -217,60 +233,96 @@ func runOptimizeInteractive(ctx context.Context, applicationName, modelOverride
 		return fmt.Errorf("m2cv.yml not found: %w. Run 'm2cv init' first", err)
 	}
 
-	configRepo := config.NewRepository()
-	cfg, err := configRepo.Load(configPath)
+	layered, err := loadConfig(configPath, appDir)
 	if err != nil {
-		return fmt.Errorf("failed to load config: %w", err)
+		return err
 	}
+	cfg := layered.Config
 
 	// Resolve and read base CV
-	cvPath := cfg.BaseCVPath
-	if baseCVPath != "" {
-		cvPath = baseCVPath
-	}
-	if !filepath.IsAbs(cvPath) {
-		configDir := filepath.Dir(configPath)
-		cvPath = filepath.Join(configDir, cvPath)
-	}
-
+	cvPath := resolveBaseCVPath(cfg, configPath)
 	baseCV, err := os.ReadFile(cvPath)
 	if err != nil {
 		return fmt.Errorf("failed to read base CV at %s: %w", cvPath, err)
 	}
 
 	// Find and read job description
-	txtFiles, err := filepath.Glob(filepath.Join(appDir, "*.txt"))
+	jdPath, err := application.FindJobDescription(appDir)
 	if err != nil {
-		return fmt.Errorf("failed to search for job description: %w", err)
-	}
-	if len(txtFiles) == 0 {
-		return fmt.Errorf("no .txt file found in %s. Job description required", appDir)
+		return err
 	}
 
-	jobDescription, err := os.ReadFile(txtFiles[0])
+	jobDescription, err := os.ReadFile(jdPath)
 	if err != nil {
-		return fmt.Errorf("failed to read job description at %s: %w", txtFiles[0], err)
+		return fmt.Errorf("failed to read job description at %s: %w", jdPath, err)
 	}
 
-	// Determine model
-	model := cfg.DefaultModel
+	// Determine model: flag > models.interactive > models.optimize > default_model
+	profile := cfg.ModelSettings().Resolve("optimize", "interactive")
 	if modelOverride != "" {
-		model = modelOverride
+		profile.Model = modelOverride
 	}
+	model := profile.Model
 
-	// Create context for MCP subprocess
-	mcpCtx := &mcp.InteractiveContext{
-		ApplicationDir: appDir,
-		BaseCV:         string(baseCV),
-		JobDescription: string(jobDescription),
-		ATSMode:        atsMode,
-		Model:          model,
+	// Name the session so its transcript can be saved and resumed
+	workDir, err := os.Getwd()
+	if err != nil {
+		return fmt.Errorf("failed to get working directory: %w", err)
+	}
+	rec := &session.Record{
+		ID:        session.NewID(),
+		WorkDir:   workDir,
+		Model:     model,
+		ATSMode:   atsMode,
+		StartedAt: time.Now().UTC(),
+	}
+	if resume {
+		last, err := session.Latest(appDir)
+		if errors.Is(err, session.ErrNoSession) {
+			return fmt.Errorf("no interactive session to resume for %s. Run 'm2cv optimize --interactive %s' first", applicationName, applicationName)
+		}
+		if err != nil {
+			return fmt.Errorf("failed to load sessions: %w", err)
+		}
+		// claude keys sessions by working directory
+		if last.WorkDir != workDir {
+			return fmt.Errorf("session %s was started in %s; run m2cv from there to resume it", last.ID, last.WorkDir)
+		}
+		rec.ID = last.ID
+		rec.Resumed = true
+	}
+
+	// Determine theme for PDF export from the session
+	theme := cfg.DefaultTheme
+	if theme == "" {
+		theme = "even"
 	}
 
-	encodedContext, err := mcpCtx.Encode()
+	// Create context for MCP subprocess
+	mcpCtx := &mcp.InteractiveContext{
+		ApplicationDir:     appDir,
+		ProjectDir:         filepath.Dir(configPath),
+		Theme:              theme,
+		ThemeOptions:       cfg.ThemeOptions,
+		BaseCV:             string(baseCV),
+		BaseCVPath:         cvPath,
+		JobDescription:     string(jobDescription),
+		JobDescriptionPath: jdPath,
+		ATSMode:            atsMode,
+		Model:              model,
+		Models:             cfg.ModelSettings(),
+		PromptSHA256:       provenance.HashBytes([]byte(interactivePromptTemplate)),
+		ToolVersion:        version,
+		ToolCommit:         commit,
+	}
+
+	// Hand the context to the MCP subprocess through a private file rather
+	// than argv; it holds the CV and would otherwise show up in ps output
+	contextPath, err := mcpCtx.WriteContextFile("")
 	if err != nil {
-		return fmt.Errorf("failed to encode context: %w", err)
+		return err
 	}
+	defer os.Remove(contextPath)
 
 	// Get our own executable path
 	execPath, err := os.Executable()
@@ -283,7 +335,7 @@ func runOptimizeInteractive(ctx context.Context, applicationName, modelOverride
 		MCPServers: map[string]mcpServerConfig{
 			"m2cv": {
 				Command: execPath,
-				Args:    []string{"mcp", "--context", encodedContext},
+				Args:    []string{"mcp", "--context-file", contextPath},
 			},
 		},
 	}
@@ -305,12 +357,24 @@ func runOptimizeInteractive(ctx context.Context, applicationName, modelOverride
 	}
 	tmpFile.Close()
 
+	// Claude owns the terminal for the session: it handles Ctrl-C itself, so
+	// m2cv must not die on SIGINT before the deferred cleanup runs. SIGTERM
+	// and SIGHUP cancel the context, which stops claude and unwinds normally.
+	interrupts := make(chan os.Signal, 1)
+	signal.Notify(interrupts, os.Interrupt)
+	defer signal.Stop(interrupts)
+	ctx, stop := signal.NotifyContext(ctx, syscall.SIGTERM, syscall.SIGHUP)
+	defer stop()
+
 	// Build system prompt
 	atsText := ""
 	if atsMode {
 		atsText = atsInstructions
 	}
-	systemPrompt := fmt.Sprintf(interactivePromptTemplate, applicationName, atsText, string(baseCV), string(jobDescription))
+	systemPrompt := fmt.Sprintf(interactivePromptTemplate, applicationName, atsText)
+	if resume {
+		systemPrompt = fmt.Sprintf(resumePromptTemplate, applicationName)
+	}
 
 	// Execute Claude interactively
 	exec := executor.NewClaudeExecutor()
@@ -318,7 +382,26 @@ func runOptimizeInteractive(ctx context.Context, applicationName, modelOverride
 		MCPConfigPath: tmpFile.Name(),
 		SystemPrompt:  systemPrompt,
 		Model:         model,
+		ExtraArgs:     profile.Args,
+		SessionID:     rec.ID,
+		Resume:        resume,
 	}
 
-	return exec.ExecuteInteractive(ctx, interactiveCfg)
+	runErr := exec.ExecuteInteractive(ctx, interactiveCfg)
+	rec.EndedAt = time.Now().UTC()
+	recordSession(appDir, rec)
+	return runErr
+}
+
+// recordSession saves the transcript and logs the session. Failures are
+// reported as warnings since the conversation itself already happened.
+func recordSession(appDir string, rec *session.Record) {
+	if err := session.SaveTranscript(appDir, rec); err != nil {
+		fmt.Fprintf(os.Stderr, "warning: transcript not saved: %v\n", err)
+	} else {
+		fmt.Fprintf(os.Stderr, "Transcript saved to: %s\n", filepath.Join(appDir, session.Dir, rec.ID+".md"))
+	}
+	if err := session.Append(appDir, rec); err != nil {
+		fmt.Fprintf(os.Stderr, "warning: failed to record session: %v\n", err)
+	}
 }
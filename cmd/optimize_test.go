@@ -1,12 +1,15 @@
 package cmd
 
 import (
+	"context"
 	"os"
 	"path/filepath"
+	"runtime"
 	"strings"
 	"testing"
 
 	"github.com/richq/m2cv/internal/assets"
+	"github.com/richq/m2cv/internal/session"
 )
 
 // setupOptimizeTest creates a temp directory and changes to it for testing.
@@ -14,6 +17,8 @@ import (
 func setupOptimizeTest(t *testing.T) (string, func()) {
 	t.Helper()
 	tmpDir := t.TempDir()
+	// Keep the user's own config layer out of the tests
+	t.Setenv("XDG_CONFIG_HOME", filepath.Join(tmpDir, ".config"))
 	origDir, _ := os.Getwd()
 	if err := os.Chdir(tmpDir); err != nil {
 		t.Fatalf("failed to chdir to temp dir: %v", err)
@@ -219,6 +224,33 @@ func TestOptimizeCommand_MissingArgument(t *testing.T) {
 	}
 }
 
+func TestOptimizeCommand_RebaseFlags(t *testing.T) {
+	// Note: Cannot use t.Parallel() - NewRootCommand writes to global vars
+	rootCmd := NewRootCommand()
+	rootCmd.AddCommand(newOptimizeCommand())
+	rootCmd.SetArgs([]string{"optimize", "--rebase", "--interactive", "acme"})
+	rootCmd.PersistentPreRunE = nil
+
+	err := rootCmd.Execute()
+	if err == nil || !strings.Contains(err.Error(), "--rebase cannot be combined with --interactive") {
+		t.Errorf("error = %v, want --rebase/--interactive conflict", err)
+	}
+}
+
+func TestOptimizeCommand_RebaseWithoutVersions(t *testing.T) {
+	tmpDir, cleanup := setupOptimizeTest(t)
+	defer cleanup()
+	os.WriteFile(filepath.Join(tmpDir, "m2cv.yml"), []byte("version: 2\nbase_cv_path: base-cv.md\n"), 0644)
+	os.WriteFile(filepath.Join(tmpDir, "base-cv.md"), []byte("# Base CV"), 0644)
+	os.MkdirAll(filepath.Join(tmpDir, "applications", "acme"), 0755)
+	os.WriteFile(filepath.Join(tmpDir, "applications", "acme", "job.txt"), []byte("Go engineer"), 0644)
+
+	err := runOptimize(context.Background(), "acme", "", false, true)
+	if err == nil || !strings.Contains(err.Error(), "nothing to rebase") {
+		t.Errorf("error = %v, want nothing to rebase", err)
+	}
+}
+
 func TestOptimizeCommand_HelpOutput(t *testing.T) {
 	// Note: Cannot use t.Parallel() - NewRootCommand writes to global vars
 	rootCmd := NewRootCommand()
@@ -411,3 +443,144 @@ func TestOptimizeCommand_ErrorOrder(t *testing.T) {
 		})
 	}
 }
+
+// writeInteractiveFixture creates a config, base CV and test-app application.
+func writeInteractiveFixture(t *testing.T, tmpDir string) {
+	t.Helper()
+	if err := os.WriteFile(filepath.Join(tmpDir, "m2cv.yml"), []byte("base_cv_path: base-cv.md\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(tmpDir, "base-cv.md"), []byte("# Secret Person, +44 7700 900000"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	appDir := filepath.Join(tmpDir, "applications", "test-app")
+	if err := os.MkdirAll(appDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(appDir, "job.txt"), []byte("Go engineer"), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// installFakeClaude puts a claude shell script first on PATH.
+func installFakeClaude(t *testing.T, script string) {
+	t.Helper()
+	binDir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(binDir, "claude"), []byte(script), 0755); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("PATH", binDir+string(os.PathListSeparator)+os.Getenv("PATH"))
+}
+
+// TestOptimizeInteractive_ContextFile verifies the session context reaches the
+// MCP server through a private file, the CV never appears in argv, and the
+// temp files are removed when the session ends.
+func TestOptimizeInteractive_ContextFile(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("fake claude is a shell script")
+	}
+	tmpDir, cleanup := setupOptimizeTest(t)
+	defer cleanup()
+
+	writeInteractiveFixture(t, tmpDir)
+
+	// Fake claude records its arguments and the context file it was pointed at
+	outDir := t.TempDir()
+	script := `#!/bin/sh
+echo "$@" > "` + outDir + `/args"
+ctx=$(sed 's/.*"--context-file","\([^"]*\)".*/\1/' "$2")
+echo "$2" > "` + outDir + `/mcp-config-path"
+echo "$ctx" > "` + outDir + `/context-path"
+ls -l "$ctx" | cut -c1-10 > "` + outDir + `/context-mode"
+cp "$ctx" "` + outDir + `/context.json"
+`
+	installFakeClaude(t, script)
+
+	if err := runOptimizeInteractive(context.Background(), "test-app", "", false, false); err != nil {
+		t.Fatalf("runOptimizeInteractive() error = %v", err)
+	}
+
+	read := func(name string) string {
+		data, err := os.ReadFile(filepath.Join(outDir, name))
+		if err != nil {
+			t.Fatalf("fake claude did not record %s: %v", name, err)
+		}
+		return strings.TrimSpace(string(data))
+	}
+
+	if args := read("args"); strings.Contains(args, "Secret Person") || strings.Contains(args, "Go engineer") {
+		t.Errorf("claude argv contains CV or job description: %s", args)
+	}
+	if mode := read("context-mode"); mode != "-rw-------" {
+		t.Errorf("context file mode = %s, want -rw-------", mode)
+	}
+	if ctx := read("context.json"); !strings.Contains(ctx, "Secret Person") {
+		t.Errorf("context file missing base CV: %s", ctx)
+	}
+	for _, name := range []string{"context-path", "mcp-config-path"} {
+		if _, err := os.Stat(read(name)); !os.IsNotExist(err) {
+			t.Errorf("%s was not removed after the session (stat err = %v)", name, err)
+		}
+	}
+}
+
+// TestOptimizeInteractive_SaveAndResume verifies the transcript is copied into
+// the application and --resume continues the same claude session.
+func TestOptimizeInteractive_SaveAndResume(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("fake claude is a shell script")
+	}
+	tmpDir, cleanup := setupOptimizeTest(t)
+	defer cleanup()
+	writeInteractiveFixture(t, tmpDir)
+	t.Setenv("CLAUDE_CONFIG_DIR", t.TempDir())
+
+	// Fake claude appends a turn to its transcript, keyed like the real CLI
+	outDir := t.TempDir()
+	installFakeClaude(t, `#!/bin/sh
+while [ $# -gt 0 ]; do
+  case "$1" in
+    --session-id|--resume) mode="$1"; id="$2"; shift ;;
+  esac
+  shift
+done
+echo "$mode $id" >> "`+outDir+`/calls"
+dir="$CLAUDE_CONFIG_DIR/projects/$(pwd | sed 's/[^a-zA-Z0-9]/-/g')"
+mkdir -p "$dir"
+echo '{"type":"user","message":{"role":"user","content":"turn"}}' >> "$dir/$id.jsonl"
+`)
+
+	appDir := filepath.Join("applications", "test-app")
+	if err := runOptimizeInteractive(context.Background(), "test-app", "", false, true); err == nil || !strings.Contains(err.Error(), "no interactive session to resume") {
+		t.Errorf("resume without a session error = %v", err)
+	}
+
+	if err := runOptimizeInteractive(context.Background(), "test-app", "", false, false); err != nil {
+		t.Fatalf("first session error = %v", err)
+	}
+	if err := runOptimizeInteractive(context.Background(), "test-app", "", false, true); err != nil {
+		t.Fatalf("resumed session error = %v", err)
+	}
+
+	records, err := session.Load(appDir)
+	if err != nil || len(records) != 2 {
+		t.Fatalf("session.Load() = %d records, %v", len(records), err)
+	}
+	id := records[0].ID
+	if records[1].ID != id || !records[1].Resumed {
+		t.Errorf("resume should continue session %s, got %+v", id, records[1])
+	}
+
+	calls, _ := os.ReadFile(filepath.Join(outDir, "calls"))
+	if want := "--session-id " + id + "\n--resume " + id + "\n"; string(calls) != want {
+		t.Errorf("claude calls = %q, want %q", calls, want)
+	}
+
+	md, err := os.ReadFile(filepath.Join(appDir, session.Dir, id+".md"))
+	if err != nil {
+		t.Fatalf("readable transcript missing: %v", err)
+	}
+	if strings.Count(string(md), "## User") != 2 {
+		t.Errorf("saved transcript should include both sessions:\n%s", md)
+	}
+}
@@ -0,0 +1,206 @@
+package cmd
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/richq/m2cv/internal/executor"
+	"github.com/richq/m2cv/internal/generator"
+	"github.com/richq/m2cv/internal/outdated"
+	"github.com/richq/m2cv/internal/preflight"
+	"github.com/richq/m2cv/internal/progress"
+	"github.com/spf13/cobra"
+)
+
+// outdatedOptions are the flags of m2cv outdated.
+type outdatedOptions struct {
+	jsonOutput bool
+	reoptimize bool
+	yes        bool
+	model      string
+}
+
+// reoptimizeFunc updates one application; swapped out in tests.
+var reoptimizeFunc = reoptimizeApplication
+
+// newOutdatedCommand creates the outdated subcommand.
+func newOutdatedCommand() *cobra.Command {
+	var opts outdatedOptions
+
+	cmd := &cobra.Command{
+		Use:   "outdated",
+		Short: "List applications tailored from an older base CV",
+		Long: `List the applications whose latest optimized CV was tailored from an
+older version of the base CV, with a section-by-section summary of what
+changed in the base CV since.
+
+Every optimized version records the hash of the base CV it was tailored
+from, and m2cv keeps a snapshot of each base CV in .m2cv/snapshots, so
+the old and current base CVs can be compared. Versions written before
+snapshots were kept are listed without a summary.
+
+With --reoptimize, m2cv offers to update each outdated application: the
+latest version, including any edits made by hand, is revised for the base
+CV changes only, and written as a new version. Pass --yes to update all
+of them without asking.
+
+Examples:
+  m2cv outdated
+  m2cv outdated --json
+  m2cv outdated --reoptimize
+  m2cv outdated --reoptimize --yes -m opus`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runOutdated(cmd.Context(), cmd.InOrStdin(), cmd.OutOrStdout(), opts)
+		},
+	}
+
+	cmd.Flags().BoolVar(&opts.jsonOutput, "json", false, "print the outdated applications as JSON")
+	cmd.Flags().BoolVar(&opts.reoptimize, "reoptimize", false, "offer to update each outdated application for the base CV changes")
+	cmd.Flags().BoolVarP(&opts.yes, "yes", "y", false, "update without asking (with --reoptimize)")
+	cmd.Flags().StringVarP(&opts.model, "model", "m", "", "override Claude model (with --reoptimize)")
+
+	return cmd
+}
+
+// runOutdated executes the outdated command logic.
+func runOutdated(ctx context.Context, in io.Reader, out io.Writer, opts outdatedOptions) error {
+	if opts.jsonOutput && opts.reoptimize {
+		return fmt.Errorf("--json cannot be combined with --reoptimize")
+	}
+
+	configPath, err := findConfig()
+	if err != nil {
+		return err
+	}
+	layered, err := loadConfig(configPath, "")
+	if err != nil {
+		return err
+	}
+	baseCVPath := resolveBaseCVPath(layered.Config, configPath)
+	baseCV, err := os.ReadFile(baseCVPath)
+	if err != nil {
+		return fmt.Errorf("failed to read base CV at %s: %w", baseCVPath, err)
+	}
+
+	apps, err := outdated.Find("applications", filepath.Dir(configPath), baseCV)
+	if err != nil {
+		return err
+	}
+
+	if opts.jsonOutput {
+		if apps == nil {
+			apps = []outdated.Application{}
+		}
+		data, err := json.MarshalIndent(apps, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to marshal applications: %w", err)
+		}
+		fmt.Fprintln(out, string(data))
+		return nil
+	}
+
+	if len(apps) == 0 {
+		fmt.Fprintf(out, "All applications are up to date with %s.\n", filepath.Base(baseCVPath))
+		return nil
+	}
+	for _, app := range apps {
+		printOutdated(out, app)
+	}
+
+	if !opts.reoptimize {
+		fmt.Fprintf(out, "\n%d application(s) tailored from an older %s. Run 'm2cv outdated --reoptimize' to update them, keeping manual edits.\n", len(apps), filepath.Base(baseCVPath))
+		return nil
+	}
+	if err := preflight.CheckClaude(); err != nil {
+		return err
+	}
+
+	fmt.Fprintln(out)
+	answers := bufio.NewReader(in)
+	failed := 0
+	for _, app := range apps {
+		if app.PreviousBaseCV == nil {
+			fmt.Fprintf(out, "Skipping %s: the base CV it was tailored from was not kept, so its changes are unknown. Run 'm2cv optimize %s' to tailor it from scratch.\n", app.Name, app.Name)
+			continue
+		}
+		if !opts.yes && !confirm(answers, out, fmt.Sprintf("Update %s for the base CV changes?", app.Name)) {
+			continue
+		}
+		path, err := reoptimizeFunc(ctx, app, opts.model)
+		if err != nil {
+			fmt.Fprintf(out, "Failed to update %s: %v\n", app.Name, err)
+			failed++
+			continue
+		}
+		fmt.Fprintf(out, "Updated CV written to: %s\n", path)
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d update(s) failed", failed)
+	}
+	return nil
+}
+
+// printOutdated prints an outdated application and the base CV changes.
+func printOutdated(out io.Writer, app outdated.Application) {
+	fmt.Fprintf(out, "%s: %s", app.Name, filepath.Base(app.LatestPath))
+	if !app.TailoredAt.IsZero() {
+		fmt.Fprintf(out, ", tailored %s", app.TailoredAt.Local().Format("2006-01-02"))
+	}
+	if app.Edited {
+		fmt.Fprint(out, " (edited since)")
+	}
+	fmt.Fprintln(out)
+
+	if app.PreviousBaseCV == nil {
+		fmt.Fprintln(out, "  base CV changes unknown: no snapshot of the base CV it was tailored from")
+		return
+	}
+	if len(app.Changes) == 0 {
+		fmt.Fprintln(out, "  base CV changed only in whitespace")
+	}
+	for _, change := range app.Changes {
+		fmt.Fprintf(out, "  %s\n", change)
+	}
+}
+
+// confirm asks a yes/no question on out and reads the answer from in.
+// Anything but y or yes, including end of input, is no.
+func confirm(in *bufio.Reader, out io.Writer, question string) bool {
+	fmt.Fprintf(out, "%s [y/N] ", question)
+	answer, _ := in.ReadString('\n')
+	switch strings.ToLower(strings.TrimSpace(answer)) {
+	case "y", "yes":
+		return true
+	}
+	return false
+}
+
+// reoptimizeApplication updates the latest optimized CV of app for the
+// base CV changes and returns the new version's path.
+func reoptimizeApplication(ctx context.Context, app outdated.Application, model string) (string, error) {
+	req, err := newOptimizeRequest(app.Name, model, app.ATSMode)
+	if err != nil {
+		return "", err
+	}
+	req.PreviousPath = app.LatestPath
+	req.PreviousBaseCV = app.PreviousBaseCV
+	req.ExecuteOptions = []executor.ExecuteOption{
+		executor.WithProgress(progress.New(os.Stderr, "Updating "+app.Name)),
+	}
+
+	result, err := generator.Optimize(ctx, req)
+	if err != nil {
+		return "", err
+	}
+	for _, warning := range result.Warnings {
+		fmt.Fprintf(os.Stderr, "warning: %s\n", warning)
+	}
+	return result.OutputPath, nil
+}
@@ -0,0 +1,163 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/richq/m2cv/internal/application"
+	"github.com/richq/m2cv/internal/outdated"
+	"github.com/richq/m2cv/internal/provenance"
+)
+
+const (
+	outdatedOldBase = "# Experience\n\n## Developer | Acme\n"
+	outdatedNewBase = "# Experience\n\n## Staff Engineer | Initech\n\n## Developer | Acme\n"
+)
+
+// setupOutdatedTest creates a project with the new base CV and two
+// applications tailored from older ones: acme (snapshot kept) and
+// umbrella (no snapshot). It replaces reoptimizeFunc with a stub that
+// records the updated applications.
+func setupOutdatedTest(t *testing.T) *[]string {
+	t.Helper()
+	tmpDir, cleanup := setupOptimizeTest(t)
+	t.Cleanup(cleanup)
+	installFakeClaude(t, "#!/bin/sh\nexit 0\n")
+
+	os.WriteFile(filepath.Join(tmpDir, "m2cv.yml"), []byte("version: 2\nbase_cv_path: base-cv.md\n"), 0644)
+	os.WriteFile(filepath.Join(tmpDir, "base-cv.md"), []byte(outdatedNewBase), 0644)
+	if _, err := provenance.SaveSnapshot(tmpDir, []byte(outdatedOldBase)); err != nil {
+		t.Fatal(err)
+	}
+	for name, base := range map[string]string{
+		"acme":     outdatedOldBase,
+		"globex":   outdatedNewBase,
+		"umbrella": "# Lost base\n",
+	} {
+		appDir := filepath.Join(tmpDir, "applications", name)
+		os.MkdirAll(appDir, 0755)
+		path := application.VersionPath(appDir, 1)
+		os.WriteFile(path, []byte("# "+name+" CV\n"), 0644)
+		meta := &provenance.Metadata{Command: "optimize"}
+		meta.AddInput(provenance.RoleBaseCV, "base-cv.md", []byte(base))
+		if err := meta.AddOutput(path); err != nil {
+			t.Fatal(err)
+		}
+		if err := provenance.Write(path, meta); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	var updated []string
+	orig := reoptimizeFunc
+	reoptimizeFunc = func(ctx context.Context, app outdated.Application, model string) (string, error) {
+		if string(app.PreviousBaseCV) != outdatedOldBase {
+			return "", errors.New("wrong previous base CV")
+		}
+		updated = append(updated, app.Name)
+		return application.VersionPath(filepath.Join("applications", app.Name), 2), nil
+	}
+	t.Cleanup(func() { reoptimizeFunc = orig })
+	return &updated
+}
+
+func TestRunOutdated_Report(t *testing.T) {
+	updated := setupOutdatedTest(t)
+
+	var out bytes.Buffer
+	if err := runOutdated(context.Background(), strings.NewReader(""), &out, outdatedOptions{}); err != nil {
+		t.Fatalf("runOutdated() error = %v", err)
+	}
+	got := out.String()
+	for _, want := range []string{
+		"acme: optimized-cv-1.md",
+		`  Experience: added "Staff Engineer | Initech"`,
+		"umbrella: optimized-cv-1.md",
+		"base CV changes unknown",
+		"2 application(s) tailored from an older base-cv.md",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("output missing %q:\n%s", want, got)
+		}
+	}
+	if strings.Contains(got, "globex") {
+		t.Errorf("up-to-date application listed:\n%s", got)
+	}
+	if len(*updated) != 0 {
+		t.Errorf("updated without --reoptimize: %v", *updated)
+	}
+}
+
+func TestRunOutdated_JSON(t *testing.T) {
+	setupOutdatedTest(t)
+
+	var out bytes.Buffer
+	if err := runOutdated(context.Background(), nil, &out, outdatedOptions{jsonOutput: true}); err != nil {
+		t.Fatalf("runOutdated() error = %v", err)
+	}
+	for _, want := range []string{`"name": "acme"`, `"name": "umbrella"`, `"title": "Experience"`} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("JSON missing %s:\n%s", want, out.String())
+		}
+	}
+
+	err := runOutdated(context.Background(), nil, &out, outdatedOptions{jsonOutput: true, reoptimize: true})
+	if err == nil {
+		t.Error("--json with --reoptimize should fail")
+	}
+}
+
+func TestRunOutdated_Reoptimize(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		yes   bool
+		want  []string
+	}{
+		{name: "confirmed", input: "y\n", want: []string{"acme"}},
+		{name: "declined", input: "n\n"},
+		{name: "no input", input: ""},
+		{name: "yes flag", yes: true, want: []string{"acme"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			updated := setupOutdatedTest(t)
+
+			var out bytes.Buffer
+			opts := outdatedOptions{reoptimize: true, yes: tt.yes}
+			if err := runOutdated(context.Background(), strings.NewReader(tt.input), &out, opts); err != nil {
+				t.Fatalf("runOutdated() error = %v", err)
+			}
+			if strings.Join(*updated, ",") != strings.Join(tt.want, ",") {
+				t.Errorf("updated = %v, want %v", *updated, tt.want)
+			}
+			if !strings.Contains(out.String(), "Skipping umbrella") {
+				t.Errorf("umbrella (no snapshot) not skipped:\n%s", out.String())
+			}
+			asked := strings.Contains(out.String(), "Update acme for the base CV changes? [y/N]")
+			if asked == tt.yes {
+				t.Errorf("asked = %v with --yes = %v", asked, tt.yes)
+			}
+		})
+	}
+}
+
+func TestRunOutdated_UpToDate(t *testing.T) {
+	tmpDir, cleanup := setupOptimizeTest(t)
+	defer cleanup()
+	os.WriteFile(filepath.Join(tmpDir, "m2cv.yml"), []byte("version: 2\nbase_cv_path: base-cv.md\n"), 0644)
+	os.WriteFile(filepath.Join(tmpDir, "base-cv.md"), []byte(outdatedNewBase), 0644)
+
+	var out bytes.Buffer
+	if err := runOutdated(context.Background(), nil, &out, outdatedOptions{}); err != nil {
+		t.Fatalf("runOutdated() error = %v", err)
+	}
+	if !strings.Contains(out.String(), "All applications are up to date") {
+		t.Errorf("output = %q", out.String())
+	}
+}
@@ -0,0 +1,123 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"syscall"
+
+	"github.com/richq/m2cv/internal/config"
+	"github.com/richq/m2cv/internal/generator"
+	"github.com/richq/m2cv/internal/preflight"
+	"github.com/richq/m2cv/internal/preview"
+	themepkg "github.com/richq/m2cv/internal/theme"
+	"github.com/spf13/cobra"
+)
+
+// newPreviewCommand creates the preview subcommand.
+func newPreviewCommand() *cobra.Command {
+	var (
+		addr  string
+		theme string
+		model string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "preview <application-name>",
+		Short: "Serve a live HTML preview of the resume",
+		Long: `Start a local web server that renders the application's resume.json in
+a JSON Resume theme, and reloads the page whenever it changes.
+
+If resume.json is missing or was converted from an older optimized CV, the
+latest optimized CV is converted first via Claude. While the server runs,
+editing the latest optimized CV converts it again, and changes to
+resume.json or the theme reload every open page. Conversion errors are
+shown in the page and the terminal; the server keeps running.
+
+Pages:
+  /                      the resume in the configured theme
+  /?theme=flat           the resume in another installed or local theme
+  /compare               every installed theme side by side
+  /compare?themes=a,b    chosen themes side by side
+
+No PDF is exported. Ctrl-C stops the server.
+
+Examples:
+  m2cv preview acme-software-engineer
+  m2cv preview --addr 127.0.0.1:9000 --theme flat acme-software-engineer`,
+		Args: cobra.ExactArgs(1),
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			configPath, err := config.FindWithOverrides(cfgFile, ".")
+			if err != nil {
+				// Config not found - will be reported in RunE, skip preflight
+				return nil
+			}
+			return preflight.CheckResumed(filepath.Dir(configPath))
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runPreview(cmd.Context(), args[0], addr, theme, model)
+		},
+	}
+
+	cmd.Flags().StringVar(&addr, "addr", "127.0.0.1:8810", "address to serve the preview on")
+	cmd.Flags().StringVar(&theme, "theme", "", "override JSON Resume theme")
+	cmd.Flags().StringVarP(&model, "model", "m", "", "override Claude model for conversion")
+
+	return cmd
+}
+
+// runPreview serves the preview until SIGINT or SIGTERM.
+func runPreview(ctx context.Context, applicationName, addr, themeOverride, modelOverride string) error {
+	req, err := newGenerateRequest(applicationName, themeOverride, modelOverride)
+	if err != nil {
+		return err
+	}
+
+	ctx, stop := signal.NotifyContext(ctx, os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	server := preview.New(preview.Config{
+		AppDir:       req.AppDir,
+		ProjectDir:   req.ProjectDir,
+		Theme:        req.Theme,
+		ThemeOptions: req.ThemeOptions,
+		Log:          os.Stderr,
+		Themes: func() ([]string, error) {
+			// Reloaded on every request so newly added themes show up
+			_, cfg, err := loadThemeConfig()
+			if err != nil {
+				return nil, err
+			}
+			return themepkg.Available(req.ProjectDir, cfg)
+		},
+		Convert: func(ctx context.Context) error {
+			runReq := req
+			runReq.ExecuteOptions = generateProgress()
+			result, err := generator.Convert(ctx, runReq)
+			if err != nil {
+				return err
+			}
+			for _, warning := range result.Warnings {
+				fmt.Fprintf(os.Stderr, "warning: %s\n", warning)
+			}
+			return nil
+		},
+	})
+
+	go func() {
+		server.Refresh(ctx)
+		server.Watch(ctx)
+	}()
+
+	err = server.ListenAndServe(ctx, addr, func(url string) {
+		fmt.Fprintf(os.Stderr, "Previewing %s at %s (Ctrl-C to stop)\n", applicationName, url)
+		fmt.Fprintf(os.Stderr, "Compare themes at %scompare\n", url)
+	})
+	if err != nil {
+		return err
+	}
+	fmt.Fprintln(os.Stderr, "Preview stopped.")
+	return nil
+}
@@ -0,0 +1,34 @@
+package cmd
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestPreviewCommand_Structure(t *testing.T) {
+	t.Parallel()
+
+	cmd := newPreviewCommand()
+	if !strings.HasPrefix(cmd.Use, "preview ") {
+		t.Errorf("wrong Use: %q", cmd.Use)
+	}
+	for _, name := range []string{"addr", "theme", "model"} {
+		if cmd.Flags().Lookup(name) == nil {
+			t.Errorf("missing --%s flag", name)
+		}
+	}
+	if got := cmd.Flags().Lookup("addr").DefValue; !strings.HasPrefix(got, "127.0.0.1:") {
+		t.Errorf("preview should bind to loopback by default, got %s", got)
+	}
+}
+
+func TestRunPreview_MissingApplication(t *testing.T) {
+	_, cleanup := setupOptimizeTest(t)
+	defer cleanup()
+
+	err := runPreview(context.Background(), "nope", "127.0.0.1:0", "", "")
+	if err == nil || !strings.Contains(err.Error(), "application folder not found") {
+		t.Errorf("expected missing application error, got %v", err)
+	}
+}
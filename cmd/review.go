@@ -0,0 +1,150 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"time"
+
+	"github.com/richq/m2cv/internal/application"
+	"github.com/richq/m2cv/internal/diff"
+	"github.com/richq/m2cv/internal/generator"
+	"github.com/richq/m2cv/internal/tui"
+	"github.com/spf13/cobra"
+)
+
+// reviewFunc runs the interactive review; swapped out in tests.
+var reviewFunc = tui.RunReview
+
+// newReviewCommand creates the review subcommand.
+func newReviewCommand() *cobra.Command {
+	var from, to int
+
+	cmd := &cobra.Command{
+		Use:   "review <application-name>",
+		Short: "Accept or reject the changes of a new version hunk by hunk",
+		Long: `Review the changes between the previous and the latest optimized version,
+one hunk at a time, grouped by CV section.
+
+Each hunk can be accepted, rejected or edited. When every hunk is decided,
+the result is written as a new version, so you keep control over Claude's
+rewrites without editing the file by hand. The versions reviewed are left
+untouched.
+
+Keys:
+  y / n         accept / reject the hunk
+  e             edit the hunk's new text (ctrl+s keep, esc cancel)
+  A / R         accept / reject every undecided hunk
+  ←/→           previous / next hunk
+  [ / ]         previous / next section
+  w             write the new version
+  q             quit without writing
+
+Examples:
+  m2cv review acme-software-engineer
+  m2cv review --from 1 --to 3 acme-software-engineer`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runReview(cmd.Context(), cmd.OutOrStdout(), args[0], from, to)
+		},
+	}
+
+	cmd.Flags().IntVar(&from, "from", 0, "version to compare against (default: the one before --to)")
+	cmd.Flags().IntVar(&to, "to", 0, "version to review (default: latest)")
+
+	return cmd
+}
+
+// runReview executes the review command logic.
+func runReview(ctx context.Context, out io.Writer, applicationName string, from, to int) error {
+	appDir := filepath.Join("applications", applicationName)
+	if _, err := os.Stat(appDir); os.IsNotExist(err) {
+		return fmt.Errorf("application folder not found: %s. Run 'm2cv apply' first", appDir)
+	}
+
+	versions, err := application.ListVersions(appDir)
+	if err != nil {
+		return err
+	}
+	from, to, err = reviewVersions(versions, from, to)
+	if err != nil {
+		return fmt.Errorf("%s: %w", applicationName, err)
+	}
+	fromPath, toPath := application.VersionPath(appDir, from), application.VersionPath(appDir, to)
+
+	oldText, err := os.ReadFile(fromPath)
+	if err != nil {
+		return fmt.Errorf("failed to read optimized CV at %s: %w", fromPath, err)
+	}
+	newText, err := os.ReadFile(toPath)
+	if err != nil {
+		return fmt.Errorf("failed to read optimized CV at %s: %w", toPath, err)
+	}
+
+	review := diff.NewReview(string(oldText), string(newText))
+	if len(review.Hunks) == 0 {
+		fmt.Fprintf(out, "%s and %s are identical; nothing to review.\n", filepath.Base(fromPath), filepath.Base(toPath))
+		return nil
+	}
+
+	startedAt := time.Now()
+	title := fmt.Sprintf("%s: %s → %s", applicationName, filepath.Base(fromPath), filepath.Base(toPath))
+	write, err := reviewFunc(ctx, title, review)
+	if err != nil {
+		return err
+	}
+	if !write {
+		fmt.Fprintln(out, "Review cancelled; nothing written.")
+		return nil
+	}
+
+	result, err := generator.SaveReview(generator.ReviewRequest{
+		AppDir:      appDir,
+		FromPath:    fromPath,
+		ToPath:      toPath,
+		Text:        review.Text(),
+		StartedAt:   startedAt,
+		ToolVersion: version,
+		ToolCommit:  commit,
+	})
+	if err != nil {
+		return err
+	}
+	for _, warning := range result.Warnings {
+		fmt.Fprintf(os.Stderr, "warning: %s\n", warning)
+	}
+	fmt.Fprintf(out, "Reviewed CV written to: %s (%d accepted, %d rejected, %d edited)\n",
+		result.OutputPath, review.Count(diff.Accepted), review.Count(diff.Rejected), review.Count(diff.Edited))
+	return nil
+}
+
+// reviewVersions resolves --from and --to against the application's
+// versions: to defaults to the latest and from to the one before to.
+func reviewVersions(versions []int, from, to int) (int, int, error) {
+	if len(versions) == 0 {
+		return 0, 0, fmt.Errorf("no optimized versions to review")
+	}
+	if to == 0 {
+		to = versions[len(versions)-1]
+	}
+	i := slices.Index(versions, to)
+	if i < 0 {
+		return 0, 0, fmt.Errorf("version %d not found", to)
+	}
+	if from == 0 {
+		if i == 0 {
+			return 0, 0, fmt.Errorf("optimized-cv-%d.md is the first version; nothing to compare it with", to)
+		}
+		from = versions[i-1]
+	}
+	if !slices.Contains(versions, from) {
+		return 0, 0, fmt.Errorf("version %d not found", from)
+	}
+	if from == to {
+		return 0, 0, fmt.Errorf("--from and --to are both version %d", to)
+	}
+	return from, to, nil
+}
@@ -0,0 +1,122 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/richq/m2cv/internal/application"
+	"github.com/richq/m2cv/internal/diff"
+)
+
+// setupReviewTest creates an application with the given versions and
+// replaces reviewFunc with one that applies choices in order and reports
+// write.
+func setupReviewTest(t *testing.T, versions map[int]string, write bool, choices ...diff.Choice) string {
+	t.Helper()
+	tmpDir, cleanup := setupOptimizeTest(t)
+	t.Cleanup(cleanup)
+
+	appDir := filepath.Join(tmpDir, "applications", "acme")
+	os.MkdirAll(appDir, 0755)
+	for n, content := range versions {
+		os.WriteFile(application.VersionPath(appDir, n), []byte(content), 0644)
+	}
+
+	orig := reviewFunc
+	reviewFunc = func(ctx context.Context, title string, review *diff.Review) (bool, error) {
+		for i := range review.Hunks {
+			if i < len(choices) {
+				review.Hunks[i].Choice = choices[i]
+			}
+		}
+		return write, nil
+	}
+	t.Cleanup(func() { reviewFunc = orig })
+	return appDir
+}
+
+func TestRunReview_WritesNewVersion(t *testing.T) {
+	appDir := setupReviewTest(t, map[int]string{
+		1: "# Summary\n\nDeveloper.\n\n# Skills\n\nGo\n",
+		2: "# Summary\n\nGo developer.\n\n# Skills\n\nGo, SQL\n",
+	}, true, diff.Accepted, diff.Rejected)
+
+	var out bytes.Buffer
+	if err := runReview(context.Background(), &out, "acme", 0, 0); err != nil {
+		t.Fatalf("runReview() error = %v", err)
+	}
+	if !strings.Contains(out.String(), "optimized-cv-3.md (1 accepted, 1 rejected, 0 edited)") {
+		t.Errorf("output = %q", out.String())
+	}
+	got, err := os.ReadFile(application.VersionPath(appDir, 3))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "# Summary\n\nGo developer.\n\n# Skills\n\nGo\n"; string(got) != want {
+		t.Errorf("reviewed CV = %q, want %q", got, want)
+	}
+}
+
+func TestRunReview_Cancelled(t *testing.T) {
+	appDir := setupReviewTest(t, map[int]string{1: "# CV\n", 2: "# New CV\n"}, false)
+
+	var out bytes.Buffer
+	if err := runReview(context.Background(), &out, "acme", 0, 0); err != nil {
+		t.Fatalf("runReview() error = %v", err)
+	}
+	if !strings.Contains(out.String(), "nothing written") {
+		t.Errorf("output = %q", out.String())
+	}
+	if versions, _ := application.ListVersions(appDir); len(versions) != 2 {
+		t.Errorf("cancelled review wrote a version: %v", versions)
+	}
+}
+
+func TestRunReview_Identical(t *testing.T) {
+	setupReviewTest(t, map[int]string{1: "# CV\n", 3: "# CV\n"}, true)
+
+	var out bytes.Buffer
+	if err := runReview(context.Background(), &out, "acme", 0, 0); err != nil {
+		t.Fatalf("runReview() error = %v", err)
+	}
+	if !strings.Contains(out.String(), "optimized-cv-1.md and optimized-cv-3.md are identical") {
+		t.Errorf("output = %q", out.String())
+	}
+}
+
+func TestReviewVersions(t *testing.T) {
+	tests := []struct {
+		name             string
+		versions         []int
+		from, to         int
+		wantFrom, wantTo int
+		wantErr          string
+	}{
+		{name: "defaults", versions: []int{1, 2, 4}, wantFrom: 2, wantTo: 4},
+		{name: "to", versions: []int{1, 2, 4}, to: 2, wantFrom: 1, wantTo: 2},
+		{name: "from and to", versions: []int{1, 2, 4}, from: 1, to: 4, wantFrom: 1, wantTo: 4},
+		{name: "no versions", wantErr: "no optimized versions"},
+		{name: "single version", versions: []int{1}, wantErr: "first version"},
+		{name: "missing to", versions: []int{1, 2}, to: 3, wantErr: "version 3 not found"},
+		{name: "missing from", versions: []int{1, 2}, from: 5, wantErr: "version 5 not found"},
+		{name: "same", versions: []int{1, 2}, from: 2, wantErr: "both version 2"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			from, to, err := reviewVersions(tt.versions, tt.from, tt.to)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Errorf("error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil || from != tt.wantFrom || to != tt.wantTo {
+				t.Errorf("reviewVersions() = %d, %d, %v; want %d, %d", from, to, err, tt.wantFrom, tt.wantTo)
+			}
+		})
+	}
+}
@@ -34,10 +34,27 @@ themed PDF using resumed.
 The pipeline: Job Description + Base CV -> Claude AI -> JSON Resume -> PDF`,
 		SilenceUsage: true,
 		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
-			// Skip preflight for non-functional commands, init (which only needs npm),
-			// and mcp (internal use, doesn't need claude check)
-			switch cmd.Name() {
-			case "version", "help", "completion", "init", "mcp":
+			// Subcommands are matched by group.
+			name := cmd.Name()
+			if cmd.HasParent() && cmd.Parent() != cmd.Root() {
+				name = cmd.Parent().Name()
+			}
+
+			// Warn about typos in m2cv.yml, except for commands that don't use
+			// it or (config, doctor) report problems themselves.
+			switch name {
+			case "version", "help", "completion", "init", "config", "doctor":
+			default:
+				warnConfig(cmd.ErrOrStderr())
+			}
+
+			// Skip preflight for non-functional commands, init and theme (which only
+			// need npm), mcp/serve-mcp/ui (actions report a missing claude themselves),
+			// usage/show/list (read-only reports), review (no Claude involved),
+			// outdated (which checks claude before updating), config, and
+			// doctor (which checks claude itself).
+			switch name {
+			case "version", "help", "completion", "init", "theme", "mcp", "serve-mcp", "ui", "usage", "show", "list", "review", "outdated", "config", "doctor":
 				return nil
 			}
 			return preflight.CheckClaude()
@@ -61,7 +78,19 @@ func Execute() {
 	rootCmd.AddCommand(newApplyCommand())
 	rootCmd.AddCommand(newOptimizeCommand())
 	rootCmd.AddCommand(newGenerateCommand())
+	rootCmd.AddCommand(newBuildCommand())
+	rootCmd.AddCommand(newPreviewCommand())
+	rootCmd.AddCommand(newThemeCommand())
 	rootCmd.AddCommand(newMCPCommand())
+	rootCmd.AddCommand(newServeMCPCommand())
+	rootCmd.AddCommand(newUICommand())
+	rootCmd.AddCommand(newUsageCommand())
+	rootCmd.AddCommand(newShowCommand())
+	rootCmd.AddCommand(newListCommand())
+	rootCmd.AddCommand(newOutdatedCommand())
+	rootCmd.AddCommand(newReviewCommand())
+	rootCmd.AddCommand(newDoctorCommand())
+	rootCmd.AddCommand(newConfigCommand())
 
 	if err := rootCmd.Execute(); err != nil {
 		os.Exit(1)
@@ -0,0 +1,166 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"syscall"
+
+	"github.com/richq/m2cv/internal/mcp"
+	"github.com/spf13/cobra"
+)
+
+// newServeMCPCommand creates the serve-mcp subcommand.
+func newServeMCPCommand() *cobra.Command {
+	var (
+		httpAddr string
+		baseURL  string
+		token    string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "serve-mcp",
+		Short: "Serve the whole project to MCP clients",
+		Long: `Run an MCP server exposing every application in the project.
+
+By default the server speaks MCP over stdio for a single client. Use --http
+to run one long-lived server over HTTP with server-sent events that several
+clients can connect to (endpoint: http://<addr>/sse). Protect it with
+--token (or M2CV_MCP_TOKEN); clients then send "Authorization: Bearer <token>".
+Behind a reverse proxy, set --base-url to the URL clients reach the server
+at; a wildcard bind such as :8808 otherwise tells clients to post messages
+relative to the URL they connected to.
+Ctrl-C shuts the HTTP server down gracefully.
+
+The server finds m2cv.yml (walking up from the current directory, or via
+--config) and serves the applications folder next to it. Any MCP-capable
+client can then drive the whole workflow:
+
+  Resources  m2cv://applications, m2cv://base-cv,
+             m2cv://applications/{name}/job-description,
+             m2cv://applications/{name}/versions/{version|latest},
+             m2cv://applications/{name}/resume.json
+  Tools      list_applications, apply, optimize, generate_pdf, update_status,
+             write_optimized_resume, list_versions, read_version,
+             score_version, diff_versions
+  Prompts    tailor_cv, improve_version
+
+Example client configuration:
+  {"mcpServers": {"m2cv": {"command": "m2cv", "args": ["serve-mcp", "--config", "/path/to/m2cv.yml"]}}}
+
+Examples:
+  m2cv serve-mcp
+  m2cv serve-mcp --http 127.0.0.1:8808
+  M2CV_MCP_TOKEN=s3cret m2cv serve-mcp --http 127.0.0.1:8808
+  m2cv serve-mcp --http :8808 --base-url https://cv.example.com --token s3cret`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			pctx, err := loadProjectContext()
+			if err != nil {
+				return err
+			}
+			server := mcp.NewProjectServer(pctx)
+			if httpAddr == "" {
+				return server.Serve()
+			}
+			if token == "" {
+				// Read here rather than as the flag default so --help never prints it
+				token = os.Getenv("M2CV_MCP_TOKEN")
+			}
+			return runServeMCPHTTP(cmd.Context(), server, httpAddr, baseURL, token)
+		},
+	}
+
+	cmd.Flags().StringVar(&httpAddr, "http", "", "serve over HTTP/SSE on this address (e.g. 127.0.0.1:8808) instead of stdio")
+	cmd.Flags().StringVar(&baseURL, "base-url", "", "URL clients reach the HTTP server at, e.g. behind a proxy (default: the --http address)")
+	cmd.Flags().StringVar(&token, "token", "", "bearer token required from HTTP clients (default: $M2CV_MCP_TOKEN)")
+
+	return cmd
+}
+
+// runServeMCPHTTP serves the project over HTTP/SSE until SIGINT or SIGTERM.
+func runServeMCPHTTP(ctx context.Context, server *mcp.Server, addr, baseURL, token string) error {
+	ctx, stop := signal.NotifyContext(ctx, os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	if token == "" && !isLoopback(addr) {
+		fmt.Fprintf(os.Stderr, "warning: serving on %s without --token; anyone who can reach it can read your CV\n", addr)
+	}
+
+	return server.ServeHTTP(ctx, mcp.HTTPConfig{
+		Addr:    addr,
+		BaseURL: baseURL,
+		Token:   token,
+		Ready: func(endpoint string) {
+			fmt.Fprintf(os.Stderr, "m2cv MCP server listening on %s (Ctrl-C to stop)\n", endpoint)
+		},
+	})
+}
+
+// isLoopback reports whether addr binds only to a loopback interface.
+func isLoopback(addr string) bool {
+	host, _, err := net.SplitHostPort(addr)
+	if err != nil {
+		return false
+	}
+	if host == "localhost" {
+		return true
+	}
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
+}
+
+// loadProjectContext finds and loads m2cv.yml and describes the project for
+// the MCP server. Applications are resolved next to the config file rather
+// than the working directory, since MCP clients launch servers from anywhere.
+// Each application's application.yml is read when a tool or prompt uses it,
+// so it builds the same as with 'm2cv optimize' and 'm2cv generate'.
+func loadProjectContext() (*mcp.ProjectContext, error) {
+	configPath, err := findConfig()
+	if err != nil {
+		return nil, err
+	}
+	configPath, err = filepath.Abs(configPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve config path: %w", err)
+	}
+
+	pctx, err := projectContext(configPath, "")
+	if err != nil {
+		return nil, err
+	}
+	pctx.ForApplication = func(appDir string) (*mcp.ProjectContext, error) {
+		return projectContext(configPath, appDir)
+	}
+	return pctx, nil
+}
+
+// projectContext loads the settings in effect for appDir ("" for the
+// project alone) into a ProjectContext.
+func projectContext(configPath, appDir string) (*mcp.ProjectContext, error) {
+	layered, err := loadConfig(configPath, appDir)
+	if err != nil {
+		return nil, err
+	}
+	cfg := layered.Config
+	projectDir := filepath.Dir(configPath)
+
+	theme := cfg.DefaultTheme
+	if theme == "" {
+		theme = "even"
+	}
+
+	return &mcp.ProjectContext{
+		ProjectDir:      projectDir,
+		ApplicationsDir: filepath.Join(projectDir, "applications"),
+		BaseCVPath:      resolveBaseCVPath(cfg, configPath),
+		Theme:           theme,
+		ThemeOptions:    cfg.ThemeOptions,
+		Models:          cfg.ModelSettings(),
+		ToolVersion:     version,
+		ToolCommit:      commit,
+	}, nil
+}
@@ -0,0 +1,99 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestServeMCPCommand_Structure(t *testing.T) {
+	t.Parallel()
+
+	cmd := newServeMCPCommand()
+	if cmd.Use != "serve-mcp" {
+		t.Errorf("wrong Use: %q", cmd.Use)
+	}
+	if cmd.Hidden {
+		t.Error("serve-mcp should be visible")
+	}
+}
+
+func TestLoadProjectContext(t *testing.T) {
+	tmpDir, cleanup := setupOptimizeTest(t)
+	defer cleanup()
+
+	if _, err := loadProjectContext(); err == nil || !strings.Contains(err.Error(), "m2cv.yml not found") {
+		t.Errorf("expected missing config error, got %v", err)
+	}
+
+	configContent := "base_cv_path: cv/base.md\ndefault_model: sonnet\n"
+	if err := os.WriteFile(filepath.Join(tmpDir, "m2cv.yml"), []byte(configContent), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	// Launched from a subdirectory, paths still resolve against the project
+	sub := filepath.Join(tmpDir, "applications", "acme")
+	if err := os.MkdirAll(sub, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chdir(sub); err != nil {
+		t.Fatal(err)
+	}
+
+	pctx, err := loadProjectContext()
+	if err != nil {
+		t.Fatalf("loadProjectContext() error = %v", err)
+	}
+	root, _ := filepath.EvalSymlinks(tmpDir)
+	if got, _ := filepath.EvalSymlinks(pctx.ApplicationsDir); got != filepath.Join(root, "applications") {
+		t.Errorf("ApplicationsDir = %s", pctx.ApplicationsDir)
+	}
+	if !filepath.IsAbs(pctx.BaseCVPath) || !strings.HasSuffix(pctx.BaseCVPath, filepath.Join("cv", "base.md")) {
+		t.Errorf("BaseCVPath = %s", pctx.BaseCVPath)
+	}
+	if pctx.Theme != "even" || pctx.Models.Default != "sonnet" {
+		t.Errorf("unexpected defaults: theme=%q model=%q", pctx.Theme, pctx.Models.Default)
+	}
+
+	// Each application's application.yml applies to its tools
+	if err := os.WriteFile(filepath.Join(sub, "application.yml"), []byte("default_model: opus\ndefault_theme: flat\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	actx, err := pctx.ForApplication(filepath.Join(pctx.ApplicationsDir, "acme"))
+	if err != nil {
+		t.Fatalf("ForApplication() error = %v", err)
+	}
+	if actx.Theme != "flat" || actx.Models.Default != "opus" || actx.BaseCVPath != pctx.BaseCVPath {
+		t.Errorf("application settings: theme=%q model=%q base=%s", actx.Theme, actx.Models.Default, actx.BaseCVPath)
+	}
+}
+
+func TestServeMCPCommand_HTTPFlags(t *testing.T) {
+	t.Setenv("M2CV_MCP_TOKEN", "from-env")
+
+	cmd := newServeMCPCommand()
+	if cmd.Flags().Lookup("http") == nil {
+		t.Error("missing --http flag")
+	}
+	if got := cmd.Flags().Lookup("token").DefValue; got != "" {
+		t.Errorf("--token default = %q; the env token must not leak into --help", got)
+	}
+}
+
+func TestIsLoopback(t *testing.T) {
+	tests := map[string]bool{
+		"127.0.0.1:8808": true,
+		"localhost:8808": true,
+		"[::1]:8808":     true,
+		":8808":          false,
+		"0.0.0.0:8808":   false,
+		"192.168.1.5:80": false,
+		"garbage":        false,
+	}
+	for addr, want := range tests {
+		if got := isLoopback(addr); got != want {
+			t.Errorf("isLoopback(%q) = %v, want %v", addr, got, want)
+		}
+	}
+}
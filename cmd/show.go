@@ -0,0 +1,165 @@
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	"github.com/richq/m2cv/internal/application"
+	"github.com/richq/m2cv/internal/provenance"
+	"github.com/spf13/cobra"
+)
+
+// newShowCommand creates the show subcommand.
+func newShowCommand() *cobra.Command {
+	var jsonOutput bool
+
+	cmd := &cobra.Command{
+		Use:   "show <application-name> <version>",
+		Short: "Show how a generated artifact was produced",
+		Long: `Show the provenance recorded for a generated artifact.
+
+Every optimized CV and every export gets a sidecar metadata file
+(e.g. optimized-cv-3.meta.json, resume.meta.json) recording input hashes,
+the prompt, model, ATS/interactive mode, timestamps and the m2cv version.
+
+The version argument is one of:
+  N        optimized-cv-N.md
+  latest   the highest optimized CV version
+  export   the last resume.json/resume.pdf export
+
+Examples:
+  m2cv show acme-software-engineer 3
+  m2cv show acme-software-engineer latest
+  m2cv show --json acme-software-engineer export`,
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runShow(cmd.OutOrStdout(), args[0], args[1], jsonOutput)
+		},
+	}
+
+	cmd.Flags().BoolVar(&jsonOutput, "json", false, "print the raw metadata as JSON")
+
+	return cmd
+}
+
+// runShow executes the show command logic.
+func runShow(out io.Writer, applicationName, version string, jsonOutput bool) error {
+	appDir := filepath.Join("applications", applicationName)
+	if _, err := os.Stat(appDir); os.IsNotExist(err) {
+		return fmt.Errorf("application folder not found: %s. Run 'm2cv apply' first", appDir)
+	}
+
+	artifactPath, err := resolveArtifact(appDir, version)
+	if err != nil {
+		return err
+	}
+
+	meta, err := provenance.Read(artifactPath)
+	if errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("no metadata recorded for %s", artifactPath)
+	}
+	if err != nil {
+		return err
+	}
+
+	if jsonOutput {
+		data, err := json.MarshalIndent(meta, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to marshal metadata: %w", err)
+		}
+		fmt.Fprintln(out, string(data))
+		return nil
+	}
+
+	printMetadata(out, artifactPath, meta)
+	return nil
+}
+
+// resolveArtifact maps a show version argument to an artifact path.
+func resolveArtifact(appDir, version string) (string, error) {
+	switch version {
+	case "export":
+		return filepath.Join(appDir, "resume.pdf"), nil
+	case "latest":
+		path, err := application.LatestVersionPath(appDir)
+		if err != nil {
+			return "", fmt.Errorf("failed to find optimized CV: %w", err)
+		}
+		if path == "" {
+			return "", fmt.Errorf("no optimized CV found in %s", appDir)
+		}
+		return path, nil
+	}
+
+	n, err := strconv.Atoi(version)
+	if err != nil || n < 1 {
+		return "", fmt.Errorf("invalid version %q: use a version number, 'latest' or 'export'", version)
+	}
+	return application.VersionPath(appDir, n), nil
+}
+
+// printMetadata writes a human-readable view of artifact metadata.
+func printMetadata(out io.Writer, artifactPath string, meta *provenance.Metadata) {
+	fmt.Fprintf(out, "Artifact:  %s\n", artifactPath)
+	fmt.Fprintf(out, "Command:   %s\n", meta.Command)
+	fmt.Fprintf(out, "Created:   %s\n", meta.CreatedAt.Local().Format(time.RFC3339))
+	if !meta.StartedAt.IsZero() {
+		fmt.Fprintf(out, "Duration:  %s\n", meta.CreatedAt.Sub(meta.StartedAt).Round(time.Second))
+	}
+
+	toolVersion := meta.ToolVersion
+	if meta.ToolCommit != "" {
+		toolVersion += " (" + meta.ToolCommit + ")"
+	}
+	fmt.Fprintf(out, "m2cv:      %s\n", toolVersion)
+
+	model := meta.Model
+	if model == "" {
+		model = "(default)"
+	}
+	fmt.Fprintf(out, "Model:     %s\n", model)
+	mode := "standard"
+	if meta.ATSMode {
+		mode = "ATS"
+	}
+	if meta.Interactive {
+		mode += ", interactive"
+	}
+	fmt.Fprintf(out, "Mode:      %s\n", mode)
+	if meta.Theme != "" {
+		fmt.Fprintf(out, "Theme:     %s\n", meta.Theme)
+	}
+	if meta.Prompt != nil {
+		fmt.Fprintf(out, "Prompt:    %s %s\n", meta.Prompt.Name, shortHash(meta.Prompt.SHA256))
+	}
+
+	if len(meta.Inputs) > 0 {
+		fmt.Fprintln(out, "Inputs:")
+		for _, f := range meta.Inputs {
+			fmt.Fprintf(out, "  %-16s %s %s\n", f.Role, f.Path, shortHash(f.SHA256))
+		}
+	}
+	if len(meta.Outputs) > 0 {
+		fmt.Fprintln(out, "Outputs:")
+		for _, f := range meta.Outputs {
+			fmt.Fprintf(out, "  %s %s\n", f.Path, shortHash(f.SHA256))
+		}
+	}
+}
+
+// shortHash abbreviates a hex hash for display.
+func shortHash(sum string) string {
+	if len(sum) > 12 {
+		sum = sum[:12]
+	}
+	if sum == "" {
+		return ""
+	}
+	return "sha256:" + sum
+}
@@ -0,0 +1,100 @@
+package cmd
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/richq/m2cv/internal/provenance"
+)
+
+func TestShowCommand_Structure(t *testing.T) {
+	t.Parallel()
+
+	cmd := newShowCommand()
+	if cmd.Use != "show <application-name> <version>" {
+		t.Errorf("wrong Use: %q", cmd.Use)
+	}
+	if cmd.Flags().Lookup("json") == nil {
+		t.Error("missing --json flag")
+	}
+}
+
+func TestRunShow(t *testing.T) {
+	tmpDir, cleanup := setupOptimizeTest(t)
+	defer cleanup()
+
+	appDir := filepath.Join(tmpDir, "applications", "acme")
+	if err := os.MkdirAll(appDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	cvPath := filepath.Join("applications", "acme", "optimized-cv-2.md")
+	if err := os.WriteFile(cvPath, []byte("# CV"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	meta := &provenance.Metadata{Command: "optimize", ToolVersion: version, StartedAt: time.Now().UTC()}
+	meta.AddInput(provenance.RoleBaseCV, "base-cv.md", []byte("base"))
+	meta.Prompt = &provenance.Prompt{Name: "optimize-ats", SHA256: provenance.HashBytes([]byte("p"))}
+	meta.Model = "sonnet"
+	meta.ATSMode = true
+	if err := meta.AddOutput(cvPath); err != nil {
+		t.Fatal(err)
+	}
+	if err := provenance.Write(cvPath, meta); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, version := range []string{"2", "latest"} {
+		var out bytes.Buffer
+		if err := runShow(&out, "acme", version, false); err != nil {
+			t.Fatalf("runShow(%s) error = %v", version, err)
+		}
+		for _, want := range []string{"optimized-cv-2.md", "optimize-ats", "sonnet", "Mode:      ATS", "base_cv", "sha256:"} {
+			if !strings.Contains(out.String(), want) {
+				t.Errorf("runShow(%s) output missing %q:\n%s", version, want, out.String())
+			}
+		}
+	}
+
+	var out bytes.Buffer
+	if err := runShow(&out, "acme", "2", true); err != nil {
+		t.Fatalf("runShow(--json) error = %v", err)
+	}
+	if !strings.Contains(out.String(), `"command": "optimize"`) {
+		t.Errorf("JSON output missing command:\n%s", out.String())
+	}
+}
+
+func TestRunShow_Errors(t *testing.T) {
+	tmpDir, cleanup := setupOptimizeTest(t)
+	defer cleanup()
+
+	var out bytes.Buffer
+	if err := runShow(&out, "missing", "1", false); err == nil || !strings.Contains(err.Error(), "application folder not found") {
+		t.Errorf("expected missing application error, got %v", err)
+	}
+
+	if err := os.MkdirAll(filepath.Join(tmpDir, "applications", "acme"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		version     string
+		errContains string
+	}{
+		{"zero", "invalid version"},
+		{"0", "invalid version"},
+		{"latest", "no optimized CV found"},
+		{"3", "no metadata recorded"},
+		{"export", "no metadata recorded"},
+	}
+	for _, tt := range tests {
+		err := runShow(&out, "acme", tt.version, false)
+		if err == nil || !strings.Contains(err.Error(), tt.errContains) {
+			t.Errorf("runShow(%s) error = %v, want to contain %q", tt.version, err, tt.errContains)
+		}
+	}
+}
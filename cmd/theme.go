@@ -0,0 +1,430 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/richq/m2cv/internal/config"
+	"github.com/richq/m2cv/internal/executor"
+	"github.com/richq/m2cv/internal/gallery"
+	"github.com/richq/m2cv/internal/generator"
+	"github.com/richq/m2cv/internal/preflight"
+	themepkg "github.com/richq/m2cv/internal/theme"
+	"github.com/spf13/cobra"
+)
+
+// newThemeCommand creates the theme command group.
+func newThemeCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "theme",
+		Short: "Manage JSON Resume themes",
+		Long: `Install, remove and update JSON Resume themes and keep m2cv.yml in sync.
+
+A theme can be any npm package or a local directory:
+  even                           jsonresume-theme-even
+  @acme/jsonresume-theme-brand   a scoped package
+  npm:fancy-resume               a package outside the naming convention
+  ./themes/brand                 a local theme (stored as file:themes/brand)
+
+Append @<version> to pin a version when adding (e.g. even@1.2.0). Local
+paths are relative to the directory containing m2cv.yml; use
+'m2cv theme new' to start one.`,
+	}
+
+	cmd.AddCommand(newThemeListCommand())
+	cmd.AddCommand(newThemeAddCommand())
+	cmd.AddCommand(newThemeRemoveCommand())
+	cmd.AddCommand(newThemeUpdateCommand())
+	cmd.AddCommand(newThemeNewCommand())
+	cmd.AddCommand(newThemeGalleryCommand())
+	return cmd
+}
+
+// newThemeListCommand creates the theme list subcommand.
+func newThemeListCommand() *cobra.Command {
+	var jsonOutput bool
+
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "List configured and installed themes",
+		Long: `List the themes configured in m2cv.yml and whether each is installed in
+node_modules, followed by installed themes that are not configured.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runThemeList(cmd.OutOrStdout(), jsonOutput)
+		},
+	}
+
+	cmd.Flags().BoolVar(&jsonOutput, "json", false, "print the themes as JSON")
+	return cmd
+}
+
+// newThemeAddCommand creates the theme add subcommand.
+func newThemeAddCommand() *cobra.Command {
+	var makeDefault bool
+
+	cmd := &cobra.Command{
+		Use:   "add <theme>...",
+		Short: "Install themes and add them to m2cv.yml",
+		Example: `  m2cv theme add flat macchiato
+  m2cv theme add --default @acme/jsonresume-theme-brand@^2
+  m2cv theme add npm:fancy-resume
+  m2cv theme add ./themes/brand`,
+		Args: cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runThemeChange(cmd.Context(), cmd.OutOrStdout(), func(ctx context.Context, m *themepkg.Manager, cfg *config.Config) (string, error) {
+				refs, err := m.Add(ctx, cfg, args, makeDefault)
+				if err != nil {
+					return "", err
+				}
+				msg := fmt.Sprintf("Added %v", refs)
+				if cfg.DefaultTheme == refs[0] {
+					msg += fmt.Sprintf(" (default: %s)", refs[0])
+				}
+				return msg, nil
+			})
+		},
+	}
+
+	cmd.Flags().BoolVar(&makeDefault, "default", false, "make the first theme the default")
+	return cmd
+}
+
+// newThemeRemoveCommand creates the theme remove subcommand.
+func newThemeRemoveCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:     "remove <theme>...",
+		Aliases: []string{"rm"},
+		Short:   "Uninstall themes and remove them from m2cv.yml",
+		Long: `Uninstall npm themes and remove them from m2cv.yml. Local themes are only
+removed from m2cv.yml; their directories are left alone.`,
+		Args: cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runThemeChange(cmd.Context(), cmd.OutOrStdout(), func(ctx context.Context, m *themepkg.Manager, cfg *config.Config) (string, error) {
+				refs, err := m.Remove(ctx, cfg, args)
+				if err != nil {
+					return "", err
+				}
+				return fmt.Sprintf("Removed %v", refs), nil
+			})
+		},
+	}
+}
+
+// newThemeUpdateCommand creates the theme update subcommand.
+func newThemeUpdateCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "update [theme]...",
+		Short: "Update themes to the newest allowed versions",
+		Long: `Update the given themes, or every configured theme, with npm update.
+Local themes get the dependencies in their package.json installed.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runThemeChange(cmd.Context(), cmd.OutOrStdout(), func(ctx context.Context, m *themepkg.Manager, cfg *config.Config) (string, error) {
+				refs, err := m.Update(ctx, cfg, args)
+				if err != nil {
+					return "", err
+				}
+				return fmt.Sprintf("Updated %v", refs), nil
+			})
+		},
+	}
+}
+
+// newThemeNewCommand creates the theme new subcommand.
+func newThemeNewCommand() *cobra.Command {
+	var (
+		dir         string
+		makeDefault bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "new <name>",
+		Short: "Scaffold a local theme to customise",
+		Long: `Create a minimal, dependency-free JSON Resume theme in <dir>/<name> (relative
+to the directory containing m2cv.yml) and add it to m2cv.yml as a local
+theme. Edit its index.js and style.css, and watch the result with
+'m2cv preview --theme file:<dir>/<name> <application>'.`,
+		Example: `  m2cv theme new brand
+  m2cv theme new --default --dir design brand`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runThemeChange(cmd.Context(), cmd.OutOrStdout(), func(ctx context.Context, m *themepkg.Manager, cfg *config.Config) (string, error) {
+				return runThemeNew(ctx, m, cfg, args[0], dir, makeDefault)
+			})
+		},
+	}
+
+	cmd.Flags().StringVar(&dir, "dir", "themes", "directory to create the theme in")
+	cmd.Flags().BoolVar(&makeDefault, "default", false, "make the new theme the default")
+	return cmd
+}
+
+// runThemeNew scaffolds a local theme and adds it to the config.
+func runThemeNew(ctx context.Context, m *themepkg.Manager, cfg *config.Config, name, dir string, makeDefault bool) (string, error) {
+	ref, err := themepkg.Scaffold(m.ProjectDir(), dir, name)
+	if err != nil {
+		return "", err
+	}
+	if _, err := m.Add(ctx, cfg, []string{ref}, makeDefault); err != nil {
+		return "", err
+	}
+	msg := fmt.Sprintf("Created theme %s in %s", ref, themepkg.LocalPath(m.ProjectDir(), ref))
+	if cfg.DefaultTheme == ref {
+		msg += " (default)"
+	}
+	return msg, nil
+}
+
+// newThemeGalleryCommand creates the theme gallery subcommand.
+func newThemeGalleryCommand() *cobra.Command {
+	var (
+		workers int
+		themes  []string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "gallery <application-name>",
+		Short: "Render an application's resume in every installed theme",
+		Long: `Export the application's resume.json as a PDF in every installed theme
+and configured local theme (or the themes given with --themes) into its gallery/ folder, and write
+gallery/index.html linking them with their sizes and page counts.
+
+Exports run in parallel, at most --jobs at a time. No Claude call is made;
+run 'm2cv generate' first to produce resume.json.`,
+		Example: `  m2cv theme gallery acme-software-engineer
+  m2cv theme gallery --themes even,flat --jobs 2 acme-software-engineer`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runThemeGallery(cmd.Context(), cmd.OutOrStdout(), args[0], themes, workers)
+		},
+	}
+
+	cmd.Flags().IntVarP(&workers, "jobs", "j", gallery.DefaultWorkers, "number of themes to export at once")
+	cmd.Flags().StringSliceVar(&themes, "themes", nil, "themes to render (default: all installed and local)")
+	return cmd
+}
+
+// newGalleryExporter creates the PDF exporter for galleries; swapped out in tests.
+var newGalleryExporter = func() (gallery.Exporter, error) {
+	return generator.NewExporter()
+}
+
+// runThemeGallery builds the theme gallery for an application.
+func runThemeGallery(ctx context.Context, out io.Writer, applicationName string, themes []string, workers int) error {
+	appDir := filepath.Join("applications", applicationName)
+	if _, err := os.Stat(appDir); os.IsNotExist(err) {
+		return fmt.Errorf("application folder not found: %s. Run 'm2cv apply' first", appDir)
+	}
+	jsonPath := filepath.Join(appDir, "resume.json")
+	if _, err := os.Stat(jsonPath); err != nil {
+		return fmt.Errorf("no resume.json in %s. Run 'm2cv generate %s' first", appDir, applicationName)
+	}
+
+	configPath, cfg, err := loadThemeConfig()
+	if err != nil {
+		return err
+	}
+	projectDir := filepath.Dir(configPath)
+	if err := preflight.CheckResumed(projectDir); err != nil {
+		return err
+	}
+
+	if len(themes) == 0 {
+		themes, err = themepkg.Available(projectDir, cfg)
+		if err != nil {
+			return err
+		}
+		if len(themes) == 0 {
+			return fmt.Errorf("no themes installed. Add one with 'm2cv theme add <theme>'")
+		}
+	}
+
+	style, err := themepkg.LoadStyle(projectDir, cfg.ThemeOptions, appDir)
+	if err != nil {
+		return fmt.Errorf("failed to load theme options: %w", err)
+	}
+
+	exporter, err := newGalleryExporter()
+	if err != nil {
+		return fmt.Errorf("failed to initialize exporter: %w", err)
+	}
+
+	outDir := filepath.Join(appDir, "gallery")
+	fmt.Fprintf(out, "Rendering %d theme(s) into %s...\n", len(themes), outDir)
+	entries, err := gallery.Build(ctx, gallery.Options{
+		JSONPath:   jsonPath,
+		OutDir:     outDir,
+		ProjectDir: projectDir,
+		Themes:     themes,
+		Style:      style,
+		Workers:    workers,
+		Exporter:   exporter,
+		OnDone: func(e gallery.Entry) {
+			if e.Err != nil {
+				fmt.Fprintf(out, "  %s: failed: %v\n", e.Theme, e.Err)
+				return
+			}
+			pages := "? pages"
+			if e.Pages > 0 {
+				pages = fmt.Sprintf("%d page(s)", e.Pages)
+			}
+			fmt.Fprintf(out, "  %s: %s, %d KB (%s)\n", e.Theme, pages, (e.Size+1023)/1024, e.Duration.Round(100*time.Millisecond))
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	failed := 0
+	for _, e := range entries {
+		if e.Err != nil {
+			failed++
+		}
+	}
+	fmt.Fprintf(out, "Gallery written to: %s\n", filepath.Join(outDir, gallery.IndexFile))
+	if failed == len(entries) {
+		return fmt.Errorf("all %d theme(s) failed to export", failed)
+	}
+	if failed > 0 {
+		fmt.Fprintf(out, "%d of %d theme(s) failed; see the index for details\n", failed, len(entries))
+	}
+	return nil
+}
+
+// newThemeManager is the npm-backed manager constructor; swapped out in tests.
+var newThemeManager = func(projectDir string) (*themepkg.Manager, error) {
+	npm, err := executor.NewNPMExecutor()
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize npm: %w", err)
+	}
+	return themepkg.NewManager(projectDir, npm), nil
+}
+
+// loadThemeConfig finds m2cv.yml and loads the settings in effect across
+// every layer, so the default theme matches the one generate uses.
+func loadThemeConfig() (string, *config.Config, error) {
+	configPath, err := findConfig()
+	if err != nil {
+		return "", nil, err
+	}
+	layered, err := loadConfig(configPath, "")
+	if err != nil {
+		return "", nil, err
+	}
+	return configPath, layered.Config, nil
+}
+
+// runThemeList prints the project's themes.
+func runThemeList(out io.Writer, jsonOutput bool) error {
+	configPath, cfg, err := loadThemeConfig()
+	if err != nil {
+		return err
+	}
+
+	// Listing only reads node_modules, so it doesn't need npm
+	infos, err := themepkg.NewManager(filepath.Dir(configPath), nil).List(cfg)
+	if err != nil {
+		return err
+	}
+
+	if jsonOutput {
+		if infos == nil {
+			infos = []themepkg.Info{}
+		}
+		enc := json.NewEncoder(out)
+		enc.SetIndent("", "  ")
+		return enc.Encode(infos)
+	}
+
+	if len(infos) == 0 {
+		fmt.Fprintln(out, "No themes configured or installed. Add one with 'm2cv theme add <theme>'.")
+		return nil
+	}
+
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(w, "Theme\tSource\tStatus\t\n")
+	for _, info := range infos {
+		name := info.Ref
+		if info.Default {
+			name += " (default)"
+		}
+		var status string
+		switch {
+		case info.Configured && info.Installed:
+			status = "installed"
+		case info.Configured && info.Path != "":
+			status = "directory missing"
+		case info.Configured:
+			status = "not installed - run 'm2cv theme add " + info.Ref + "'"
+		default:
+			status = "installed, not in m2cv.yml"
+		}
+		source := info.Package
+		if info.Path != "" {
+			source = info.Path
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t\n", name, source, status)
+	}
+	return w.Flush()
+}
+
+// runThemeChange runs an npm-backed change and saves m2cv.yml if it succeeds.
+// The change sees the project's themes and the effective default theme, so
+// a default set in another layer is neither removed nor overridden unless
+// asked. Only the settings the change touched are written to the project
+// file, through the document, so comments, unknown keys and key order
+// survive as with 'm2cv config set'.
+func runThemeChange(ctx context.Context, out io.Writer, change func(ctx context.Context, m *themepkg.Manager, cfg *config.Config) (string, error)) error {
+	configPath, effective, err := loadThemeConfig()
+	if err != nil {
+		return err
+	}
+	doc, err := config.LoadDocument(configPath)
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+	cfg, err := doc.Config()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+	cfg.DefaultTheme = effective.DefaultTheme
+	themes := slices.Clone(cfg.Themes)
+
+	m, err := newThemeManager(filepath.Dir(configPath))
+	if err != nil {
+		return err
+	}
+
+	msg, err := change(ctx, m, cfg)
+	if err != nil {
+		return err
+	}
+	changed := false
+	if !slices.Equal(cfg.Themes, themes) {
+		if err := doc.Set("themes", strings.Join(cfg.Themes, ",")); err != nil {
+			return err
+		}
+		changed = true
+	}
+	if cfg.DefaultTheme != effective.DefaultTheme {
+		if err := doc.Set("default_theme", cfg.DefaultTheme); err != nil {
+			return err
+		}
+		changed = true
+	}
+	if changed {
+		if err := doc.Save(configPath); err != nil {
+			return fmt.Errorf("failed to save config: %w", err)
+		}
+	}
+	fmt.Fprintln(out, msg)
+	return nil
+}
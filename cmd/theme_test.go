@@ -0,0 +1,318 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/richq/m2cv/internal/config"
+	"github.com/richq/m2cv/internal/gallery"
+	themepkg "github.com/richq/m2cv/internal/theme"
+)
+
+// fakeThemeNPM simulates npm by creating and removing node_modules folders.
+type fakeThemeNPM struct {
+	commands []string
+}
+
+func (f *fakeThemeNPM) run(dir, command string, packages []string) error {
+	f.commands = append(f.commands, command+" "+strings.Join(packages, " "))
+	for _, pkg := range packages {
+		ref, _ := themepkg.SplitVersion(pkg)
+		path := filepath.Join(dir, "node_modules", ref)
+		if command == "uninstall" {
+			os.RemoveAll(path)
+		} else if err := os.MkdirAll(path, 0755); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (f *fakeThemeNPM) Install(ctx context.Context, dir string, packages ...string) error {
+	return f.run(dir, "install", packages)
+}
+
+func (f *fakeThemeNPM) Uninstall(ctx context.Context, dir string, packages ...string) error {
+	return f.run(dir, "uninstall", packages)
+}
+
+func (f *fakeThemeNPM) Update(ctx context.Context, dir string, packages ...string) error {
+	return f.run(dir, "update", packages)
+}
+
+func (f *fakeThemeNPM) CheckInstalled(ctx context.Context, dir string, pkg string) (bool, error) {
+	return false, nil
+}
+
+func (f *fakeThemeNPM) Init(ctx context.Context, dir string) error {
+	return nil
+}
+
+// setupThemeTest creates a project with m2cv.yml and a fake npm.
+func setupThemeTest(t *testing.T, configContent string) (string, *fakeThemeNPM) {
+	t.Helper()
+	tmpDir, cleanup := setupOptimizeTest(t)
+	t.Cleanup(cleanup)
+	if err := os.WriteFile(filepath.Join(tmpDir, "m2cv.yml"), []byte(configContent), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	npm := &fakeThemeNPM{}
+	orig := newThemeManager
+	newThemeManager = func(projectDir string) (*themepkg.Manager, error) {
+		return themepkg.NewManager(projectDir, npm), nil
+	}
+	t.Cleanup(func() { newThemeManager = orig })
+	return tmpDir, npm
+}
+
+// runThemeCmd executes a theme subcommand and returns its output.
+func runThemeCmd(t *testing.T, args ...string) (string, error) {
+	t.Helper()
+	cmd := newThemeCommand()
+	var out bytes.Buffer
+	cmd.SetOut(&out)
+	cmd.SetErr(&out)
+	cmd.SetArgs(args)
+	err := cmd.Execute()
+	return out.String(), err
+}
+
+func TestThemeCommand_AddListRemove(t *testing.T) {
+	tmpDir, npm := setupThemeTest(t, "default_theme: even\nthemes:\n  - even\n")
+
+	out, err := runThemeCmd(t, "add", "flat", "@acme/jsonresume-theme-brand@^2")
+	if err != nil {
+		t.Fatalf("theme add error = %v", err)
+	}
+	if !strings.Contains(out, "Added [flat @acme/jsonresume-theme-brand]") {
+		t.Errorf("add output = %q", out)
+	}
+	if npm.commands[0] != "install jsonresume-theme-flat @acme/jsonresume-theme-brand@^2" {
+		t.Errorf("npm = %q", npm.commands[0])
+	}
+
+	cfg, err := config.NewRepository().Load(filepath.Join(tmpDir, "m2cv.yml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Join(cfg.Themes, ",") != "even,flat,@acme/jsonresume-theme-brand" || cfg.DefaultTheme != "even" {
+		t.Errorf("config not updated: %+v", cfg)
+	}
+
+	out, err = runThemeCmd(t, "list")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{"even (default)", "not installed - run 'm2cv theme add even'", "@acme/jsonresume-theme-brand", "flat"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("list output missing %q:\n%s", want, out)
+		}
+	}
+
+	if _, err := runThemeCmd(t, "remove", "even"); err == nil || !strings.Contains(err.Error(), "cannot remove the default theme") {
+		t.Errorf("removing default should fail, got %v", err)
+	}
+
+	if _, err := runThemeCmd(t, "rm", "flat"); err != nil {
+		t.Fatalf("theme rm error = %v", err)
+	}
+	out, _ = runThemeCmd(t, "list", "--json")
+	var infos []themepkg.Info
+	if err := json.Unmarshal([]byte(out), &infos); err != nil {
+		t.Fatalf("invalid JSON: %v\n%s", err, out)
+	}
+	if len(infos) != 2 || infos[1].Ref != "@acme/jsonresume-theme-brand" || !infos[1].Installed {
+		t.Errorf("themes after remove = %+v", infos)
+	}
+}
+
+func TestThemeCommand_AddKeepsComments(t *testing.T) {
+	tmpDir, _ := setupThemeTest(t, "# Project settings\ndefault_theme: even # house style\nthemes:\n  - even\nfuture_key: kept\n")
+
+	if _, err := runThemeCmd(t, "add", "flat"); err != nil {
+		t.Fatalf("theme add error = %v", err)
+	}
+	data, err := os.ReadFile(filepath.Join(tmpDir, "m2cv.yml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := "# Project settings\ndefault_theme: even # house style\nthemes:\n  - even\n  - flat\nfuture_key: kept\n"
+	if string(data) != want {
+		t.Errorf("m2cv.yml =\n%s\nwant\n%s", data, want)
+	}
+}
+
+func TestThemeCommand_UserDefault(t *testing.T) {
+	tmpDir, _ := setupThemeTest(t, "themes:\n  - flat\n")
+	userConfig := filepath.Join(tmpDir, ".config", "m2cv", "config.yml")
+	os.MkdirAll(filepath.Dir(userConfig), 0755)
+	if err := os.WriteFile(userConfig, []byte("default_theme: even\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	out, err := runThemeCmd(t, "list")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(out, "even (default)") || strings.Contains(out, "flat (default)") {
+		t.Errorf("list should mark the user-level default:\n%s", out)
+	}
+
+	// Adding a theme leaves the user-level default in charge
+	if _, err := runThemeCmd(t, "add", "elegant"); err != nil {
+		t.Fatalf("theme add error = %v", err)
+	}
+	data, _ := os.ReadFile(filepath.Join(tmpDir, "m2cv.yml"))
+	if want := "themes:\n  - flat\n  - elegant\n"; string(data) != want {
+		t.Errorf("m2cv.yml = %q, want %q", data, want)
+	}
+
+	if _, err := runThemeCmd(t, "remove", "even"); err == nil || !strings.Contains(err.Error(), "cannot remove the default theme") {
+		t.Errorf("removing the effective default should fail, got %v", err)
+	}
+
+	// --default writes the project's own default
+	if _, err := runThemeCmd(t, "add", "--default", "flat"); err != nil {
+		t.Fatalf("theme add --default error = %v", err)
+	}
+	cfg, err := config.NewRepository().Load(filepath.Join(tmpDir, "m2cv.yml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.DefaultTheme != "flat" {
+		t.Errorf("default_theme = %q, want flat", cfg.DefaultTheme)
+	}
+}
+
+func TestThemeCommand_Update(t *testing.T) {
+	_, npm := setupThemeTest(t, "default_theme: even\nthemes:\n  - even\n  - flat\n")
+
+	if _, err := runThemeCmd(t, "update"); err != nil {
+		t.Fatal(err)
+	}
+	if npm.commands[0] != "update jsonresume-theme-even jsonresume-theme-flat" {
+		t.Errorf("npm = %q", npm.commands[0])
+	}
+}
+
+func TestThemeCommand_InvalidTheme(t *testing.T) {
+	_, npm := setupThemeTest(t, "default_theme: even\n")
+
+	if _, err := runThemeCmd(t, "add", "Bad Theme"); err == nil || !strings.Contains(err.Error(), "invalid theme") {
+		t.Errorf("expected invalid theme error, got %v", err)
+	}
+	if len(npm.commands) != 0 {
+		t.Error("npm must not run for invalid themes")
+	}
+}
+
+func TestThemeCommand_New(t *testing.T) {
+	tmpDir, npm := setupThemeTest(t, "default_theme: even\nthemes:\n  - even\n")
+
+	out, err := runThemeCmd(t, "new", "--default", "brand")
+	if err != nil {
+		t.Fatalf("theme new error = %v", err)
+	}
+	themeDir := filepath.Join(tmpDir, "themes", "brand")
+	if !strings.Contains(out, "Created theme file:themes/brand in "+themeDir+" (default)") {
+		t.Errorf("new output = %q", out)
+	}
+	if _, err := os.Stat(filepath.Join(themeDir, "index.js")); err != nil {
+		t.Errorf("theme not scaffolded: %v", err)
+	}
+	if len(npm.commands) != 0 {
+		t.Errorf("npm should not run for the starter theme: %v", npm.commands)
+	}
+
+	cfg, err := config.NewRepository().Load(filepath.Join(tmpDir, "m2cv.yml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Join(cfg.Themes, ",") != "even,file:themes/brand" || cfg.DefaultTheme != "file:themes/brand" {
+		t.Errorf("config not updated: %+v", cfg)
+	}
+
+	out, err = runThemeCmd(t, "list")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(out, "file:themes/brand (default)") || !strings.Contains(out, themeDir) {
+		t.Errorf("list should show the local theme and its directory:\n%s", out)
+	}
+
+	if _, err := runThemeCmd(t, "new", "brand"); err == nil || !strings.Contains(err.Error(), "already exists") {
+		t.Errorf("expected already exists error, got %v", err)
+	}
+}
+
+func TestThemeCommand_AddLocal(t *testing.T) {
+	tmpDir, _ := setupThemeTest(t, "default_theme: even\n")
+
+	if _, err := runThemeCmd(t, "add", "./design/brand"); err == nil || !strings.Contains(err.Error(), "theme directory not found") {
+		t.Errorf("expected missing directory error, got %v", err)
+	}
+
+	if err := os.MkdirAll(filepath.Join(tmpDir, "design", "brand"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	out, err := runThemeCmd(t, "add", "./design/brand")
+	if err != nil {
+		t.Fatalf("theme add error = %v", err)
+	}
+	if !strings.Contains(out, "Added [file:design/brand]") {
+		t.Errorf("add output = %q", out)
+	}
+}
+
+// fakeGalleryExporter writes a one-page PDF stub, failing for "broken".
+type fakeGalleryExporter struct{}
+
+func (fakeGalleryExporter) ExportPDF(ctx context.Context, jsonPath, outputPath, theme, projectDir string) error {
+	if theme == "broken" {
+		return errors.New("boom")
+	}
+	return os.WriteFile(outputPath, []byte("%PDF /Type /Page"), 0644)
+}
+
+func TestThemeGallery(t *testing.T) {
+	tmpDir, _ := setupThemeTest(t, "default_theme: even\n")
+	for _, dir := range []string{"node_modules/resumed", "node_modules/jsonresume-theme-even", "node_modules/jsonresume-theme-broken", "applications/acme"} {
+		if err := os.MkdirAll(filepath.Join(tmpDir, dir), 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	orig := newGalleryExporter
+	newGalleryExporter = func() (gallery.Exporter, error) { return fakeGalleryExporter{}, nil }
+	t.Cleanup(func() { newGalleryExporter = orig })
+
+	if _, err := runThemeCmd(t, "gallery", "acme"); err == nil || !strings.Contains(err.Error(), "Run 'm2cv generate acme' first") {
+		t.Errorf("expected missing resume.json error, got %v", err)
+	}
+
+	if err := os.WriteFile(filepath.Join(tmpDir, "applications", "acme", "resume.json"), []byte("{}"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	out, err := runThemeCmd(t, "gallery", "acme")
+	if err != nil {
+		t.Fatalf("theme gallery error = %v\n%s", err, out)
+	}
+	for _, want := range []string{"Rendering 2 theme(s)", "even: 1 page(s)", "broken: failed: boom", "1 of 2 theme(s) failed"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q:\n%s", want, out)
+		}
+	}
+	if _, err := os.Stat(filepath.Join(tmpDir, "applications", "acme", "gallery", "index.html")); err != nil {
+		t.Errorf("index not written: %v", err)
+	}
+
+	if _, err := runThemeCmd(t, "gallery", "--themes", "broken", "acme"); err == nil || !strings.Contains(err.Error(), "all 1 theme(s) failed") {
+		t.Errorf("expected all-failed error, got %v", err)
+	}
+}
@@ -0,0 +1,62 @@
+package cmd
+
+import (
+	"github.com/richq/m2cv/internal/mcp"
+	"github.com/richq/m2cv/internal/tui"
+	"github.com/spf13/cobra"
+)
+
+// newUICommand creates the ui subcommand.
+func newUICommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "ui",
+		Short: "Browse and manage applications in a full-screen UI",
+		Long: `Open a full-screen terminal UI over every application in the project.
+
+The list shows each application with its status and version count. Press
+enter to open one: the detail view has tabs for the job description, the
+selected CV version, its diff against the previous version and its keyword
+score. From there you can optimize, export a PDF or change the status
+without leaving the UI; Claude runs in the background with a live spinner.
+
+Keys (detail view):
+  tab / 1-4     switch pane (job, version, diff, score)
+  ←/→ or [ ]    previous / next version
+  o / a         optimize / optimize for ATS
+  g             generate PDF from the selected version
+  s             show keyword score
+  t             set status
+  esc           back to the list
+  ctrl+c        cancel a running job, or quit`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			pctx, err := loadProjectContext()
+			if err != nil {
+				return err
+			}
+			cfg := uiConfig(pctx)
+			cfg.ForApplication = func(appDir string) (tui.Config, error) {
+				actx, err := pctx.ForApplication(appDir)
+				if err != nil {
+					return tui.Config{}, err
+				}
+				return uiConfig(actx), nil
+			}
+			return tui.Run(cmd.Context(), cfg)
+		},
+	}
+}
+
+// uiConfig describes the project in pctx to the UI.
+func uiConfig(pctx *mcp.ProjectContext) tui.Config {
+	return tui.Config{
+		ApplicationsDir: pctx.ApplicationsDir,
+		ProjectDir:      pctx.ProjectDir,
+		BaseCVPath:      pctx.BaseCVPath,
+		Theme:           pctx.Theme,
+		ThemeOptions:    pctx.ThemeOptions,
+		Models:          pctx.Models,
+		ToolVersion:     pctx.ToolVersion,
+		ToolCommit:      pctx.ToolCommit,
+	}
+}
@@ -0,0 +1,29 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestUICommand_Structure(t *testing.T) {
+	t.Parallel()
+
+	cmd := newUICommand()
+	if cmd.Use != "ui" {
+		t.Errorf("wrong Use: %q", cmd.Use)
+	}
+	if cmd.Args == nil {
+		t.Error("ui should validate arguments")
+	}
+}
+
+func TestUICommand_NoConfig(t *testing.T) {
+	_, cleanup := setupOptimizeTest(t)
+	defer cleanup()
+
+	cmd := newUICommand()
+	err := cmd.RunE(cmd, nil)
+	if err == nil || !strings.Contains(err.Error(), "m2cv.yml not found") {
+		t.Errorf("expected missing config error, got %v", err)
+	}
+}
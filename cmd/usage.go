@@ -0,0 +1,107 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"text/tabwriter"
+	"time"
+
+	"github.com/richq/m2cv/internal/usage"
+	"github.com/spf13/cobra"
+)
+
+// newUsageCommand creates the usage subcommand.
+func newUsageCommand() *cobra.Command {
+	var since string
+
+	cmd := &cobra.Command{
+		Use:   "usage [application-name]",
+		Short: "Show Claude token usage and cost per application",
+		Long: `Show Claude token usage and cost recorded for your applications.
+
+Every optimize and generate run appends a record (tokens, cost, model,
+session id, duration) to usage.jsonl in the application folder. This
+command sums those records across applications, models and commands.
+
+Pass an application name to limit the report to that application.
+Use --since to only include recent usage, as a date (2026-01-31),
+an RFC 3339 timestamp, or a duration (7d, 24h).
+
+Examples:
+  m2cv usage
+  m2cv usage acme-software-engineer
+  m2cv usage --since 7d`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			applicationName := ""
+			if len(args) == 1 {
+				applicationName = args[0]
+			}
+			return runUsage(cmd.OutOrStdout(), applicationName, since)
+		},
+	}
+
+	cmd.Flags().StringVar(&since, "since", "", "only include usage after this date or duration (e.g. 2026-01-31, 7d)")
+
+	return cmd
+}
+
+// runUsage executes the usage command logic.
+func runUsage(out io.Writer, applicationName, since string) error {
+	var sinceTime time.Time
+	if since != "" {
+		t, err := usage.ParseSince(since, time.Now())
+		if err != nil {
+			return err
+		}
+		sinceTime = t
+	}
+
+	var (
+		records []usage.Record
+		err     error
+	)
+	if applicationName != "" {
+		appDir := filepath.Join("applications", applicationName)
+		if _, statErr := os.Stat(appDir); os.IsNotExist(statErr) {
+			return fmt.Errorf("application folder not found: %s. Run 'm2cv apply' first", appDir)
+		}
+		records, err = usage.Load(appDir)
+	} else {
+		records, err = usage.LoadAll("applications")
+	}
+	if err != nil {
+		return fmt.Errorf("failed to load usage: %w", err)
+	}
+
+	summary := usage.Summarize(records, sinceTime)
+	if summary.Total.Calls == 0 {
+		fmt.Fprintln(out, "No usage recorded.")
+		return nil
+	}
+
+	if applicationName == "" {
+		printTotalsTable(out, "Application", summary.ByApplication)
+	}
+	printTotalsTable(out, "Model", summary.ByModel)
+	printTotalsTable(out, "Command", summary.ByCommand)
+
+	fmt.Fprintf(out, "Total: %d calls, %d input tokens, %d output tokens, $%.4f\n",
+		summary.Total.Calls, summary.Total.InputTokens, summary.Total.OutputTokens, summary.Total.CostUSD)
+	return nil
+}
+
+// printTotalsTable writes one aligned table of totals grouped by a key.
+func printTotalsTable(out io.Writer, heading string, groups map[string]*usage.Totals) {
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(w, "%s\tCalls\tInput\tOutput\tCache read\tCost\t\n", heading)
+	for _, key := range usage.SortedKeys(groups) {
+		t := groups[key]
+		fmt.Fprintf(w, "%s\t%d\t%d\t%d\t%d\t$%.4f\t\n",
+			key, t.Calls, t.InputTokens, t.OutputTokens, t.CacheReadInputTokens, t.CostUSD)
+	}
+	w.Flush()
+	fmt.Fprintln(out)
+}
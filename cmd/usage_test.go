@@ -0,0 +1,74 @@
+package cmd
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/richq/m2cv/internal/executor"
+	"github.com/richq/m2cv/internal/usage"
+)
+
+func TestUsageCommand_Structure(t *testing.T) {
+	t.Parallel()
+
+	cmd := newUsageCommand()
+	if cmd.Use != "usage [application-name]" {
+		t.Errorf("wrong Use: %q", cmd.Use)
+	}
+	if cmd.Flags().Lookup("since") == nil {
+		t.Error("missing --since flag")
+	}
+}
+
+func TestRunUsage(t *testing.T) {
+	tmpDir, cleanup := setupOptimizeTest(t)
+	defer cleanup()
+
+	for _, app := range []string{"acme", "globex"} {
+		appDir := filepath.Join(tmpDir, "applications", app)
+		if err := os.MkdirAll(appDir, 0755); err != nil {
+			t.Fatal(err)
+		}
+		rec := usage.NewRecord(app, "optimize", &executor.Result{Model: "sonnet", InputTokens: 100, OutputTokens: 10, CostUSD: 0.25})
+		if err := usage.Append(appDir, rec); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	var out bytes.Buffer
+	if err := runUsage(&out, "", ""); err != nil {
+		t.Fatalf("runUsage() error = %v", err)
+	}
+	for _, want := range []string{"acme", "globex", "sonnet", "optimize", "Total: 2 calls", "$0.5000"} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("output missing %q:\n%s", want, out.String())
+		}
+	}
+
+	out.Reset()
+	if err := runUsage(&out, "acme", ""); err != nil {
+		t.Fatalf("runUsage(acme) error = %v", err)
+	}
+	if strings.Contains(out.String(), "globex") || !strings.Contains(out.String(), "Total: 1 calls") {
+		t.Errorf("single-application report should only include acme:\n%s", out.String())
+	}
+}
+
+func TestRunUsage_Errors(t *testing.T) {
+	_, cleanup := setupOptimizeTest(t)
+	defer cleanup()
+
+	var out bytes.Buffer
+	if err := runUsage(&out, "missing", ""); err == nil || !strings.Contains(err.Error(), "application folder not found") {
+		t.Errorf("expected missing application error, got %v", err)
+	}
+	if err := runUsage(&out, "", "yesterday-ish"); err == nil || !strings.Contains(err.Error(), "invalid --since") {
+		t.Errorf("expected invalid --since error, got %v", err)
+	}
+	if err := runUsage(&out, "", ""); err != nil || !strings.Contains(out.String(), "No usage recorded") {
+		t.Errorf("expected empty report, got err=%v out=%q", err, out.String())
+	}
+}
@@ -0,0 +1,104 @@
+package application
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/richq/m2cv/internal/extractor"
+)
+
+// JobDescriptionFile is the filename used when a job description is saved
+// from raw content rather than copied from a file.
+const JobDescriptionFile = "job-description.txt"
+
+// ErrNoJobDescription is returned when an application folder has no .txt file.
+var ErrNoJobDescription = errors.New("no .txt file found")
+
+// FindJobDescription returns the path of the job description in appDir.
+// The first .txt file (in lexical order) is used.
+func FindJobDescription(appDir string) (string, error) {
+	txtFiles, err := filepath.Glob(filepath.Join(appDir, "*.txt"))
+	if err != nil {
+		return "", fmt.Errorf("failed to search for job description: %w", err)
+	}
+	if len(txtFiles) == 0 {
+		return "", fmt.Errorf("%w in %s. Job description required", ErrNoJobDescription, appDir)
+	}
+	return txtFiles[0], nil
+}
+
+// Create makes a new application folder for jobName under applicationsDir.
+// The name is sanitized first; an existing folder is an error.
+func Create(applicationsDir, jobName string) (string, error) {
+	appPath := filepath.Join(applicationsDir, extractor.SanitizeFilename(jobName))
+
+	if _, err := os.Stat(appPath); err == nil {
+		return "", fmt.Errorf("application folder already exists: %s. Provide a different job-name", appPath)
+	}
+	if err := os.MkdirAll(appPath, 0755); err != nil {
+		return "", fmt.Errorf("failed to create application folder: %w", err)
+	}
+	return appPath, nil
+}
+
+// List returns the names of the application folders in applicationsDir,
+// sorted alphabetically. A missing applications directory yields no names.
+func List(applicationsDir string) ([]string, error) {
+	entries, err := os.ReadDir(applicationsDir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read applications directory: %w", err)
+	}
+
+	var names []string
+	for _, entry := range entries {
+		if entry.IsDir() {
+			names = append(names, entry.Name())
+		}
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+// Summary is a one-line overview of an application folder.
+type Summary struct {
+	Name     string `json:"name"`
+	Status   Status `json:"status"`
+	Versions []int  `json:"versions"`
+	HasPDF   bool   `json:"has_pdf"`
+}
+
+// Summarize returns a Summary for every application in applicationsDir.
+func Summarize(applicationsDir string) ([]Summary, error) {
+	names, err := List(applicationsDir)
+	if err != nil {
+		return nil, err
+	}
+
+	summaries := make([]Summary, 0, len(names))
+	for _, name := range names {
+		appDir := filepath.Join(applicationsDir, name)
+		status, err := ReadStatus(appDir)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", name, err)
+		}
+		versions, err := ListVersions(appDir)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", name, err)
+		}
+		_, pdfErr := os.Stat(filepath.Join(appDir, "resume.pdf"))
+
+		summaries = append(summaries, Summary{
+			Name:     name,
+			Status:   status.Status,
+			Versions: versions,
+			HasPDF:   pdfErr == nil,
+		})
+	}
+	return summaries, nil
+}
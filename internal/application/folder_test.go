@@ -0,0 +1,105 @@
+package application
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestFindJobDescription(t *testing.T) {
+	appDir := t.TempDir()
+
+	if _, err := FindJobDescription(appDir); !errors.Is(err, ErrNoJobDescription) {
+		t.Errorf("empty folder error = %v, want ErrNoJobDescription", err)
+	}
+
+	for _, name := range []string{"b.txt", "a.txt", "notes.md"} {
+		if err := os.WriteFile(filepath.Join(appDir, name), []byte("x"), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	got, err := FindJobDescription(appDir)
+	if err != nil {
+		t.Fatalf("FindJobDescription() error = %v", err)
+	}
+	if filepath.Base(got) != "a.txt" {
+		t.Errorf("FindJobDescription() = %s, want a.txt", got)
+	}
+}
+
+func TestCreate(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "applications")
+
+	appPath, err := Create(dir, "Acme Senior Engineer")
+	if err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	if filepath.Base(appPath) != "acme-senior-engineer" {
+		t.Errorf("Create() = %s, want sanitized folder name", appPath)
+	}
+	if info, err := os.Stat(appPath); err != nil || !info.IsDir() {
+		t.Errorf("application folder not created: %v", err)
+	}
+
+	if _, err := Create(dir, "acme-senior-engineer"); err == nil || !strings.Contains(err.Error(), "already exists") {
+		t.Errorf("duplicate Create() error = %v", err)
+	}
+}
+
+func TestList(t *testing.T) {
+	dir := t.TempDir()
+	for _, name := range []string{"zeta", "alpha"} {
+		if err := os.Mkdir(filepath.Join(dir, name), 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := os.WriteFile(filepath.Join(dir, "stray.md"), []byte("x"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := List(dir)
+	if err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+	if !reflect.DeepEqual(got, []string{"alpha", "zeta"}) {
+		t.Errorf("List() = %v", got)
+	}
+
+	if got, err := List(filepath.Join(dir, "missing")); err != nil || got != nil {
+		t.Errorf("List(missing) = %v, %v; want nil, nil", got, err)
+	}
+}
+
+func TestSummarize(t *testing.T) {
+	dir := t.TempDir()
+	acme := filepath.Join(dir, "acme")
+	if err := os.Mkdir(acme, 0755); err != nil {
+		t.Fatal(err)
+	}
+	for _, name := range []string{"optimized-cv-1.md", "optimized-cv-2.md", "resume.pdf"} {
+		if err := os.WriteFile(filepath.Join(acme, name), []byte("x"), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, err := WriteStatus(acme, StatusOffer, ""); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Mkdir(filepath.Join(dir, "globex"), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := Summarize(dir)
+	if err != nil {
+		t.Fatalf("Summarize() error = %v", err)
+	}
+	want := []Summary{
+		{Name: "acme", Status: StatusOffer, Versions: []int{1, 2}, HasPDF: true},
+		{Name: "globex", Status: StatusDraft},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Summarize() = %+v, want %+v", got, want)
+	}
+}
@@ -0,0 +1,99 @@
+package application
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// StatusFile is the file in an application folder that tracks its status.
+const StatusFile = "status.yml"
+
+// Status is where an application stands in the hiring process.
+type Status string
+
+// Application statuses, in the order an application usually moves through them.
+const (
+	StatusDraft        Status = "draft"
+	StatusApplied      Status = "applied"
+	StatusInterviewing Status = "interviewing"
+	StatusOffer        Status = "offer"
+	StatusRejected     Status = "rejected"
+	StatusWithdrawn    Status = "withdrawn"
+)
+
+// Statuses lists every valid status.
+var Statuses = []Status{
+	StatusDraft,
+	StatusApplied,
+	StatusInterviewing,
+	StatusOffer,
+	StatusRejected,
+	StatusWithdrawn,
+}
+
+// ParseStatus validates a status name (case-insensitive).
+func ParseStatus(s string) (Status, error) {
+	status := Status(strings.ToLower(strings.TrimSpace(s)))
+	for _, valid := range Statuses {
+		if status == valid {
+			return status, nil
+		}
+	}
+
+	names := make([]string, len(Statuses))
+	for i, valid := range Statuses {
+		names[i] = string(valid)
+	}
+	return "", fmt.Errorf("invalid status %q (valid: %s)", s, strings.Join(names, ", "))
+}
+
+// StatusInfo is the content of status.yml.
+type StatusInfo struct {
+	Status    Status    `yaml:"status"`
+	Note      string    `yaml:"note,omitempty"`
+	UpdatedAt time.Time `yaml:"updated_at,omitempty"`
+}
+
+// ReadStatus loads the status of the application in appDir.
+// Applications without a status file are drafts.
+func ReadStatus(appDir string) (*StatusInfo, error) {
+	data, err := os.ReadFile(filepath.Join(appDir, StatusFile))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return &StatusInfo{Status: StatusDraft}, nil
+		}
+		return nil, fmt.Errorf("failed to read status: %w", err)
+	}
+
+	var info StatusInfo
+	if err := yaml.Unmarshal(data, &info); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", StatusFile, err)
+	}
+	if info.Status == "" {
+		info.Status = StatusDraft
+	}
+	return &info, nil
+}
+
+// WriteStatus records a new status for the application in appDir.
+func WriteStatus(appDir string, status Status, note string) (*StatusInfo, error) {
+	info := &StatusInfo{
+		Status:    status,
+		Note:      note,
+		UpdatedAt: time.Now().UTC().Truncate(time.Second),
+	}
+
+	data, err := yaml.Marshal(info)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal status: %w", err)
+	}
+	if err := os.WriteFile(filepath.Join(appDir, StatusFile), data, 0644); err != nil {
+		return nil, fmt.Errorf("failed to write status: %w", err)
+	}
+	return info, nil
+}
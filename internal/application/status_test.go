@@ -0,0 +1,46 @@
+package application
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestParseStatus(t *testing.T) {
+	if got, err := ParseStatus(" Interviewing "); err != nil || got != StatusInterviewing {
+		t.Errorf("ParseStatus() = %q, %v", got, err)
+	}
+	if _, err := ParseStatus("ghosted"); err == nil {
+		t.Error("ParseStatus(ghosted) should fail")
+	}
+}
+
+func TestReadWriteStatus(t *testing.T) {
+	appDir := t.TempDir()
+
+	info, err := ReadStatus(appDir)
+	if err != nil {
+		t.Fatalf("ReadStatus() error = %v", err)
+	}
+	if info.Status != StatusDraft {
+		t.Errorf("default status = %q, want draft", info.Status)
+	}
+
+	if _, err := WriteStatus(appDir, StatusApplied, "sent via referral"); err != nil {
+		t.Fatalf("WriteStatus() error = %v", err)
+	}
+	info, err = ReadStatus(appDir)
+	if err != nil {
+		t.Fatalf("ReadStatus() error = %v", err)
+	}
+	if info.Status != StatusApplied || info.Note != "sent via referral" || info.UpdatedAt.IsZero() {
+		t.Errorf("ReadStatus() = %+v", info)
+	}
+
+	if err := os.WriteFile(filepath.Join(appDir, StatusFile), []byte("status: [\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ReadStatus(appDir); err == nil {
+		t.Error("ReadStatus() should fail on malformed YAML")
+	}
+}
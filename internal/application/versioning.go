@@ -1,5 +1,6 @@
 // Package application provides utilities for managing application folders.
-// This includes versioning for optimized CV output files.
+// This includes versioning for optimized CV output files, job description
+// discovery and the application status.
 package application
 
 import (
@@ -69,8 +70,7 @@ func LatestVersionPath(appDir string) (string, error) {
 		return "", nil
 	}
 
-	latest := versions[len(versions)-1]
-	return filepath.Join(appDir, fmt.Sprintf("%s%d%s", OptimizedCVPrefix, latest, OptimizedCVSuffix)), nil
+	return VersionPath(appDir, versions[len(versions)-1]), nil
 }
 
 // NextVersionPath returns the path for the next version of the optimized CV.
@@ -87,5 +87,11 @@ func NextVersionPath(appDir string) (string, error) {
 		nextVersion = versions[len(versions)-1] + 1
 	}
 
-	return filepath.Join(appDir, fmt.Sprintf("%s%d%s", OptimizedCVPrefix, nextVersion, OptimizedCVSuffix)), nil
+	return VersionPath(appDir, nextVersion), nil
+}
+
+// VersionPath returns the path of optimized CV version n in the application directory.
+// The file is not required to exist.
+func VersionPath(appDir string, n int) string {
+	return filepath.Join(appDir, fmt.Sprintf("%s%d%s", OptimizedCVPrefix, n, OptimizedCVSuffix))
 }
@@ -356,3 +356,11 @@ func TestConstants(t *testing.T) {
 		t.Errorf("OptimizedCVSuffix = %q, want %q", OptimizedCVSuffix, ".md")
 	}
 }
+
+func TestVersionPath(t *testing.T) {
+	got := VersionPath("applications/acme", 7)
+	want := filepath.Join("applications/acme", "optimized-cv-7.md")
+	if got != want {
+		t.Errorf("VersionPath() = %q, want %q", got, want)
+	}
+}
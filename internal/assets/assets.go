@@ -1,10 +1,12 @@
-// Package assets provides access to embedded prompt templates and JSON schemas.
+// Package assets provides access to embedded prompt templates, JSON schemas
+// and the starter theme.
 // These files are compiled into the binary using Go's embed directive.
 package assets
 
 import (
 	"embed"
 	"fmt"
+	"io/fs"
 	"path/filepath"
 	"strings"
 )
@@ -15,6 +17,9 @@ var promptFS embed.FS
 //go:embed schema/*.json
 var schemaFS embed.FS
 
+//go:embed theme
+var themeFS embed.FS
+
 // GetPrompt reads a prompt template by name (without extension).
 // For example, GetPrompt("optimize") reads "prompts/optimize.txt".
 func GetPrompt(name string) (string, error) {
@@ -37,6 +42,17 @@ func GetSchema(name string) ([]byte, error) {
 	return data, nil
 }
 
+// ThemeScaffold returns the starter theme files rooted at the theme
+// directory. Files are text/template sources; see theme.Scaffold.
+func ThemeScaffold() fs.FS {
+	sub, err := fs.Sub(themeFS, "theme")
+	if err != nil {
+		// Unreachable: the directory is embedded at compile time
+		panic(err)
+	}
+	return sub
+}
+
 // ListPrompts returns all available prompt names (without extension).
 // Useful for debugging and validation.
 func ListPrompts() ([]string, error) {
@@ -0,0 +1,89 @@
+// A minimal JSON Resume theme. resumed calls render(resume) and prints the
+// returned HTML to PDF with pdfRenderOptions. Edit freely: this file has no
+// dependencies, but you can add some to package.json and run
+// `m2cv theme update file:<this directory>` to install them.
+const fs = require('fs');
+const path = require('path');
+
+const css = fs.readFileSync(path.join(__dirname, 'style.css'), 'utf8');
+
+function escape(value) {
+  return String(value ?? '')
+    .replace(/&/g, '&amp;')
+    .replace(/</g, '&lt;')
+    .replace(/>/g, '&gt;')
+    .replace(/"/g, '&quot;');
+}
+
+function dates(item) {
+  const start = item.startDate || '';
+  const end = item.endDate || (start ? 'Present' : '');
+  return [start, end].filter(Boolean).join(' – ');
+}
+
+function section(title, items, renderItem) {
+  if (!items || items.length === 0) {
+    return '';
+  }
+  return `<section><h2>${escape(title)}</h2>${items.map(renderItem).join('')}</section>`;
+}
+
+function highlights(list) {
+  if (!list || list.length === 0) {
+    return '';
+  }
+  return `<ul>${list.map((h) => `<li>${escape(h)}</li>`).join('')}</ul>`;
+}
+
+function render(resume) {
+  const basics = resume.basics || {};
+  const contact = [basics.email, basics.phone, basics.url, basics.location && basics.location.city]
+    .filter(Boolean)
+    .map(escape)
+    .join(' · ');
+
+  const work = section('Experience', resume.work, (job) => `
+    <article>
+      <h3>${escape(job.position)}<span>${escape(job.name)}</span></h3>
+      <p class="dates">${escape(dates(job))}</p>
+      ${job.summary ? `<p>${escape(job.summary)}</p>` : ''}
+      ${highlights(job.highlights)}
+    </article>`);
+
+  const education = section('Education', resume.education, (school) => `
+    <article>
+      <h3>${escape([school.studyType, school.area].filter(Boolean).join(', '))}<span>${escape(school.institution)}</span></h3>
+      <p class="dates">${escape(dates(school))}</p>
+    </article>`);
+
+  const skills = section('Skills', resume.skills, (skill) => `
+    <p><strong>${escape(skill.name)}</strong>${skill.keywords ? ': ' + skill.keywords.map(escape).join(', ') : ''}</p>`);
+
+  return `<!DOCTYPE html>
+<html lang="en">
+<head>
+  <meta charset="utf-8">
+  <title>${escape(basics.name)}</title>
+  <style>${css}</style>
+</head>
+<body>
+  <header>
+    <h1>${escape(basics.name)}</h1>
+    ${basics.label ? `<p class="label">${escape(basics.label)}</p>` : ''}
+    <p class="contact">${contact}</p>
+  </header>
+  ${basics.summary ? `<section><p>${escape(basics.summary)}</p></section>` : ''}
+  ${work}
+  ${education}
+  ${skills}
+</body>
+</html>`;
+}
+
+const pdfRenderOptions = {
+  format: 'A4',
+  printBackground: true,
+  margin: { top: '12mm', bottom: '12mm', left: '12mm', right: '12mm' },
+};
+
+module.exports = { render, pdfRenderOptions };
@@ -0,0 +1,180 @@
+// Package batch runs a job for many applications at once: a bounded pool
+// of workers shares one context, so a cancelled run stops starting new
+// applications, and every application gets a result.
+package batch
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/richq/m2cv/internal/application"
+)
+
+// ErrStopped is the cause reported for jobs not started because an
+// earlier one failed with Options.FailFast.
+var ErrStopped = errors.New("not started after an earlier failure")
+
+// Result is the outcome of one application's job.
+type Result struct {
+	// Name is the application name.
+	Name string
+	// Err is the job's error; nil on success.
+	Err error
+	// Started is false when the run was cancelled before the job began.
+	Started bool
+	// Duration is how long the job ran.
+	Duration time.Duration
+}
+
+// Options configure Run.
+type Options struct {
+	// Jobs is the maximum number of jobs running at once (at least 1).
+	Jobs int
+	// FailFast cancels the remaining jobs after the first failure.
+	FailFast bool
+	// OnStart and OnDone, if set, are called as jobs start and finish.
+	// Calls are serialized, so they may write to a shared output.
+	OnStart func(name string)
+	OnDone  func(Result)
+}
+
+// Run calls job for every name, at most opts.Jobs at a time, and returns
+// the results in the order of names. The jobs share a context derived
+// from ctx; once it is cancelled (or with FailFast, once a job fails),
+// names that have not started are reported with the cancellation error.
+func Run(ctx context.Context, names []string, job func(ctx context.Context, name string) error, opts Options) []Result {
+	ctx, cancel := context.WithCancelCause(ctx)
+	defer cancel(nil)
+
+	workers := max(opts.Jobs, 1)
+	results := make([]Result, len(names))
+	indexes := make(chan int)
+	var (
+		wg sync.WaitGroup
+		mu sync.Mutex
+	)
+
+	for range workers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				results[i] = runOne(ctx, names[i], job, opts, &mu)
+				if results[i].Err != nil && opts.FailFast {
+					cancel(ErrStopped)
+				}
+			}
+		}()
+	}
+
+	for i, name := range names {
+		// Check first: select picks randomly when both cases are ready
+		if ctx.Err() != nil {
+			results[i] = Result{Name: name, Err: context.Cause(ctx)}
+			continue
+		}
+		select {
+		case indexes <- i:
+		case <-ctx.Done():
+			results[i] = Result{Name: name, Err: context.Cause(ctx)}
+		}
+	}
+	close(indexes)
+	wg.Wait()
+	return results
+}
+
+// runOne runs a single job and reports it through the callbacks.
+func runOne(ctx context.Context, name string, job func(ctx context.Context, name string) error, opts Options, mu *sync.Mutex) Result {
+	if opts.OnStart != nil {
+		mu.Lock()
+		opts.OnStart(name)
+		mu.Unlock()
+	}
+
+	start := time.Now()
+	err := job(ctx, name)
+	result := Result{Name: name, Err: err, Started: true, Duration: time.Since(start)}
+
+	if opts.OnDone != nil {
+		mu.Lock()
+		opts.OnDone(result)
+		mu.Unlock()
+	}
+	return result
+}
+
+// Failed returns the number of jobs that started and returned an error.
+func Failed(results []Result) int {
+	n := 0
+	for _, r := range results {
+		if r.Started && r.Err != nil {
+			n++
+		}
+	}
+	return n
+}
+
+// Skipped returns the number of jobs never started because the run was
+// cancelled or stopped after a failure.
+func Skipped(results []Result) int {
+	n := 0
+	for _, r := range results {
+		if !r.Started {
+			n++
+		}
+	}
+	return n
+}
+
+// Select returns the names of the applications matching any of patterns
+// (path.Match globs, or plain names) and having one of statuses. No
+// patterns selects every application; no statuses allows any status. A
+// plain name that matches no application is an error, since it is
+// probably a typo.
+func Select(summaries []application.Summary, patterns []string, statuses []application.Status) ([]string, error) {
+	matched := make(map[string]bool)
+	for _, pattern := range patterns {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return nil, fmt.Errorf("invalid pattern %q: %w", pattern, err)
+		}
+		found := false
+		for _, s := range summaries {
+			if ok, _ := path.Match(pattern, s.Name); ok {
+				matched[s.Name] = true
+				found = true
+			}
+		}
+		if !found && !isGlob(pattern) {
+			return nil, fmt.Errorf("application not found: %s", pattern)
+		}
+	}
+
+	var names []string
+	for _, s := range summaries {
+		if len(patterns) > 0 && !matched[s.Name] {
+			continue
+		}
+		if len(statuses) > 0 && !slices.Contains(statuses, s.Status) {
+			continue
+		}
+		names = append(names, s.Name)
+	}
+	return names, nil
+}
+
+// isGlob reports whether pattern has any path.Match metacharacters.
+func isGlob(pattern string) bool {
+	for _, c := range pattern {
+		switch c {
+		case '*', '?', '[', '\\':
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,108 @@
+package batch
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/richq/m2cv/internal/application"
+)
+
+func TestRun_BoundedAndOrdered(t *testing.T) {
+	names := []string{"a", "b", "c", "d", "e", "f"}
+	var running, peak atomic.Int32
+	var started []string
+
+	results := Run(context.Background(), names, func(ctx context.Context, name string) error {
+		n := running.Add(1)
+		defer running.Add(-1)
+		for {
+			p := peak.Load()
+			if n <= p || peak.CompareAndSwap(p, n) {
+				break
+			}
+		}
+		time.Sleep(10 * time.Millisecond)
+		if name == "c" {
+			return errors.New("boom")
+		}
+		return nil
+	}, Options{Jobs: 2, OnStart: func(name string) { started = append(started, name) }})
+
+	if peak.Load() > 2 {
+		t.Errorf("peak concurrency = %d, want <= 2", peak.Load())
+	}
+	if len(started) != len(names) {
+		t.Errorf("started = %v", started)
+	}
+	for i, r := range results {
+		if r.Name != names[i] || !r.Started {
+			t.Errorf("results[%d] = %+v", i, r)
+		}
+	}
+	if Failed(results) != 1 || results[2].Err == nil {
+		t.Errorf("expected only c to fail: %+v", results)
+	}
+}
+
+func TestRun_FailFast(t *testing.T) {
+	results := Run(context.Background(), []string{"a", "b", "c"}, func(ctx context.Context, name string) error {
+		return errors.New("boom")
+	}, Options{Jobs: 1, FailFast: true})
+
+	if !results[0].Started || results[2].Started || !errors.Is(results[2].Err, ErrStopped) || Failed(results) != 1 || Skipped(results) != 2 {
+		t.Errorf("results = %+v", results)
+	}
+}
+
+func TestRun_Cancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	results := Run(ctx, []string{"a", "b", "c"}, func(ctx context.Context, name string) error {
+		cancel()
+		<-ctx.Done()
+		return ctx.Err()
+	}, Options{Jobs: 1})
+
+	if Failed(results) != 1 || Skipped(results) != 2 || results[1].Started || !errors.Is(results[1].Err, context.Canceled) {
+		t.Errorf("results = %+v", results)
+	}
+}
+
+func TestSelect(t *testing.T) {
+	summaries := []application.Summary{
+		{Name: "acme-backend", Status: application.StatusApplied},
+		{Name: "acme-sre", Status: application.StatusDraft},
+		{Name: "globex", Status: application.StatusDraft},
+	}
+
+	tests := []struct {
+		name     string
+		patterns []string
+		statuses []application.Status
+		want     []string
+		wantErr  bool
+	}{
+		{name: "all", want: []string{"acme-backend", "acme-sre", "globex"}},
+		{name: "glob", patterns: []string{"acme-*"}, want: []string{"acme-backend", "acme-sre"}},
+		{name: "status", statuses: []application.Status{application.StatusDraft}, want: []string{"acme-sre", "globex"}},
+		{name: "glob and status", patterns: []string{"acme-*"}, statuses: []application.Status{application.StatusDraft}, want: []string{"acme-sre"}},
+		{name: "names", patterns: []string{"globex", "acme-sre"}, want: []string{"acme-sre", "globex"}},
+		{name: "glob without match", patterns: []string{"initech-*"}},
+		{name: "unknown name", patterns: []string{"globx"}, wantErr: true},
+		{name: "bad pattern", patterns: []string{"[acme"}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Select(summaries, tt.patterns, tt.statuses)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Select() error = %v", err)
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("Select() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
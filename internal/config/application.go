@@ -0,0 +1,41 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ApplicationFile holds per-application settings inside an application
+// folder. Besides theme_options it may override any m2cv.yml key; see
+// LoadLayered.
+const ApplicationFile = "application.yml"
+
+// Application represents an application's optional application.yml.
+type Application struct {
+	// ThemeOptions override the project's theme options for this application.
+	ThemeOptions ThemeOptions `yaml:"theme_options,omitempty"`
+}
+
+// LoadApplication reads appDir/application.yml. A missing file yields empty
+// settings.
+func LoadApplication(appDir string) (*Application, error) {
+	path := filepath.Join(appDir, ApplicationFile)
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return &Application{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var app Application
+	if err := yaml.Unmarshal(data, &app); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	return &app, nil
+}
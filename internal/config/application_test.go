@@ -0,0 +1,38 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestLoadApplication(t *testing.T) {
+	appDir := t.TempDir()
+
+	app, err := LoadApplication(appDir)
+	if err != nil || app.ThemeOptions.AccentColor != "" {
+		t.Fatalf("missing file should give empty settings, got %+v, %v", app, err)
+	}
+
+	content := "theme_options:\n  accent_color: '#c0392b'\n  css: brand.css\n  meta:\n    colors:\n      accent: ['#c0392b', '#e74c3c']\n"
+	if err := os.WriteFile(filepath.Join(appDir, ApplicationFile), []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	app, err = LoadApplication(appDir)
+	if err != nil {
+		t.Fatalf("LoadApplication() error = %v", err)
+	}
+	if app.ThemeOptions.AccentColor != "#c0392b" || app.ThemeOptions.CSS != "brand.css" {
+		t.Errorf("ThemeOptions = %+v", app.ThemeOptions)
+	}
+	if _, ok := app.ThemeOptions.Meta["colors"]; !ok {
+		t.Errorf("Meta = %v", app.ThemeOptions.Meta)
+	}
+
+	if err := os.WriteFile(filepath.Join(appDir, ApplicationFile), []byte("theme_options: [oops"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := LoadApplication(appDir); err == nil {
+		t.Error("invalid YAML should fail")
+	}
+}
@@ -13,10 +13,37 @@ import (
 
 // Config represents the m2cv.yml configuration file.
 type Config struct {
-	BaseCVPath   string   `yaml:"base_cv_path"`
-	DefaultTheme string   `yaml:"default_theme"`
-	Themes       []string `yaml:"themes"`
-	DefaultModel string   `yaml:"default_model"`
+	// Version is the file format version; see CurrentVersion and Migrate.
+	// Files without one are version 1.
+	Version      int          `yaml:"version,omitempty"`
+	BaseCVPath   string       `yaml:"base_cv_path"`
+	DefaultTheme string       `yaml:"default_theme"`
+	Themes       []string     `yaml:"themes"`
+	DefaultModel string       `yaml:"default_model"`
+	ThemeOptions ThemeOptions `yaml:"theme_options,omitempty"`
+	// Models tunes the Claude invocation per command or prompt; see
+	// ModelProfileNames.
+	Models map[string]ModelProfile `yaml:"models,omitempty"`
+}
+
+// ThemeOptions adjusts the look of any theme without forking it. The
+// project's options in m2cv.yml can be overridden field by field in an
+// application's application.yml.
+type ThemeOptions struct {
+	// AccentColor colours headings and links (any CSS colour).
+	AccentColor string `yaml:"accent_color,omitempty" json:"accent_color,omitempty"`
+	// FontFamily replaces the body font (a CSS font-family list).
+	FontFamily string `yaml:"font_family,omitempty" json:"font_family,omitempty"`
+	// FontSize sets the base font size (e.g. "10.5pt").
+	FontSize string `yaml:"font_size,omitempty" json:"font_size,omitempty"`
+	// Margin sets the PDF page margins as a CSS shorthand (e.g. "12mm 15mm").
+	Margin string `yaml:"margin,omitempty" json:"margin,omitempty"`
+	// CSS is a stylesheet injected after the theme's own styles. Relative
+	// paths are resolved against the directory of the file declaring it.
+	CSS string `yaml:"css,omitempty" json:"css,omitempty"`
+	// Meta is merged into the resume's meta.themeOptions, the JSON Resume
+	// convention some themes read (e.g. colors for jsonresume-theme-even).
+	Meta map[string]any `yaml:"meta,omitempty" json:"meta,omitempty"`
 }
 
 // Repository defines the interface for configuration operations.
@@ -0,0 +1,325 @@
+package config
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"reflect"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ErrNotSet is returned by Document.Get and Document.Unset for a known key
+// that the file does not set.
+var ErrNotSet = errors.New("not set")
+
+// Document is an m2cv.yml file edited in place. It round-trips through
+// yaml.v3 nodes, so comments, key order and unrelated settings survive.
+type Document struct {
+	root *yaml.Node
+}
+
+// Setting is one leaf value in a Document.
+type Setting struct {
+	// Key is the dotted key (e.g. "theme_options.accent_color").
+	Key string
+	// Value is the value as shown by Get.
+	Value string
+}
+
+// ParseDocument parses the contents of an m2cv.yml file.
+func ParseDocument(data []byte) (*Document, error) {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, err
+	}
+	if len(doc.Content) == 0 {
+		doc = yaml.Node{Kind: yaml.DocumentNode, Content: []*yaml.Node{{Kind: yaml.MappingNode, Tag: "!!map"}}}
+	}
+	if doc.Content[0].Kind != yaml.MappingNode {
+		return nil, fmt.Errorf("expected a mapping of settings at the top level")
+	}
+	return &Document{root: &doc}, nil
+}
+
+// LoadDocument reads and parses the m2cv.yml file at path.
+func LoadDocument(path string) (*Document, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return ParseDocument(data)
+}
+
+// Bytes encodes the document back to YAML.
+func (d *Document) Bytes() ([]byte, error) {
+	var buf bytes.Buffer
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(d.root); err != nil {
+		return nil, err
+	}
+	if err := enc.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// Save writes the document to path.
+func (d *Document) Save(path string) error {
+	data, err := d.Bytes()
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0644)
+}
+
+// Config decodes the document into a Config.
+func (d *Document) Config() (*Config, error) {
+	var cfg Config
+	if err := d.root.Decode(&cfg); err != nil {
+		return nil, err
+	}
+	return &cfg, nil
+}
+
+// Get returns the value of key. Scalars are returned as-is, lists of
+// scalars comma-separated and anything else as flow-style YAML.
+func (d *Document) Get(key string) (string, error) {
+	if _, err := keyType(key); err != nil {
+		return "", err
+	}
+	node := d.lookup(key)
+	if node == nil {
+		return "", fmt.Errorf("%s: %w", key, ErrNotSet)
+	}
+	return format(node), nil
+}
+
+// Set sets key to value, creating parent mappings as needed. The value is
+// interpreted according to the key: a string for string settings, a
+// comma-separated list for list settings (e.g. themes) and YAML for
+// free-form ones (theme_options.meta.*). The result must still decode
+// into a Config.
+func (d *Document) Set(key, value string) error {
+	if key == "version" {
+		return fmt.Errorf("version is managed by migrations; run 'm2cv config migrate'")
+	}
+	t, err := keyType(key)
+	if err != nil {
+		return err
+	}
+	node, err := valueNode(t, value)
+	if err != nil {
+		return fmt.Errorf("invalid value for %s: %w", key, err)
+	}
+
+	parts := strings.Split(key, ".")
+	parent := d.root.Content[0]
+	for _, part := range parts[:len(parts)-1] {
+		child := mappingValue(parent, part)
+		if child == nil {
+			child = &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+			parent.Content = append(parent.Content, &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: part}, child)
+		} else if child.Kind != yaml.MappingNode {
+			*child = yaml.Node{Kind: yaml.MappingNode, Tag: "!!map", HeadComment: child.HeadComment, LineComment: child.LineComment}
+		}
+		parent = child
+	}
+
+	last := parts[len(parts)-1]
+	if existing := mappingValue(parent, last); existing != nil {
+		// Keep comments attached to the old value
+		node.HeadComment, node.LineComment, node.FootComment = existing.HeadComment, existing.LineComment, existing.FootComment
+		*existing = *node
+	} else {
+		parent.Content = append(parent.Content, &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: last}, node)
+	}
+
+	if _, err := d.Config(); err != nil {
+		return fmt.Errorf("invalid value for %s: %w", key, err)
+	}
+	return nil
+}
+
+// Unset removes key, and any parent mapping left empty by the removal.
+// Unknown keys present in the file can be removed too, to clean up typos.
+func (d *Document) Unset(key string) error {
+	if unset(d.root.Content[0], strings.Split(key, ".")) {
+		return nil
+	}
+	if _, err := keyType(key); err != nil {
+		return err
+	}
+	return fmt.Errorf("%s: %w", key, ErrNotSet)
+}
+
+// List returns every leaf setting in file order. Lists and free-form
+// values are leaves; mappings of known structure (theme_options, each
+// models entry) are expanded.
+func (d *Document) List() []Setting {
+	var settings []Setting
+	list(d.root.Content[0], reflect.TypeOf(Config{}), "", &settings)
+	return settings
+}
+
+// list appends the leaves under node, expanding mappings whose type is a struct.
+func list(node *yaml.Node, t reflect.Type, prefix string, settings *[]Setting) {
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		key, value := node.Content[i].Value, node.Content[i+1]
+		fieldType := childType(t, key)
+		if value.Kind == yaml.MappingNode && expands(fieldType) {
+			list(value, fieldType, prefix+key+".", settings)
+			continue
+		}
+		*settings = append(*settings, Setting{Key: prefix + key, Value: format(value)})
+	}
+}
+
+// lookup returns the value node of a dotted key, or nil.
+func (d *Document) lookup(key string) *yaml.Node {
+	node := d.root.Content[0]
+	for _, part := range strings.Split(key, ".") {
+		if node = mappingValue(node, part); node == nil {
+			return nil
+		}
+	}
+	return node
+}
+
+// unset removes the key path under node, reporting whether it was present.
+func unset(node *yaml.Node, parts []string) bool {
+	if node.Kind != yaml.MappingNode {
+		return false
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value != parts[0] {
+			continue
+		}
+		if len(parts) == 1 {
+			node.Content = append(node.Content[:i], node.Content[i+2:]...)
+			return true
+		}
+		child := node.Content[i+1]
+		if !unset(child, parts[1:]) {
+			return false
+		}
+		if len(child.Content) == 0 {
+			node.Content = append(node.Content[:i], node.Content[i+2:]...)
+		}
+		return true
+	}
+	return false
+}
+
+// mappingValue returns the value for key in a mapping node, or nil.
+func mappingValue(node *yaml.Node, key string) *yaml.Node {
+	if node.Kind != yaml.MappingNode {
+		return nil
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			return node.Content[i+1]
+		}
+	}
+	return nil
+}
+
+// keyType resolves a dotted key against the Config schema. Keys below a
+// free-form map are accepted as-is.
+func keyType(key string) (reflect.Type, error) {
+	if key == "" {
+		return nil, fmt.Errorf("key is required")
+	}
+	t := reflect.TypeOf(Config{})
+	prefix := ""
+	for _, part := range strings.Split(key, ".") {
+		if part == "" {
+			return nil, fmt.Errorf("invalid key %q", key)
+		}
+		switch t.Kind() {
+		case reflect.Struct:
+			fields := schemaFields(t)
+			next, ok := fields[part]
+			if !ok {
+				msg := fmt.Sprintf("unknown key %q", prefix+part)
+				if suggestion := closest(part, sortedKeys(fields)); suggestion != "" {
+					msg += fmt.Sprintf(" (did you mean %q?)", prefix+suggestion)
+				}
+				return nil, errors.New(msg)
+			}
+			t = next
+		case reflect.Map:
+			if t = t.Elem(); t.Kind() != reflect.Struct {
+				t = reflect.TypeOf((*any)(nil)).Elem()
+			}
+		case reflect.Interface:
+			t = reflect.TypeOf((*any)(nil)).Elem()
+		default:
+			return nil, fmt.Errorf("%s is not a group of settings", strings.TrimSuffix(prefix, "."))
+		}
+		prefix += part + "."
+	}
+	return t, nil
+}
+
+// valueNode builds the node for a value of type t from its command-line form.
+func valueNode(t reflect.Type, value string) (*yaml.Node, error) {
+	switch t.Kind() {
+	case reflect.String:
+		return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: value}, nil
+	case reflect.Slice:
+		seq := &yaml.Node{Kind: yaml.SequenceNode, Tag: "!!seq"}
+		for _, item := range strings.Split(value, ",") {
+			if item = strings.TrimSpace(item); item != "" {
+				seq.Content = append(seq.Content, &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: item})
+			}
+		}
+		return seq, nil
+	case reflect.Struct:
+		return nil, fmt.Errorf("set the individual keys instead")
+	default:
+		var doc yaml.Node
+		if err := yaml.Unmarshal([]byte(value), &doc); err != nil {
+			return nil, err
+		}
+		if len(doc.Content) == 0 {
+			return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!null", Value: "null"}, nil
+		}
+		node := doc.Content[0]
+		node.Style &^= yaml.FlowStyle
+		return node, nil
+	}
+}
+
+// format renders a value node for display.
+func format(node *yaml.Node) string {
+	switch node.Kind {
+	case yaml.ScalarNode:
+		return node.Value
+	case yaml.SequenceNode:
+		items := make([]string, 0, len(node.Content))
+		for _, item := range node.Content {
+			if item.Kind != yaml.ScalarNode {
+				return flow(node)
+			}
+			items = append(items, item.Value)
+		}
+		return strings.Join(items, ",")
+	default:
+		return flow(node)
+	}
+}
+
+// flow encodes node as single-line flow-style YAML.
+func flow(node *yaml.Node) string {
+	copied := *node
+	copied.Style |= yaml.FlowStyle
+	data, err := yaml.Marshal(&copied)
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(data))
+}
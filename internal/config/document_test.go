@@ -0,0 +1,155 @@
+package config
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const commentedConfig = `# My job search
+base_cv_path: ./base-cv.md # relative to this file
+default_theme: even
+
+# Installed themes
+themes:
+  - even
+theme_options:
+  accent_color: teal
+`
+
+func TestDocument_GetSetUnset(t *testing.T) {
+	doc, err := ParseDocument([]byte(commentedConfig))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if v, err := doc.Get("default_theme"); err != nil || v != "even" {
+		t.Errorf("Get(default_theme) = %q, %v", v, err)
+	}
+	if _, err := doc.Get("default_model"); !errors.Is(err, ErrNotSet) {
+		t.Errorf("Get(default_model) error = %v, want ErrNotSet", err)
+	}
+	if _, err := doc.Get("default_them"); err == nil || !strings.Contains(err.Error(), `did you mean "default_theme"`) {
+		t.Errorf("Get(default_them) error = %v", err)
+	}
+
+	for _, kv := range [][2]string{
+		{"base_cv_path", "./cv.md"},
+		{"themes", "even, flat"},
+		{"default_model", "sonnet"},
+		{"theme_options.font_size", "10pt"},
+		{"theme_options.meta.colors", "{background: '#fff'}"},
+		{"theme_options.meta.compact", "true"},
+		{"theme_options.accent_color", "navy"},
+		{"theme_options.meta.colors.ink", "black"},
+	} {
+		if err := doc.Set(kv[0], kv[1]); err != nil {
+			t.Fatalf("Set(%s) error = %v", kv[0], err)
+		}
+	}
+	if err := doc.Unset("default_theme"); err != nil {
+		t.Fatal(err)
+	}
+	if err := doc.Unset("default_theme"); !errors.Is(err, ErrNotSet) {
+		t.Errorf("second Unset error = %v, want ErrNotSet", err)
+	}
+
+	data, err := doc.Bytes()
+	if err != nil {
+		t.Fatal(err)
+	}
+	out := string(data)
+	for _, want := range []string{"# My job search", "base_cv_path: ./cv.md # relative to this file", "# Installed themes", "  - flat"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q:\n%s", want, out)
+		}
+	}
+	if strings.Contains(out, "default_theme") {
+		t.Errorf("default_theme should be removed:\n%s", out)
+	}
+
+	cfg, err := doc.Config()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.DefaultModel != "sonnet" || len(cfg.Themes) != 2 || cfg.ThemeOptions.AccentColor != "navy" || cfg.ThemeOptions.FontSize != "10pt" {
+		t.Errorf("config = %+v", cfg)
+	}
+	colors, _ := cfg.ThemeOptions.Meta["colors"].(map[string]any)
+	if colors["background"] != "#fff" || colors["ink"] != "black" || cfg.ThemeOptions.Meta["compact"] != true {
+		t.Errorf("meta = %v", cfg.ThemeOptions.Meta)
+	}
+}
+
+func TestDocument_SetRejects(t *testing.T) {
+	doc, err := ParseDocument(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for key, value := range map[string]string{
+		"colour":                     "red",
+		"theme_options":              "x",
+		"default_theme.name":         "x",
+		"theme_options.accent_colr":  "red",
+		"theme_options..accent_colr": "red",
+	} {
+		if err := doc.Set(key, value); err == nil {
+			t.Errorf("Set(%q) should fail", key)
+		}
+	}
+}
+
+func TestDocument_UnsetRemovesEmptyParent(t *testing.T) {
+	doc, err := ParseDocument([]byte(commentedConfig))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := doc.Unset("theme_options.accent_color"); err != nil {
+		t.Fatal(err)
+	}
+	data, _ := doc.Bytes()
+	if strings.Contains(string(data), "theme_options") {
+		t.Errorf("empty theme_options should be removed:\n%s", data)
+	}
+}
+
+func TestDocument_List(t *testing.T) {
+	doc, err := ParseDocument([]byte(commentedConfig + "  meta:\n    colors:\n      ink: black\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got []string
+	for _, s := range doc.List() {
+		got = append(got, s.Key+"="+s.Value)
+	}
+	want := "base_cv_path=./base-cv.md default_theme=even themes=even theme_options.accent_color=teal theme_options.meta={colors: {ink: black}}"
+	if strings.Join(got, " ") != want {
+		t.Errorf("List() = %v\nwant %s", got, want)
+	}
+}
+
+func TestDocument_SaveAndLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "m2cv.yml")
+	if _, err := LoadDocument(path); !os.IsNotExist(err) {
+		t.Errorf("LoadDocument(missing) error = %v", err)
+	}
+	if err := os.WriteFile(path, []byte(commentedConfig), 0644); err != nil {
+		t.Fatal(err)
+	}
+	doc, err := LoadDocument(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := doc.Set("default_theme", "flat"); err != nil {
+		t.Fatal(err)
+	}
+	if err := doc.Save(path); err != nil {
+		t.Fatal(err)
+	}
+	cfg, err := NewRepository().Load(path)
+	if err != nil || cfg.DefaultTheme != "flat" {
+		t.Errorf("reloaded = %+v, %v", cfg, err)
+	}
+}
@@ -0,0 +1,347 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Layer sources, from lowest to highest precedence.
+const (
+	// SourceUser is the user-level config file (see UserConfigPath).
+	SourceUser = "user"
+	// SourceProject is the project's m2cv.yml.
+	SourceProject = "project"
+	// SourceApplication is an application's application.yml.
+	SourceApplication = "application"
+	// SourceEnv is an M2CV_* environment variable.
+	SourceEnv = "env"
+)
+
+// EnvPrefix starts the environment variable of every setting, e.g.
+// M2CV_DEFAULT_MODEL or M2CV_THEME_OPTIONS_ACCENT_COLOR.
+const EnvPrefix = "M2CV_"
+
+// reservedEnv are M2CV_* variables that are not settings.
+var reservedEnv = map[string]bool{
+	"M2CV_CONFIG":    true, // the project file, see FindWithOverrides
+	"M2CV_MCP_TOKEN": true, // serve-mcp's HTTP bearer token
+}
+
+// pathKeys are the settings holding file paths. In the user and
+// application layers relative paths are resolved against the file's
+// directory, since the project only knows how to resolve its own.
+var pathKeys = []string{"base_cv_path", "theme_options.css"}
+
+// Origin records where the effective value of a setting came from.
+type Origin struct {
+	// Source is one of the Source* constants.
+	Source string
+	// Path is the file that set the value, or the environment variable.
+	Path string
+	// Line is the 1-based line in Path, or 0 for environment variables.
+	Line int
+}
+
+// String formats the origin as "source path:line".
+func (o Origin) String() string {
+	if o.Line > 0 {
+		return fmt.Sprintf("%s %s:%d", o.Source, o.Path, o.Line)
+	}
+	return o.Source + " " + o.Path
+}
+
+// Layered is the effective configuration merged from every layer.
+type Layered struct {
+	// Config holds the merged settings.
+	Config *Config
+	// Settings lists the effective leaf values, as Document.List does.
+	Settings []Setting
+	// Origins maps each setting's key to the layer that set it.
+	Origins map[string]Origin
+	// Problems are unknown keys found in the files and environment.
+	Problems []Problem
+
+	doc *Document
+}
+
+// Get returns the effective value of key, formatted as by Document.Get,
+// and the layer that set it.
+func (l *Layered) Get(key string) (string, Origin, error) {
+	value, err := l.doc.Get(key)
+	if err != nil {
+		return "", Origin{}, err
+	}
+	return value, l.Origins[key], nil
+}
+
+// layerOptions configures LoadLayered.
+type layerOptions struct {
+	userPath string
+	appDir   string
+	environ  []string
+}
+
+// LayerOption configures LoadLayered.
+type LayerOption func(*layerOptions)
+
+// WithUserConfig reads the user-level layer from path instead of
+// UserConfigPath. An empty path disables the layer.
+func WithUserConfig(path string) LayerOption {
+	return func(o *layerOptions) {
+		o.userPath = path
+	}
+}
+
+// WithApplication adds appDir's application.yml as a layer above the
+// project. Its theme_options are left to theme.LoadStyle, which adds the
+// application's stylesheet to the project's rather than replacing it.
+func WithApplication(appDir string) LayerOption {
+	return func(o *layerOptions) {
+		o.appDir = appDir
+	}
+}
+
+// WithEnviron reads M2CV_* variables from environ ("KEY=value" pairs)
+// instead of the process environment.
+func WithEnviron(environ []string) LayerOption {
+	return func(o *layerOptions) {
+		o.environ = environ
+	}
+}
+
+// UserConfigPath returns the user-level config file:
+// $XDG_CONFIG_HOME/m2cv/config.yml, defaulting to ~/.config/m2cv/config.yml.
+// It returns "" if neither location can be determined.
+func UserConfigPath() string {
+	dir := os.Getenv("XDG_CONFIG_HOME")
+	if dir == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return ""
+		}
+		dir = filepath.Join(home, ".config")
+	}
+	return filepath.Join(dir, "m2cv", "config.yml")
+}
+
+// LoadLayered loads the project's m2cv.yml at projectPath and merges the
+// layers in order of precedence: the user-level file, the project, the
+// application (with WithApplication) and M2CV_* environment variables.
+// Mappings are merged key by key; any other value from a higher layer
+// replaces the lower one. Only the project file is required.
+func LoadLayered(projectPath string, opts ...LayerOption) (*Layered, error) {
+	o := layerOptions{userPath: UserConfigPath(), environ: os.Environ()}
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	merged := &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+	l := &Layered{Origins: make(map[string]Origin)}
+
+	type fileLayer struct {
+		source, path string
+		required     bool
+	}
+	layers := []fileLayer{{SourceUser, o.userPath, false}, {SourceProject, projectPath, true}}
+	if o.appDir != "" {
+		layers = append(layers, fileLayer{SourceApplication, filepath.Join(o.appDir, ApplicationFile), false})
+	}
+	for _, layer := range layers {
+		if layer.path == "" {
+			continue
+		}
+		data, err := os.ReadFile(layer.path)
+		if errors.Is(err, fs.ErrNotExist) && !layer.required {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		doc, err := ParseDocument(data)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse %s: %w", layer.path, err)
+		}
+		problems, _ := UnknownKeys(data)
+		for _, p := range problems {
+			p.File = layer.path
+			l.Problems = append(l.Problems, p)
+		}
+
+		if layer.source != SourceApplication {
+			if p, ok := versionProblem(doc, layer.source); ok {
+				p.File = layer.path
+				l.Problems = append(l.Problems, p)
+			}
+		}
+		// The format version belongs to each file, not the merged settings
+		unset(doc.root.Content[0], []string{"version"})
+		if layer.source == SourceApplication {
+			unset(doc.root.Content[0], []string{"theme_options"})
+		}
+		if layer.source != SourceProject {
+			doc.resolvePaths(filepath.Dir(layer.path))
+		}
+		merge(merged, doc.root.Content[0], reflect.TypeOf(Config{}), "", Origin{Source: layer.source, Path: layer.path}, l.Origins)
+	}
+
+	env, problems := envDocument(o.environ)
+	l.Problems = append(l.Problems, problems...)
+	merge(merged, env.root.Content[0], reflect.TypeOf(Config{}), "", Origin{Source: SourceEnv}, l.Origins)
+
+	doc := &Document{root: &yaml.Node{Kind: yaml.DocumentNode, Content: []*yaml.Node{merged}}}
+	cfg, err := doc.Config()
+	if err != nil {
+		return nil, fmt.Errorf("invalid configuration: %w", err)
+	}
+	l.Config = cfg
+	l.doc = doc
+	l.Settings = doc.List()
+	return l, nil
+}
+
+// versionProblem reports a file whose format version is outdated or newer
+// than this m2cv understands.
+func versionProblem(doc *Document, source string) (Problem, bool) {
+	outdated, err := doc.Outdated()
+	if err != nil {
+		return Problem{Key: "version", Message: err.Error()}, true
+	}
+	if !outdated {
+		return Problem{}, false
+	}
+	v, _ := doc.Version()
+	fix := "m2cv config migrate"
+	if source == SourceUser {
+		fix += " --global"
+	}
+	return Problem{Key: "version", Message: fmt.Sprintf("config version %d is outdated (current: %d); run '%s'", v, CurrentVersion, fix)}, true
+}
+
+// merge merges the mapping src into dst, recording in origins the layer
+// that set each leaf. Mappings are merged recursively; other values replace.
+// Env layer origins name the variable rather than a file.
+func merge(dst, src *yaml.Node, t reflect.Type, prefix string, origin Origin, origins map[string]Origin) {
+	for i := 0; i+1 < len(src.Content); i += 2 {
+		keyNode, value := src.Content[i], src.Content[i+1]
+		key := prefix + keyNode.Value
+
+		// Record origins for the leaves List shows; free-form maps are one
+		// leaf, even when their contents are merged
+		fieldType := childType(t, keyNode.Value)
+		if expands(t) && !expands(fieldType) {
+			o := origin
+			if o.Source == SourceEnv {
+				o.Path = envName(key)
+			} else {
+				o.Line = keyNode.Line
+			}
+			origins[key] = o
+		}
+
+		existing := mappingValue(dst, keyNode.Value)
+		if value.Kind == yaml.MappingNode && (existing == nil || existing.Kind == yaml.MappingNode) {
+			if existing == nil {
+				existing = &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+				dst.Content = append(dst.Content, &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: keyNode.Value}, existing)
+			}
+			merge(existing, value, fieldType, key+".", origin, origins)
+			continue
+		}
+		copied := deepCopy(value)
+		if existing != nil {
+			*existing = *copied
+			continue
+		}
+		dst.Content = append(dst.Content, &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: keyNode.Value}, copied)
+	}
+}
+
+// deepCopy copies a node tree so later merges don't modify a layer.
+func deepCopy(node *yaml.Node) *yaml.Node {
+	copied := *node
+	copied.Content = make([]*yaml.Node, len(node.Content))
+	for i, child := range node.Content {
+		copied.Content[i] = deepCopy(child)
+	}
+	return &copied
+}
+
+// resolvePaths makes the relative path settings absolute against dir.
+func (d *Document) resolvePaths(dir string) {
+	for _, key := range pathKeys {
+		if node := d.lookup(key); node != nil && node.Kind == yaml.ScalarNode && node.Value != "" && !filepath.IsAbs(node.Value) {
+			node.Value = filepath.Join(dir, node.Value)
+		}
+	}
+}
+
+// EnvKeys returns every setting that can be set from the environment,
+// mapped to its variable name. The format version is per file, so it has
+// no variable.
+func EnvKeys() map[string]string {
+	keys := make(map[string]string)
+	var walk func(t reflect.Type, prefix string)
+	walk = func(t reflect.Type, prefix string) {
+		for name, fieldType := range schemaFields(t) {
+			if fieldType.Kind() == reflect.Struct {
+				walk(fieldType, prefix+name+".")
+				continue
+			}
+			if prefix+name == "version" {
+				continue
+			}
+			keys[prefix+name] = envName(prefix + name)
+		}
+	}
+	walk(reflect.TypeOf(Config{}), "")
+	return keys
+}
+
+// envName returns the environment variable for a setting.
+func envName(key string) string {
+	return EnvPrefix + strings.ToUpper(strings.ReplaceAll(key, ".", "_"))
+}
+
+// envDocument builds a layer from the M2CV_* variables in environ. Values
+// are interpreted as by Document.Set. Unknown variables are reported,
+// except the reserved ones that are not settings.
+func envDocument(environ []string) (*Document, []Problem) {
+	byName := make(map[string]string)
+	for key, name := range EnvKeys() {
+		byName[name] = key
+	}
+
+	doc, _ := ParseDocument(nil)
+	var problems []Problem
+	var names []string
+	values := make(map[string]string)
+	for _, kv := range environ {
+		name, value, ok := strings.Cut(kv, "=")
+		if !ok || !strings.HasPrefix(name, EnvPrefix) || reservedEnv[name] {
+			continue
+		}
+		names = append(names, name)
+		values[name] = value
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		key, ok := byName[name]
+		if !ok {
+			problems = append(problems, Problem{File: "environment", Key: name, Message: fmt.Sprintf("unknown variable %s", name)})
+			continue
+		}
+		if err := doc.Set(key, values[name]); err != nil {
+			problems = append(problems, Problem{File: "environment", Key: key, Message: fmt.Sprintf("%s: %v", name, err)})
+		}
+	}
+	return doc, problems
+}
@@ -0,0 +1,168 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// writeFile writes content to path, creating parent directories.
+func writeFile(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestLoadLayered(t *testing.T) {
+	dir := t.TempDir()
+	userPath := filepath.Join(dir, "home", "m2cv", "config.yml")
+	projectPath := filepath.Join(dir, "project", "m2cv.yml")
+	appDir := filepath.Join(dir, "project", "applications", "acme")
+
+	writeFile(t, userPath, `default_model: opus
+default_theme: flat
+base_cv_path: cv/base.md
+theme_options:
+  font_family: Inter
+  meta:
+    colors: {ink: black}
+    compact: true
+version: 2
+`)
+	writeFile(t, projectPath, `base_cv_path: ./base-cv.md
+default_theme: even
+themes: [even, flat]
+theme_options:
+  accent_color: teal
+  meta:
+    colors: {paper: white}
+version: 2
+`)
+	writeFile(t, filepath.Join(appDir, ApplicationFile), `default_theme: macchiato
+theme_options:
+  accent_color: red
+`)
+
+	l, err := LoadLayered(projectPath,
+		WithUserConfig(userPath),
+		WithApplication(appDir),
+		WithEnviron([]string{"M2CV_DEFAULT_MODEL=sonnet", "M2CV_CONFIG=ignored", "M2CV_MCP_TOKEN=ignored", "PATH=/bin"}),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cfg := l.Config
+	if cfg.DefaultModel != "sonnet" || cfg.DefaultTheme != "macchiato" || cfg.BaseCVPath != "./base-cv.md" || len(cfg.Themes) != 2 {
+		t.Errorf("config = %+v", cfg)
+	}
+	if cfg.ThemeOptions.FontFamily != "Inter" || cfg.ThemeOptions.AccentColor != "teal" {
+		t.Errorf("theme options = %+v (application theme_options belong to LoadStyle)", cfg.ThemeOptions)
+	}
+	colors, _ := cfg.ThemeOptions.Meta["colors"].(map[string]any)
+	if colors["ink"] != "black" || colors["paper"] != "white" || cfg.ThemeOptions.Meta["compact"] != true {
+		t.Errorf("meta should be merged: %v", cfg.ThemeOptions.Meta)
+	}
+
+	for key, want := range map[string]string{
+		"default_model":              "env M2CV_DEFAULT_MODEL",
+		"default_theme":              "application " + filepath.Join(appDir, ApplicationFile) + ":1",
+		"base_cv_path":               "project " + projectPath + ":1",
+		"theme_options.font_family":  "user " + userPath + ":5",
+		"theme_options.accent_color": "project " + projectPath + ":5",
+		"theme_options.meta":         "project " + projectPath + ":6",
+	} {
+		if got := l.Origins[key].String(); got != want {
+			t.Errorf("origin of %s = %q, want %q", key, got, want)
+		}
+	}
+	if len(l.Problems) != 0 {
+		t.Errorf("problems = %v", l.Problems)
+	}
+
+	value, origin, err := l.Get("default_theme")
+	if err != nil || value != "macchiato" || origin.Source != SourceApplication {
+		t.Errorf("Get(default_theme) = %q, %v, %v", value, origin, err)
+	}
+}
+
+func TestLoadLayered_UserPathsResolved(t *testing.T) {
+	dir := t.TempDir()
+	userPath := filepath.Join(dir, "home", "config.yml")
+	projectPath := filepath.Join(dir, "project", "m2cv.yml")
+	writeFile(t, userPath, "base_cv_path: cv.md\ntheme_options:\n  css: brand.css\n")
+	writeFile(t, projectPath, "version: 2\ndefault_theme: even\n")
+
+	l, err := LoadLayered(projectPath, WithUserConfig(userPath), WithEnviron(nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := filepath.Join(dir, "home", "cv.md"); l.Config.BaseCVPath != want {
+		t.Errorf("BaseCVPath = %q, want %q", l.Config.BaseCVPath, want)
+	}
+	if want := filepath.Join(dir, "home", "brand.css"); l.Config.ThemeOptions.CSS != want {
+		t.Errorf("CSS = %q, want %q", l.Config.ThemeOptions.CSS, want)
+	}
+}
+
+func TestLoadLayered_Env(t *testing.T) {
+	projectPath := filepath.Join(t.TempDir(), "m2cv.yml")
+	writeFile(t, projectPath, "version: 2\ndefault_theme: even\n")
+
+	l, err := LoadLayered(projectPath, WithUserConfig(""), WithEnviron([]string{
+		"M2CV_THEMES=even, flat",
+		"M2CV_THEME_OPTIONS_ACCENT_COLOR=#0a7",
+		"M2CV_THEME_OPTIONS_META={compact: true}",
+		"M2CV_DEFAULT_THEM=typo",
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	cfg := l.Config
+	if len(cfg.Themes) != 2 || cfg.Themes[1] != "flat" || cfg.ThemeOptions.AccentColor != "#0a7" || cfg.ThemeOptions.Meta["compact"] != true {
+		t.Errorf("config = %+v", cfg)
+	}
+	if len(l.Problems) != 1 || !strings.Contains(l.Problems[0].String(), "unknown variable M2CV_DEFAULT_THEM") {
+		t.Errorf("problems = %v", l.Problems)
+	}
+}
+
+func TestLoadLayered_Errors(t *testing.T) {
+	dir := t.TempDir()
+	if _, err := LoadLayered(filepath.Join(dir, "missing.yml"), WithUserConfig("")); err == nil {
+		t.Error("a missing project file should fail")
+	}
+
+	projectPath := filepath.Join(dir, "m2cv.yml")
+	userPath := filepath.Join(dir, "user.yml")
+	writeFile(t, projectPath, "version: 2\ndefault_theme: even\ncolour: red\n")
+	writeFile(t, userPath, "base_cv_path: [oops")
+	if _, err := LoadLayered(projectPath, WithUserConfig(userPath)); err == nil || !strings.Contains(err.Error(), userPath) {
+		t.Errorf("error = %v, want the user file named", err)
+	}
+
+	l, err := LoadLayered(projectPath, WithUserConfig(filepath.Join(dir, "none.yml")), WithEnviron(nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(l.Problems) != 1 || l.Problems[0].File != projectPath {
+		t.Errorf("problems = %v", l.Problems)
+	}
+}
+
+func TestUserConfigPath(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", "/xdg")
+	if got := UserConfigPath(); got != filepath.Join("/xdg", "m2cv", "config.yml") {
+		t.Errorf("UserConfigPath() = %q", got)
+	}
+	t.Setenv("XDG_CONFIG_HOME", "")
+	t.Setenv("HOME", "/home/jane")
+	if got := UserConfigPath(); got != filepath.Join("/home/jane", ".config", "m2cv", "config.yml") {
+		t.Errorf("UserConfigPath() = %q", got)
+	}
+}
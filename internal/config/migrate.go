@@ -0,0 +1,189 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Migration upgrades a config document by one format version.
+type Migration struct {
+	// From is the version the migration upgrades; it produces From+1.
+	From int
+	// Description summarises the format change.
+	Description string
+	// Apply edits the document and returns a line per change made.
+	Apply func(doc *Document) ([]string, error)
+}
+
+// migrations upgrade config files step by step, in order of From. To
+// change the format, append a migration; CurrentVersion follows.
+var migrations = []Migration{
+	{
+		From:        1,
+		Description: "record the format version; list the default theme in themes once",
+		Apply:       migrateThemes,
+	},
+}
+
+// CurrentVersion is the config format written by this version of m2cv.
+var CurrentVersion = len(migrations) + 1
+
+// MigrationReport describes what Migrate changed.
+type MigrationReport struct {
+	// From and To are the versions before and after.
+	From, To int
+	// Changes lists the edits made, one per line.
+	Changes []string
+}
+
+// Version returns the document's format version: its version key, or 1
+// when the key is absent.
+func (d *Document) Version() (int, error) {
+	node := mappingValue(d.root.Content[0], "version")
+	if node == nil {
+		return 1, nil
+	}
+	v, err := strconv.Atoi(node.Value)
+	if err != nil || v < 1 {
+		return 0, fmt.Errorf("invalid version %q: expected a positive integer", node.Value)
+	}
+	return v, nil
+}
+
+// Outdated reports whether the document should be migrated. A version
+// newer than CurrentVersion is an error: this m2cv cannot read it safely.
+func (d *Document) Outdated() (bool, error) {
+	v, err := d.Version()
+	if err != nil {
+		return false, err
+	}
+	if v > CurrentVersion {
+		return false, fmt.Errorf("config version %d is newer than this m2cv supports (%d); upgrade m2cv", v, CurrentVersion)
+	}
+	return v < CurrentVersion, nil
+}
+
+// Migrate upgrades the document to CurrentVersion, applying each
+// migration from its version in turn and recording the new version.
+func (d *Document) Migrate() (*MigrationReport, error) {
+	if _, err := d.Outdated(); err != nil {
+		return nil, err
+	}
+	from, _ := d.Version()
+	report := &MigrationReport{From: from, To: from}
+	for _, m := range migrations {
+		if m.From < report.To {
+			continue
+		}
+		changes, err := m.Apply(d)
+		if err != nil {
+			return nil, fmt.Errorf("migrating from version %d: %w", m.From, err)
+		}
+		report.To = m.From + 1
+		d.setVersion(report.To)
+		report.Changes = append(report.Changes, changes...)
+		report.Changes = append(report.Changes, fmt.Sprintf("set version to %d (%s)", report.To, m.Description))
+	}
+	if _, err := d.Config(); err != nil {
+		return nil, fmt.Errorf("migrated config is invalid: %w", err)
+	}
+	return report, nil
+}
+
+// MigrateFile migrates the file at path in place, first copying the
+// original to path.v<N>.bak. An up-to-date file is left untouched and
+// reported with no changes and an empty backup path.
+func MigrateFile(path string) (report *MigrationReport, backupPath string, err error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, "", err
+	}
+	doc, err := ParseDocument(data)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	report, err = doc.Migrate()
+	if err != nil {
+		return nil, "", err
+	}
+	if report.From == report.To {
+		return report, "", nil
+	}
+
+	backupPath = fmt.Sprintf("%s.v%d.bak", path, report.From)
+	if err := os.WriteFile(backupPath, data, 0644); err != nil {
+		return nil, "", fmt.Errorf("failed to back up %s: %w", path, err)
+	}
+	if err := doc.Save(path); err != nil {
+		return nil, "", fmt.Errorf("failed to save %s: %w", path, err)
+	}
+	return report, backupPath, nil
+}
+
+// NewDocument returns an empty document at CurrentVersion, for a config
+// file created from scratch, so it is never reported as outdated.
+func NewDocument() *Document {
+	doc, _ := ParseDocument(nil)
+	doc.setVersion(CurrentVersion)
+	return doc
+}
+
+// setVersion writes the version key, placing a new one first in the file
+// (after any header comment).
+func (d *Document) setVersion(v int) {
+	value := &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!int", Value: strconv.Itoa(v)}
+	top := d.root.Content[0]
+	if existing := mappingValue(top, "version"); existing != nil {
+		*existing = *value
+		return
+	}
+	key := &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: "version"}
+	if len(top.Content) > 0 {
+		key.HeadComment, top.Content[0].HeadComment = top.Content[0].HeadComment, ""
+	}
+	top.Content = append([]*yaml.Node{key, value}, top.Content...)
+}
+
+// migrateThemes is the version 1 migration: before version 2 the default
+// theme did not have to be listed in themes, and entries could repeat.
+func migrateThemes(d *Document) ([]string, error) {
+	cfg, err := d.Config()
+	if err != nil {
+		return nil, err
+	}
+
+	var changes []string
+	seen := make(map[string]bool)
+	var themes []string
+	for _, ref := range cfg.Themes {
+		if seen[ref] {
+			changes = append(changes, fmt.Sprintf("removed duplicate theme %q from themes", ref))
+			continue
+		}
+		seen[ref] = true
+		themes = append(themes, ref)
+	}
+	if cfg.DefaultTheme != "" && !seen[cfg.DefaultTheme] {
+		themes = append(themes, cfg.DefaultTheme)
+		changes = append(changes, fmt.Sprintf("added default theme %q to themes", cfg.DefaultTheme))
+	}
+	if len(changes) == 0 {
+		return nil, nil
+	}
+
+	node := &yaml.Node{Kind: yaml.SequenceNode, Tag: "!!seq"}
+	for _, ref := range themes {
+		node.Content = append(node.Content, &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: ref})
+	}
+	if existing := mappingValue(d.root.Content[0], "themes"); existing != nil {
+		node.HeadComment, node.LineComment, node.FootComment = existing.HeadComment, existing.LineComment, existing.FootComment
+		node.Style = existing.Style
+		*existing = *node
+	} else {
+		d.root.Content[0].Content = append(d.root.Content[0].Content, &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: "themes"}, node)
+	}
+	return changes, nil
+}
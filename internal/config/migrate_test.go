@@ -0,0 +1,95 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestMigrate(t *testing.T) {
+	doc, err := ParseDocument([]byte("# my project\nbase_cv_path: cv.md\ndefault_theme: even\nthemes: [flat, flat]\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if outdated, err := doc.Outdated(); err != nil || !outdated {
+		t.Fatalf("Outdated() = %v, %v", outdated, err)
+	}
+
+	report, err := doc.Migrate()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if report.From != 1 || report.To != CurrentVersion || len(report.Changes) != 3 {
+		t.Errorf("report = %+v", report)
+	}
+
+	data, _ := doc.Bytes()
+	if !strings.HasPrefix(string(data), "# my project\nversion: 2\n") || !strings.Contains(string(data), "themes: [flat, even]") {
+		t.Errorf("migrated:\n%s", data)
+	}
+
+	// Migrating again changes nothing
+	report, err = doc.Migrate()
+	if err != nil || report.From != report.To || len(report.Changes) != 0 {
+		t.Errorf("second Migrate() = %+v, %v", report, err)
+	}
+}
+
+func TestMigrate_NewerVersion(t *testing.T) {
+	doc, _ := ParseDocument([]byte("version: 99\n"))
+	if _, err := doc.Migrate(); err == nil || !strings.Contains(err.Error(), "upgrade m2cv") {
+		t.Errorf("Migrate() error = %v", err)
+	}
+	doc, _ = ParseDocument([]byte("version: two\n"))
+	if _, err := doc.Version(); err == nil {
+		t.Error("a non-numeric version should fail")
+	}
+}
+
+func TestMigrateFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "m2cv.yml")
+	original := "default_theme: even\n"
+	if err := os.WriteFile(path, []byte(original), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	report, backup, err := MigrateFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if report.To != CurrentVersion || backup != path+".v1.bak" {
+		t.Errorf("report = %+v, backup = %s", report, backup)
+	}
+	if data, _ := os.ReadFile(backup); string(data) != original {
+		t.Errorf("backup = %q", data)
+	}
+	cfg, err := NewRepository().Load(path)
+	if err != nil || cfg.Version != CurrentVersion || len(cfg.Themes) != 1 {
+		t.Errorf("migrated config = %+v, %v", cfg, err)
+	}
+
+	// Up to date: no backup
+	if _, backup, err := MigrateFile(path); err != nil || backup != "" {
+		t.Errorf("second MigrateFile() backup = %q, err = %v", backup, err)
+	}
+}
+
+func TestLoadLayered_OutdatedVersion(t *testing.T) {
+	dir := t.TempDir()
+	projectPath := filepath.Join(dir, "m2cv.yml")
+	userPath := filepath.Join(dir, "user.yml")
+	writeFile(t, projectPath, "default_theme: even\n")
+	writeFile(t, userPath, "version: 99\n")
+
+	l, err := LoadLayered(projectPath, WithUserConfig(userPath), WithEnviron(nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(l.Problems) != 2 || !strings.Contains(l.Problems[0].Message, "newer") || !strings.Contains(l.Problems[1].Message, "m2cv config migrate") {
+		t.Errorf("problems = %v", l.Problems)
+	}
+	if _, ok := l.Origins["version"]; ok {
+		t.Error("version should not be merged")
+	}
+}
@@ -0,0 +1,59 @@
+package config
+
+// ModelProfileNames are the keys accepted in the models block: the
+// commands that call Claude, and the prompts they use. A prompt's profile
+// is layered over its command's, so "optimize-ats" can differ from the
+// plain "optimize" prompt.
+var ModelProfileNames = []string{
+	// Commands
+	"optimize", "generate",
+	// Prompts
+	"optimize-ats", "optimize-update", "interactive", "md-to-json-resume",
+}
+
+// ModelProfile configures the Claude CLI for one command or prompt.
+type ModelProfile struct {
+	// Model is the Claude model (e.g. "opus" or "claude-sonnet-4-20250514").
+	Model string `yaml:"model,omitempty" json:"model,omitempty"`
+	// MaxTurns limits the agentic turns of a run (claude --max-turns).
+	MaxTurns int `yaml:"max_turns,omitempty" json:"max_turns,omitempty"`
+	// Args are extra claude CLI arguments, appended after m2cv's own.
+	Args []string `yaml:"args,omitempty" json:"args,omitempty"`
+}
+
+// ModelSettings resolves the profile for each command and prompt from
+// default_model and the models block.
+type ModelSettings struct {
+	// Default is default_model, used where no profile sets a model.
+	Default string `json:"default,omitempty"`
+	// Profiles is the models block.
+	Profiles map[string]ModelProfile `json:"profiles,omitempty"`
+}
+
+// ModelSettings returns the model settings of c.
+func (c *Config) ModelSettings() ModelSettings {
+	return ModelSettings{Default: c.DefaultModel, Profiles: c.Models}
+}
+
+// Resolve returns the profile for a prompt run by command: default_model,
+// overridden field by field by the command's profile and then the prompt's.
+// Args are replaced, not appended.
+func (s ModelSettings) Resolve(command, prompt string) ModelProfile {
+	p := ModelProfile{Model: s.Default}
+	for _, name := range []string{command, prompt} {
+		override, ok := s.Profiles[name]
+		if !ok {
+			continue
+		}
+		if override.Model != "" {
+			p.Model = override.Model
+		}
+		if override.MaxTurns > 0 {
+			p.MaxTurns = override.MaxTurns
+		}
+		if override.Args != nil {
+			p.Args = override.Args
+		}
+	}
+	return p
+}
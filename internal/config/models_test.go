@@ -0,0 +1,73 @@
+package config
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestModelSettings_Resolve(t *testing.T) {
+	cfg := &Config{
+		DefaultModel: "sonnet",
+		Models: map[string]ModelProfile{
+			"optimize":          {Model: "opus", MaxTurns: 4, Args: []string{"--effort", "high"}},
+			"optimize-ats":      {MaxTurns: 2},
+			"md-to-json-resume": {Model: "haiku", Args: []string{}},
+		},
+	}
+	s := cfg.ModelSettings()
+
+	tests := []struct {
+		command, prompt string
+		want            ModelProfile
+	}{
+		{"optimize", "optimize", ModelProfile{Model: "opus", MaxTurns: 4, Args: []string{"--effort", "high"}}},
+		{"optimize", "optimize-ats", ModelProfile{Model: "opus", MaxTurns: 2, Args: []string{"--effort", "high"}}},
+		{"generate", "md-to-json-resume", ModelProfile{Model: "haiku", Args: []string{}}},
+		{"generate", "generate", ModelProfile{Model: "sonnet"}},
+	}
+	for _, tt := range tests {
+		if got := s.Resolve(tt.command, tt.prompt); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("Resolve(%s, %s) = %+v, want %+v", tt.command, tt.prompt, got, tt.want)
+		}
+	}
+}
+
+func TestValidate_Models(t *testing.T) {
+	cfg := &Config{Models: map[string]ModelProfile{
+		"optimise": {Model: "opus"},
+		"generate": {Model: "gpt-4", MaxTurns: -1},
+	}}
+	var got []string
+	for _, p := range cfg.validateModels() {
+		got = append(got, p.Key)
+	}
+	if strings.Join(got, " ") != "models.generate.model models.generate.max_turns models.optimise" {
+		t.Errorf("problem keys = %v", got)
+	}
+	if p := cfg.validateModels()[2]; !strings.Contains(p.Message, `did you mean "optimize"`) {
+		t.Errorf("message = %s", p.Message)
+	}
+}
+
+func TestUnknownKeys_Models(t *testing.T) {
+	problems, err := UnknownKeys([]byte("models:\n  optimize:\n    model: opus\n    max_turn: 3\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(problems) != 1 || !strings.Contains(problems[0].Message, `did you mean "models.optimize.max_turns"`) {
+		t.Errorf("problems = %v", problems)
+	}
+
+	doc, _ := ParseDocument(nil)
+	if err := doc.Set("models.generate.max_turns", "3"); err != nil {
+		t.Fatal(err)
+	}
+	cfg, err := doc.Config()
+	if err != nil || cfg.Models["generate"].MaxTurns != 3 {
+		t.Errorf("config = %+v, %v", cfg, err)
+	}
+	if settings := doc.List(); len(settings) != 1 || settings[0].Key != "models.generate.max_turns" {
+		t.Errorf("List() = %v", settings)
+	}
+}
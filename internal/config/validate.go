@@ -0,0 +1,251 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Problem is an issue found in a configuration file. Problems are
+// warnings: m2cv still runs, but the setting is probably not doing what
+// the user intended.
+type Problem struct {
+	// File is the file (or "environment") the problem was found in, when
+	// not implied by the caller.
+	File string
+	// Line is the 1-based line in m2cv.yml, or 0 when not tied to a line.
+	Line int
+	// Key is the dotted key the problem concerns (e.g. "theme_options.margin").
+	Key string
+	// Message describes the problem.
+	Message string
+}
+
+// String formats the problem as "[file: ][line N: ]message".
+func (p Problem) String() string {
+	msg := p.Message
+	if p.Line > 0 {
+		msg = fmt.Sprintf("line %d: %s", p.Line, msg)
+	}
+	if p.File != "" {
+		msg = p.File + ": " + msg
+	}
+	return msg
+}
+
+// modelPattern matches full Claude model names such as
+// "claude-sonnet-4-20250514", optionally with a context suffix like "[1m]".
+var modelPattern = regexp.MustCompile(`^claude-[a-z0-9]+(?:[.-][a-z0-9]+)*(?:\[[a-z0-9]+\])?$`)
+
+// modelAliases are the short model names accepted by the claude CLI.
+var modelAliases = map[string]bool{"default": true, "sonnet": true, "opus": true, "haiku": true, "opusplan": true}
+
+// ValidModel reports whether name is a well-formed Claude model name: a
+// claude CLI alias (sonnet, opus, haiku, ...) or a full "claude-..." name.
+func ValidModel(name string) bool {
+	base, _, _ := strings.Cut(name, "[")
+	return modelAliases[base] || modelPattern.MatchString(name)
+}
+
+// UnknownKeys decodes data strictly and reports keys that m2cv does not
+// recognise, suggesting the closest known key for likely typos. Free-form
+// maps (theme_options.meta) accept any key. A YAML syntax error is returned
+// as an error.
+func UnknownKeys(data []byte) ([]Problem, error) {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, err
+	}
+	if len(doc.Content) == 0 {
+		return nil, nil
+	}
+	var problems []Problem
+	unknownKeys(doc.Content[0], reflect.TypeOf(Config{}), "", &problems)
+	return problems, nil
+}
+
+// unknownKeys walks node alongside the struct type t.
+func unknownKeys(node *yaml.Node, t reflect.Type, prefix string, problems *[]Problem) {
+	if node.Kind != yaml.MappingNode || !expands(t) {
+		return
+	}
+	if t.Kind() == reflect.Map {
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			unknownKeys(node.Content[i+1], t.Elem(), prefix+node.Content[i].Value+".", problems)
+		}
+		return
+	}
+	fields := schemaFields(t)
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		keyNode, valueNode := node.Content[i], node.Content[i+1]
+		key := prefix + keyNode.Value
+		fieldType, ok := fields[keyNode.Value]
+		if !ok {
+			msg := fmt.Sprintf("unknown key %q", key)
+			if suggestion := closest(keyNode.Value, sortedKeys(fields)); suggestion != "" {
+				msg += fmt.Sprintf(" (did you mean %q?)", prefix+suggestion)
+			}
+			*problems = append(*problems, Problem{Line: keyNode.Line, Key: key, Message: msg})
+			continue
+		}
+		unknownKeys(valueNode, fieldType, key+".", problems)
+	}
+}
+
+// Validate checks the settings for problems the YAML schema cannot catch:
+// a missing base CV, an uninstalled theme and a malformed model name.
+// Relative paths are resolved against projectDir. themeInstalled reports
+// whether a theme reference is installed; nil skips the theme check.
+func (c *Config) Validate(projectDir string, themeInstalled func(ref string) bool) []Problem {
+	var problems []Problem
+	if c.BaseCVPath == "" {
+		problems = append(problems, Problem{Key: "base_cv_path", Message: "base_cv_path is not set"})
+	} else {
+		path := c.BaseCVPath
+		if !filepath.IsAbs(path) {
+			path = filepath.Join(projectDir, path)
+		}
+		if _, err := os.Stat(path); err != nil {
+			problems = append(problems, Problem{Key: "base_cv_path", Message: fmt.Sprintf("base CV not found: %s", path)})
+		}
+	}
+
+	if themeInstalled != nil {
+		seen := make(map[string]bool)
+		for i, ref := range append([]string{c.DefaultTheme}, c.Themes...) {
+			if ref == "" || seen[ref] {
+				continue
+			}
+			seen[ref] = true
+			if themeInstalled(ref) {
+				continue
+			}
+			key := "themes"
+			if i == 0 {
+				key = "default_theme"
+			}
+			problems = append(problems, Problem{Key: key, Message: fmt.Sprintf("theme %q is not installed (run: m2cv theme add %s)", ref, ref)})
+		}
+	}
+
+	if c.DefaultModel != "" && !ValidModel(c.DefaultModel) {
+		problems = append(problems, Problem{Key: "default_model", Message: fmt.Sprintf("default_model %q is not a Claude model name (e.g. sonnet or claude-sonnet-4-20250514)", c.DefaultModel)})
+	}
+	return append(problems, c.validateModels()...)
+}
+
+// validateModels checks the models block: known profile names,
+// well-formed models and a non-negative max_turns.
+func (c *Config) validateModels() []Problem {
+	known := make(map[string]bool, len(ModelProfileNames))
+	for _, name := range ModelProfileNames {
+		known[name] = true
+	}
+	names := make([]string, 0, len(c.Models))
+	for name := range c.Models {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var problems []Problem
+	for _, name := range names {
+		profile := c.Models[name]
+		key := "models." + name
+		if !known[name] {
+			msg := fmt.Sprintf("%s: unknown command or prompt %q", key, name)
+			if suggestion := closest(name, ModelProfileNames); suggestion != "" {
+				msg += fmt.Sprintf(" (did you mean %q?)", suggestion)
+			}
+			problems = append(problems, Problem{Key: key, Message: msg})
+		}
+		if profile.Model != "" && !ValidModel(profile.Model) {
+			problems = append(problems, Problem{Key: key + ".model", Message: fmt.Sprintf("%s.model %q is not a Claude model name", key, profile.Model)})
+		}
+		if profile.MaxTurns < 0 {
+			problems = append(problems, Problem{Key: key + ".max_turns", Message: fmt.Sprintf("%s.max_turns must be positive", key)})
+		}
+	}
+	return problems
+}
+
+// schemaFields returns the YAML keys of struct type t and their types.
+func schemaFields(t reflect.Type) map[string]reflect.Type {
+	fields := make(map[string]reflect.Type)
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		name, _, _ := strings.Cut(f.Tag.Get("yaml"), ",")
+		if name == "" || name == "-" {
+			continue
+		}
+		fields[name] = f.Type
+	}
+	return fields
+}
+
+// expands reports whether settings of type t are made of individual keys
+// with a known schema: structs, and maps of structs (e.g. models).
+func expands(t reflect.Type) bool {
+	return t != nil && (t.Kind() == reflect.Struct || t.Kind() == reflect.Map && t.Elem().Kind() == reflect.Struct)
+}
+
+// childType returns the type of key within t, or nil if unknown.
+func childType(t reflect.Type, key string) reflect.Type {
+	switch {
+	case t == nil:
+		return nil
+	case t.Kind() == reflect.Struct:
+		return schemaFields(t)[key]
+	case t.Kind() == reflect.Map:
+		return t.Elem()
+	}
+	return nil
+}
+
+// sortedKeys returns the keys of m in order.
+func sortedKeys(m map[string]reflect.Type) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// closest returns the candidate nearest to name by edit distance, or ""
+// when none is close enough to be a plausible typo.
+func closest(name string, candidates []string) string {
+	best, bestDist := "", len(name)/3+2
+	for _, c := range candidates {
+		if d := editDistance(name, c); d < bestDist {
+			best, bestDist = c, d
+		}
+	}
+	return best
+}
+
+// editDistance is the Levenshtein distance between a and b.
+func editDistance(a, b string) int {
+	prev := make([]int, len(b)+1)
+	cur := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		cur[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			cur[j] = min(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+		}
+		prev, cur = cur, prev
+	}
+	return prev[len(b)]
+}
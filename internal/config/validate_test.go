@@ -0,0 +1,85 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestUnknownKeys(t *testing.T) {
+	data := []byte(`base_cv_path: cv.md
+default_them: even
+theme_options:
+  accent_colour: teal
+  meta:
+    anything: goes
+colour: red
+`)
+	problems, err := UnknownKeys(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{
+		`line 2: unknown key "default_them" (did you mean "default_theme"?)`,
+		`line 4: unknown key "theme_options.accent_colour" (did you mean "theme_options.accent_color"?)`,
+		`line 7: unknown key "colour"`,
+	}
+	if len(problems) != len(want) {
+		t.Fatalf("problems = %v, want %v", problems, want)
+	}
+	for i, p := range problems {
+		if p.String() != want[i] {
+			t.Errorf("problems[%d] = %q, want %q", i, p, want[i])
+		}
+	}
+}
+
+func TestUnknownKeys_InvalidYAML(t *testing.T) {
+	if _, err := UnknownKeys([]byte("base_cv_path: [oops")); err == nil {
+		t.Error("expected a syntax error")
+	}
+	if problems, err := UnknownKeys(nil); err != nil || len(problems) != 0 {
+		t.Errorf("empty file: %v, %v", problems, err)
+	}
+}
+
+func TestValidModel(t *testing.T) {
+	for _, name := range []string{"sonnet", "opus", "haiku", "claude-sonnet-4-20250514", "claude-3-5-haiku-latest", "claude-opus-4.1", "sonnet[1m]", "claude-sonnet-4-5[1m]"} {
+		if !ValidModel(name) {
+			t.Errorf("ValidModel(%q) = false", name)
+		}
+	}
+	for _, name := range []string{"", "gpt-4", "Claude-Sonnet", "claude-", "claude sonnet", "sonet"} {
+		if ValidModel(name) {
+			t.Errorf("ValidModel(%q) = true", name)
+		}
+	}
+}
+
+func TestValidate(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "cv.md"), []byte("# CV"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	installed := func(ref string) bool { return ref == "even" }
+
+	cfg := &Config{BaseCVPath: "cv.md", DefaultTheme: "even", Themes: []string{"even"}, DefaultModel: "sonnet"}
+	if problems := cfg.Validate(dir, installed); len(problems) != 0 {
+		t.Errorf("valid config: %v", problems)
+	}
+
+	cfg = &Config{BaseCVPath: "missing.md", DefaultTheme: "flat", Themes: []string{"flat", "macchiato"}, DefaultModel: "gpt-4"}
+	problems := cfg.Validate(dir, installed)
+	keys := make([]string, len(problems))
+	for i, p := range problems {
+		keys[i] = p.Key
+	}
+	if got := strings.Join(keys, " "); got != "base_cv_path default_theme themes default_model" {
+		t.Errorf("problem keys = %s: %v", got, problems)
+	}
+
+	if problems := cfg.Validate(dir, nil); len(problems) != 2 {
+		t.Errorf("nil themeInstalled should skip theme checks: %v", problems)
+	}
+}
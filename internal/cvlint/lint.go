@@ -0,0 +1,146 @@
+// Package cvlint checks a markdown base CV against the conventions the
+// JSON Resume conversion relies on (see "Markdown CV Format" in the README),
+// so problems show up before a Claude call rather than in the PDF.
+package cvlint
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Severity ranks an issue.
+type Severity string
+
+const (
+	// Error marks content the conversion will lose or get wrong.
+	Error Severity = "error"
+	// Warning marks content that deviates from the conventions.
+	Warning Severity = "warning"
+)
+
+// Issue is a single lint finding.
+type Issue struct {
+	// Line is the 1-based line number, or 0 for the whole document.
+	Line int `json:"line,omitempty"`
+	// Severity is Error or Warning.
+	Severity Severity `json:"severity"`
+	// Message describes the problem.
+	Message string `json:"message"`
+}
+
+// String formats the issue as "line N: message".
+func (i Issue) String() string {
+	if i.Line == 0 {
+		return i.Message
+	}
+	return fmt.Sprintf("line %d: %s", i.Line, i.Message)
+}
+
+// Sections are the top-level headings the conversion maps to JSON Resume.
+var Sections = []string{"Summary", "Experience", "Education", "Skills", "Projects", "Languages", "Certificates"}
+
+// entrySections need "## Title | Organisation" entry headings.
+var entrySections = map[string]string{
+	"experience": "## Title | Company",
+	"education":  "## Degree | Institution",
+}
+
+// datePattern matches "*2021-01 - present*" style date lines.
+var datePattern = regexp.MustCompile(`(?i)^\*\d{4}(-\d{2}){0,2}\s+-\s+(\d{4}(-\d{2}){0,2}|present)\*$`)
+
+// Lint checks content and returns its issues in document order.
+func Lint(content []byte) []Issue {
+	if len(bytes.TrimSpace(content)) == 0 {
+		return []Issue{{Severity: Error, Message: "base CV is empty"}}
+	}
+
+	lines := strings.Split(strings.ReplaceAll(string(content), "\r\n", "\n"), "\n")
+	issues, body := lintFrontmatter(lines)
+
+	seen := make(map[string]bool)
+	section := ""
+	for i := body; i < len(lines); i++ {
+		line := strings.TrimSpace(lines[i])
+		lineNo := i + 1
+		switch {
+		case strings.HasPrefix(line, "# "):
+			title := strings.TrimSpace(strings.TrimPrefix(line, "# "))
+			section = strings.ToLower(title)
+			if !isKnownSection(title) {
+				issues = append(issues, Issue{lineNo, Warning, fmt.Sprintf("unknown section %q; expected one of %s", title, strings.Join(Sections, ", "))})
+			} else if seen[section] {
+				issues = append(issues, Issue{lineNo, Warning, fmt.Sprintf("section %q appears more than once", title)})
+			}
+			seen[section] = true
+		case strings.HasPrefix(line, "## "):
+			if want, ok := entrySections[section]; ok && !strings.Contains(line, "|") {
+				issues = append(issues, Issue{lineNo, Warning, fmt.Sprintf("entry heading should look like %q", want)})
+			}
+		case strings.HasPrefix(line, "*") && !strings.HasPrefix(line, "**") && strings.HasSuffix(line, "*"):
+			if !datePattern.MatchString(line) {
+				issues = append(issues, Issue{lineNo, Warning, fmt.Sprintf("dates %s should look like *2021-01 - present*", line)})
+			}
+		}
+	}
+
+	if !seen["experience"] {
+		issues = append(issues, Issue{Severity: Warning, Message: "no # Experience section"})
+	}
+	return issues
+}
+
+// lintFrontmatter checks the YAML frontmatter and returns its issues and
+// the index of the first body line.
+func lintFrontmatter(lines []string) ([]Issue, int) {
+	if strings.TrimSpace(lines[0]) != "---" {
+		return []Issue{{1, Warning, "no YAML frontmatter; contact details (name, email, ...) will be missing"}}, 0
+	}
+
+	end := -1
+	for i := 1; i < len(lines); i++ {
+		if strings.TrimSpace(lines[i]) == "---" {
+			end = i
+			break
+		}
+	}
+	if end < 0 {
+		return []Issue{{1, Error, "frontmatter is not closed with ---"}}, len(lines)
+	}
+
+	var basics map[string]any
+	if err := yaml.Unmarshal([]byte(strings.Join(lines[1:end], "\n")), &basics); err != nil {
+		return []Issue{{2, Error, fmt.Sprintf("frontmatter is not valid YAML: %v", err)}}, end + 1
+	}
+	var issues []Issue
+	if name, _ := basics["name"].(string); strings.TrimSpace(name) == "" {
+		issues = append(issues, Issue{1, Error, "frontmatter has no name"})
+	}
+	if _, ok := basics["email"]; !ok {
+		issues = append(issues, Issue{1, Warning, "frontmatter has no email"})
+	}
+	return issues, end + 1
+}
+
+// isKnownSection reports whether title is one of Sections (case-insensitive).
+func isKnownSection(title string) bool {
+	for _, s := range Sections {
+		if strings.EqualFold(s, title) {
+			return true
+		}
+	}
+	return false
+}
+
+// HasErrors reports whether any issue is an Error.
+func HasErrors(issues []Issue) bool {
+	for _, issue := range issues {
+		if issue.Severity == Error {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,84 @@
+package cvlint
+
+import (
+	"reflect"
+	"testing"
+)
+
+const validCV = `---
+name: Jane Doe
+email: jane@example.com
+---
+
+# Summary
+Engineer.
+
+# Experience
+## Senior Developer | Acme Corp
+*2021-01 - present*
+- Led migration
+
+# Skills
+## Backend
+- Go
+`
+
+func TestLint_Valid(t *testing.T) {
+	if issues := Lint([]byte(validCV)); len(issues) != 0 {
+		t.Errorf("Lint() = %v, want no issues", issues)
+	}
+}
+
+func TestLint_Issues(t *testing.T) {
+	cv := `---
+label: Engineer
+---
+# Experience
+## Senior Developer at Acme
+*Jan 2021 to now*
+**Bold line**
+
+# Hobbies
+- Chess
+
+# experience
+`
+	want := []Issue{
+		{1, Error, "frontmatter has no name"},
+		{1, Warning, "frontmatter has no email"},
+		{5, Warning, `entry heading should look like "## Title | Company"`},
+		{6, Warning, "dates *Jan 2021 to now* should look like *2021-01 - present*"},
+		{9, Warning, `unknown section "Hobbies"; expected one of Summary, Experience, Education, Skills, Projects, Languages, Certificates`},
+		{12, Warning, `section "experience" appears more than once`},
+	}
+	got := Lint([]byte(cv))
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Lint() =\n%v\nwant\n%v", got, want)
+	}
+	if !HasErrors(got) {
+		t.Error("HasErrors() = false")
+	}
+}
+
+func TestLint_Frontmatter(t *testing.T) {
+	tests := map[string]Issue{
+		"":                           {0, Error, "base CV is empty"},
+		"# Experience\n":             {1, Warning, "no YAML frontmatter; contact details (name, email, ...) will be missing"},
+		"---\nname: Jane\n":          {1, Error, "frontmatter is not closed with ---"},
+		"---\nname: [Jane\n---\n# x": {2, Error, ""},
+	}
+	for cv, want := range tests {
+		issues := Lint([]byte(cv))
+		if len(issues) == 0 {
+			t.Errorf("Lint(%q) = no issues", cv)
+			continue
+		}
+		got := issues[0]
+		if want.Message == "" {
+			got.Message = ""
+		}
+		if got != want {
+			t.Errorf("Lint(%q)[0] = %+v, want %+v", cv, got, want)
+		}
+	}
+}
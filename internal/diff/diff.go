@@ -0,0 +1,198 @@
+// Package diff computes line-based differences between CV versions and
+// renders them as unified diff hunks.
+package diff
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Kind classifies a line in an edit script.
+type Kind int
+
+const (
+	// Equal lines appear in both inputs.
+	Equal Kind = iota
+	// Delete lines appear only in the old input.
+	Delete
+	// Insert lines appear only in the new input.
+	Insert
+)
+
+// prefix returns the unified diff marker for the kind.
+func (k Kind) prefix() string {
+	switch k {
+	case Delete:
+		return "-"
+	case Insert:
+		return "+"
+	default:
+		return " "
+	}
+}
+
+// Edit is a single line of an edit script.
+type Edit struct {
+	Kind Kind
+	Text string
+}
+
+// Hunk is a contiguous group of edits with surrounding context.
+// Line numbers are 1-based, as in unified diff headers.
+type Hunk struct {
+	OldStart, OldLines int
+	NewStart, NewLines int
+	Edits              []Edit
+}
+
+// Header returns the unified diff hunk header, e.g. "@@ -3,4 +3,5 @@".
+func (h Hunk) Header() string {
+	return fmt.Sprintf("@@ -%d,%d +%d,%d @@", h.OldStart, h.OldLines, h.NewStart, h.NewLines)
+}
+
+// SplitLines splits text into lines without trailing newlines.
+// An empty string yields no lines.
+func SplitLines(text string) []string {
+	if text == "" {
+		return nil
+	}
+	return strings.Split(strings.TrimSuffix(text, "\n"), "\n")
+}
+
+// Lines computes the edit script turning a into b using a longest common
+// subsequence table. CVs are a few hundred lines, so the quadratic table
+// is cheap and keeps the algorithm easy to follow.
+func Lines(a, b []string) []Edit {
+	n, m := len(a), len(b)
+
+	// lcs[i][j] is the LCS length of a[i:] and b[j:]
+	lcs := make([][]int, n+1)
+	for i := range lcs {
+		lcs[i] = make([]int, m+1)
+	}
+	for i := n - 1; i >= 0; i-- {
+		for j := m - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	edits := make([]Edit, 0, n+m)
+	i, j := 0, 0
+	for i < n && j < m {
+		switch {
+		case a[i] == b[j]:
+			edits = append(edits, Edit{Equal, a[i]})
+			i++
+			j++
+		case lcs[i+1][j] >= lcs[i][j+1]:
+			edits = append(edits, Edit{Delete, a[i]})
+			i++
+		default:
+			edits = append(edits, Edit{Insert, b[j]})
+			j++
+		}
+	}
+	for ; i < n; i++ {
+		edits = append(edits, Edit{Delete, a[i]})
+	}
+	for ; j < m; j++ {
+		edits = append(edits, Edit{Insert, b[j]})
+	}
+	return edits
+}
+
+// Hunks groups an edit script into hunks with up to context lines of
+// unchanged text around each change. Returns nil if there are no changes.
+func Hunks(edits []Edit, context int) []Hunk {
+	var hunks []Hunk
+
+	// Track 1-based line numbers in old and new inputs for each edit
+	oldLine, newLine := make([]int, len(edits)), make([]int, len(edits))
+	o, n := 1, 1
+	for i, e := range edits {
+		oldLine[i], newLine[i] = o, n
+		if e.Kind != Insert {
+			o++
+		}
+		if e.Kind != Delete {
+			n++
+		}
+	}
+
+	for i := 0; i < len(edits); {
+		if edits[i].Kind == Equal {
+			i++
+			continue
+		}
+
+		// Extend the hunk while changes are within 2*context lines of each other
+		start := max(i-context, 0)
+		end := i
+		for end < len(edits) {
+			if edits[end].Kind != Equal {
+				end++
+				continue
+			}
+			run := end
+			for run < len(edits) && edits[run].Kind == Equal {
+				run++
+			}
+			if run == len(edits) || run-end > 2*context {
+				end = min(end+context, len(edits))
+				break
+			}
+			end = run
+		}
+
+		h := Hunk{
+			OldStart: oldLine[start],
+			NewStart: newLine[start],
+			Edits:    edits[start:end],
+		}
+		for _, e := range h.Edits {
+			if e.Kind != Insert {
+				h.OldLines++
+			}
+			if e.Kind != Delete {
+				h.NewLines++
+			}
+		}
+		// Unified diff convention: an empty range starts at the preceding line
+		if h.OldLines == 0 {
+			h.OldStart--
+		}
+		if h.NewLines == 0 {
+			h.NewStart--
+		}
+		hunks = append(hunks, h)
+		i = end
+	}
+
+	return hunks
+}
+
+// Unified renders a unified diff between two texts.
+// Returns an empty string when the texts are identical.
+func Unified(oldName, newName, oldText, newText string, context int) string {
+	hunks := Hunks(Lines(SplitLines(oldText), SplitLines(newText)), context)
+	if len(hunks) == 0 {
+		return ""
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "--- %s\n+++ %s\n", oldName, newName)
+	for _, h := range hunks {
+		b.WriteString(h.Header())
+		b.WriteByte('\n')
+		for _, e := range h.Edits {
+			b.WriteString(e.Kind.prefix())
+			b.WriteString(e.Text)
+			b.WriteByte('\n')
+		}
+	}
+	return b.String()
+}
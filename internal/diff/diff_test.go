@@ -0,0 +1,120 @@
+package diff
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// apply reconstructs both inputs from an edit script.
+func apply(edits []Edit) (oldLines, newLines []string) {
+	for _, e := range edits {
+		if e.Kind != Insert {
+			oldLines = append(oldLines, e.Text)
+		}
+		if e.Kind != Delete {
+			newLines = append(newLines, e.Text)
+		}
+	}
+	return oldLines, newLines
+}
+
+func TestLines(t *testing.T) {
+	tests := []struct {
+		name        string
+		a, b        []string
+		wantChanges int
+	}{
+		{"identical", []string{"a", "b"}, []string{"a", "b"}, 0},
+		{"insert", []string{"a", "c"}, []string{"a", "b", "c"}, 1},
+		{"delete", []string{"a", "b", "c"}, []string{"a", "c"}, 1},
+		{"replace", []string{"a", "b", "c"}, []string{"a", "x", "c"}, 2},
+		{"from empty", nil, []string{"a", "b"}, 2},
+		{"to empty", []string{"a", "b"}, nil, 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			edits := Lines(tt.a, tt.b)
+
+			gotOld, gotNew := apply(edits)
+			if !reflect.DeepEqual(gotOld, tt.a) || !reflect.DeepEqual(gotNew, tt.b) {
+				t.Errorf("edit script does not reproduce inputs: old=%v new=%v", gotOld, gotNew)
+			}
+
+			changes := 0
+			for _, e := range edits {
+				if e.Kind != Equal {
+					changes++
+				}
+			}
+			if changes != tt.wantChanges {
+				t.Errorf("got %d changed lines, want %d", changes, tt.wantChanges)
+			}
+		})
+	}
+}
+
+func TestHunks_SplitsDistantChanges(t *testing.T) {
+	var a, b []string
+	for i := 0; i < 20; i++ {
+		line := string(rune('a' + i))
+		a = append(a, line)
+		b = append(b, line)
+	}
+	b[1] = "CHANGED-1"
+	b[15] = "CHANGED-15"
+
+	hunks := Hunks(Lines(a, b), 2)
+	if len(hunks) != 2 {
+		t.Fatalf("got %d hunks, want 2", len(hunks))
+	}
+	if got := hunks[0].Header(); got != "@@ -1,4 +1,4 @@" {
+		t.Errorf("first hunk header = %q", got)
+	}
+	if got := hunks[1].Header(); got != "@@ -14,5 +14,5 @@" {
+		t.Errorf("second hunk header = %q", got)
+	}
+
+	// Nearby changes merge into one hunk
+	b[4] = "CHANGED-4"
+	if hunks := Hunks(Lines(a, b), 2); len(hunks) != 2 {
+		t.Errorf("changes 3 lines apart should merge, got %d hunks", len(hunks))
+	}
+}
+
+func TestHunks_NoChanges(t *testing.T) {
+	if hunks := Hunks(Lines([]string{"a"}, []string{"a"}), 3); hunks != nil {
+		t.Errorf("expected no hunks, got %v", hunks)
+	}
+}
+
+func TestUnified(t *testing.T) {
+	oldText := "# Summary\nGo developer\n\n# Skills\n- Go\n"
+	newText := "# Summary\nSenior Go developer\n\n# Skills\n- Go\n- Kubernetes\n"
+
+	got := Unified("optimized-cv-1.md", "optimized-cv-2.md", oldText, newText, 1)
+	for _, want := range []string{
+		"--- optimized-cv-1.md\n+++ optimized-cv-2.md\n",
+		"-Go developer\n",
+		"+Senior Go developer\n",
+		"+- Kubernetes\n",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("unified diff missing %q:\n%s", want, got)
+		}
+	}
+
+	if got := Unified("a", "b", oldText, oldText, 3); got != "" {
+		t.Errorf("identical texts should produce empty diff, got %q", got)
+	}
+}
+
+func TestSplitLines(t *testing.T) {
+	if got := SplitLines(""); got != nil {
+		t.Errorf("SplitLines(\"\") = %v, want nil", got)
+	}
+	if got := SplitLines("a\nb\n"); !reflect.DeepEqual(got, []string{"a", "b"}) {
+		t.Errorf("SplitLines() = %v", got)
+	}
+}
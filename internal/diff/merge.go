@@ -0,0 +1,393 @@
+package diff
+
+import (
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// Conflict markers written by Merge, as in git's diff3 conflict style.
+const (
+	MarkerOurs   = "<<<<<<<"
+	MarkerBase   = "|||||||"
+	MarkerSep    = "======="
+	MarkerTheirs = ">>>>>>>"
+)
+
+// frontmatterKey identifies the frontmatter among section titles; it
+// can't collide with a heading.
+const frontmatterKey = "\x00frontmatter"
+
+// bulletPattern matches the first line of a list item.
+var bulletPattern = regexp.MustCompile(`^\s*([-*+]|\d+[.)])\s`)
+
+// MergeLabels name the three inputs in conflict markers.
+type MergeLabels struct {
+	Base   string
+	Ours   string
+	Theirs string
+}
+
+// MergeResult is the outcome of a three-way merge.
+type MergeResult struct {
+	// Text is the merged CV, with conflict markers where both sides
+	// changed the same part.
+	Text string
+	// Conflicts counts the conflict regions in Text.
+	Conflicts int
+}
+
+// Merge combines the changes ours and theirs each made to base. The merge
+// is section-aware: sections are matched by "# " heading, and within a
+// section the units are list items (with their continuation lines) and
+// other lines. A unit changed on one side only takes that side's change,
+// and units added at the same place by both sides are kept from both;
+// conflict markers are written only where both sides changed the same
+// unit differently.
+func Merge(base, ours, theirs string, labels MergeLabels) MergeResult {
+	b, o, t := splitChunks(base), splitChunks(ours), splitChunks(theirs)
+	m := &merger{labels: labels}
+
+	for _, r := range merge3(chunkKeys(b), chunkKeys(o), chunkKeys(t)) {
+		if r.stable {
+			m.mergeSection(b[r.base[0]], o[r.ours[0]], t[r.theirs[0]])
+			continue
+		}
+		m.resolve(
+			joinChunks(b[r.base[0]:r.base[1]]),
+			joinChunks(o[r.ours[0]:r.ours[1]]),
+			joinChunks(t[r.theirs[0]:r.theirs[1]]),
+			func() []string {
+				// Different sections added at the same place by each side
+				if r.base[0] == r.base[1] && !sharesKey(o[r.ours[0]:r.ours[1]], t[r.theirs[0]:r.theirs[1]]) {
+					added := append(slices.Clone(o[r.ours[0]:r.ours[1]]), t[r.theirs[0]:r.theirs[1]]...)
+					return joinChunks(added)
+				}
+				return nil
+			},
+		)
+	}
+
+	text := strings.Join(m.out, "\n")
+	if text != "" {
+		text += "\n"
+	}
+	return MergeResult{Text: text, Conflicts: m.conflicts}
+}
+
+// chunk is a section of a CV: the frontmatter, the text before the first
+// heading, or a "# " section including its heading line.
+type chunk struct {
+	key   string
+	lines []string
+}
+
+// merger accumulates merged output, separating sections by a blank line.
+type merger struct {
+	labels    MergeLabels
+	out       []string
+	conflicts int
+}
+
+// emit appends lines as the next section.
+func (m *merger) emit(lines []string) {
+	if len(lines) == 0 {
+		return
+	}
+	if len(m.out) > 0 {
+		m.out = append(m.out, "")
+	}
+	m.out = append(m.out, lines...)
+}
+
+// resolve merges one region changed on at least one side. A side that
+// left the region as in base yields to the other; equal changes agree.
+// Otherwise fallback may combine the sides, or a conflict is written.
+func (m *merger) resolve(base, ours, theirs []string, fallback func() []string) {
+	switch {
+	case slices.Equal(ours, base):
+		m.emit(theirs)
+	case slices.Equal(theirs, base), slices.Equal(ours, theirs):
+		m.emit(ours)
+	default:
+		if combined := fallback(); combined != nil {
+			m.emit(combined)
+			return
+		}
+		m.emit(m.conflict(base, ours, theirs))
+	}
+}
+
+// conflict formats a conflict region and counts it.
+func (m *merger) conflict(base, ours, theirs []string) []string {
+	m.conflicts++
+	lines := []string{strings.TrimSpace(MarkerOurs + " " + m.labels.Ours)}
+	lines = append(lines, ours...)
+	lines = append(lines, strings.TrimSpace(MarkerBase+" "+m.labels.Base))
+	lines = append(lines, base...)
+	lines = append(lines, MarkerSep)
+	lines = append(lines, theirs...)
+	return append(lines, strings.TrimSpace(MarkerTheirs+" "+m.labels.Theirs))
+}
+
+// mergeSection merges a section present on all three sides, unit by unit.
+func (m *merger) mergeSection(b, o, t chunk) {
+	m.emit(unitLines(m.mergeUnits(splitUnits(b.lines), splitUnits(o.lines), splitUnits(t.lines))))
+}
+
+// unitChange is one side's change to base: the units base[start:end]
+// replaced by units. An insertion has start == end.
+type unitChange struct {
+	start, end int
+	units      []string
+	ours       bool
+}
+
+// mergeUnits merges the units of a section. Each side's changes are found
+// against base on their own, so a change on one side is taken unit by
+// unit, and insertions by both sides at the same place are combined. Only
+// changes to the same base units on both sides can conflict.
+func (m *merger) mergeUnits(base, ours, theirs []string) []string {
+	all := append(unitChanges(base, ours, true), unitChanges(base, theirs, false)...)
+	// Insertions sort before replacements starting at the same unit, and
+	// ours before theirs
+	slices.SortStableFunc(all, func(a, b unitChange) int {
+		if a.start != b.start {
+			return a.start - b.start
+		}
+		return a.end - b.end
+	})
+
+	var out []string
+	pos := 0
+	for i := 0; i < len(all); {
+		// A cluster is a run of changes touching the same base units
+		start, end := all[i].start, all[i].end
+		j := i + 1
+		for ; j < len(all); j++ {
+			c := all[j]
+			sameInsertion := start == end && c.start == start && c.end == start
+			if c.start >= end && !sameInsertion {
+				break
+			}
+			end = max(end, c.end)
+		}
+		out = append(out, base[pos:start]...)
+		out = append(out, m.resolveCluster(base, start, end, all[i:j])...)
+		pos, i = end, j
+	}
+	return append(out, base[pos:]...)
+}
+
+// resolveCluster merges the changes to base[start:end]. A side that left
+// the units as in base yields to the other, equal changes agree and
+// insertions on both sides are combined; otherwise it is a conflict.
+func (m *merger) resolveCluster(base []string, start, end int, cluster []unitChange) []string {
+	apply := func(ours bool) []string {
+		var units []string
+		pos := start
+		for _, c := range cluster {
+			if c.ours == ours {
+				units = append(units, base[pos:c.start]...)
+				units = append(units, c.units...)
+				pos = c.end
+			}
+		}
+		return append(units, base[pos:end]...)
+	}
+	b, o, t := base[start:end], apply(true), apply(false)
+
+	switch {
+	case slices.Equal(o, b):
+		return t
+	case slices.Equal(t, b), slices.Equal(o, t):
+		return o
+	case start == end:
+		return append(o, t...)
+	}
+	return []string{strings.Join(m.conflict(unitLines(b), unitLines(o), unitLines(t)), "\n")}
+}
+
+// unitChanges lists the changes from base to side, in order.
+func unitChanges(base, side []string, ours bool) []unitChange {
+	var changes []unitChange
+	var current *unitChange
+	i := 0
+	for _, e := range Lines(base, side) {
+		if e.Kind == Equal {
+			if current != nil {
+				changes = append(changes, *current)
+				current = nil
+			}
+			i++
+			continue
+		}
+		if current == nil {
+			current = &unitChange{start: i, end: i, ours: ours}
+		}
+		if e.Kind == Delete {
+			i++
+			current.end = i
+		} else {
+			current.units = append(current.units, e.Text)
+		}
+	}
+	if current != nil {
+		changes = append(changes, *current)
+	}
+	return changes
+}
+
+// region is a run of the three inputs found by merge3: stable when all
+// three agree, changed otherwise. Ranges are [start, end) indexes.
+type region struct {
+	stable             bool
+	base, ours, theirs [2]int
+}
+
+// merge3 aligns ours and theirs against base (the diff3 algorithm): a
+// base element matched on both sides in the longest common subsequences
+// is stable, and the runs between stable elements are changed regions.
+func merge3(base, ours, theirs []string) []region {
+	toOurs, toTheirs := matches(base, ours), matches(base, theirs)
+
+	var regions []region
+	i, o, t := 0, 0, 0
+	for i < len(base) || o < len(ours) || t < len(theirs) {
+		if i < len(base) && toOurs[i] == o && toTheirs[i] == t {
+			regions = append(regions, region{stable: true, base: [2]int{i, i + 1}, ours: [2]int{o, o + 1}, theirs: [2]int{t, t + 1}})
+			i, o, t = i+1, o+1, t+1
+			continue
+		}
+
+		// The changed region runs to the next base element kept by both
+		j := i
+		for j < len(base) && (toOurs[j] < 0 || toTheirs[j] < 0) {
+			j++
+		}
+		oEnd, tEnd := len(ours), len(theirs)
+		if j < len(base) {
+			oEnd, tEnd = toOurs[j], toTheirs[j]
+		}
+		regions = append(regions, region{base: [2]int{i, j}, ours: [2]int{o, oEnd}, theirs: [2]int{t, tEnd}})
+		i, o, t = j, oEnd, tEnd
+	}
+	return regions
+}
+
+// matches maps each element of a to its index in b along the longest
+// common subsequence, or -1 if it is not part of it.
+func matches(a, b []string) []int {
+	m := make([]int, len(a))
+	i, j := 0, 0
+	for _, e := range Lines(a, b) {
+		switch e.Kind {
+		case Equal:
+			m[i] = j
+			i++
+			j++
+		case Delete:
+			m[i] = -1
+			i++
+		case Insert:
+			j++
+		}
+	}
+	return m
+}
+
+// splitChunks splits a CV into its frontmatter, the text before the first
+// heading and its "# " sections. Trailing blank lines are dropped from
+// each, so section spacing is normalized rather than merged.
+func splitChunks(text string) []chunk {
+	lines := SplitLines(strings.ReplaceAll(text, "\r\n", "\n"))
+	var chunks []chunk
+
+	if len(lines) > 0 && strings.TrimSpace(lines[0]) == "---" {
+		for i := 1; i < len(lines); i++ {
+			if strings.TrimSpace(lines[i]) == "---" {
+				chunks = append(chunks, chunk{key: frontmatterKey, lines: lines[:i+1]})
+				lines = lines[i+1:]
+				break
+			}
+		}
+	}
+
+	for _, line := range lines {
+		trimmed := strings.TrimSpace(line)
+		switch {
+		case strings.HasPrefix(trimmed, "# "):
+			chunks = append(chunks, chunk{key: strings.TrimSpace(trimmed[2:])})
+		case len(chunks) == 0 || chunks[len(chunks)-1].key == frontmatterKey:
+			if trimmed == "" {
+				continue
+			}
+			chunks = append(chunks, chunk{})
+		}
+		c := &chunks[len(chunks)-1]
+		c.lines = append(c.lines, line)
+	}
+
+	for i := range chunks {
+		chunks[i].lines = trimBlank(chunks[i].lines)
+	}
+	return chunks
+}
+
+// chunkKeys returns the keys of chunks in order.
+func chunkKeys(chunks []chunk) []string {
+	keys := make([]string, len(chunks))
+	for i, c := range chunks {
+		keys[i] = c.key
+	}
+	return keys
+}
+
+// joinChunks returns the lines of chunks separated by blank lines.
+func joinChunks(chunks []chunk) []string {
+	var lines []string
+	for i, c := range chunks {
+		if i > 0 {
+			lines = append(lines, "")
+		}
+		lines = append(lines, c.lines...)
+	}
+	return lines
+}
+
+// sharesKey reports whether a and b have a section title in common.
+func sharesKey(a, b []chunk) bool {
+	for _, c := range a {
+		if slices.ContainsFunc(b, func(d chunk) bool { return d.key == c.key }) {
+			return true
+		}
+	}
+	return false
+}
+
+// splitUnits splits section lines into merge units: a list item with its
+// indented continuation lines, or any other single line.
+func splitUnits(lines []string) []string {
+	var units []string
+	inItem := false
+	for _, line := range lines {
+		continuation := inItem && strings.TrimSpace(line) != "" &&
+			(line[0] == ' ' || line[0] == '\t') && !bulletPattern.MatchString(line)
+		if continuation {
+			units[len(units)-1] += "\n" + line
+			continue
+		}
+		units = append(units, line)
+		inItem = bulletPattern.MatchString(line)
+	}
+	return units
+}
+
+// unitLines expands units back into lines.
+func unitLines(units []string) []string {
+	var lines []string
+	for _, u := range units {
+		lines = append(lines, strings.Split(u, "\n")...)
+	}
+	return lines
+}
@@ -0,0 +1,181 @@
+package diff
+
+import (
+	"strings"
+	"testing"
+)
+
+const mergeBase = `---
+name: Jane
+---
+
+# Summary
+
+Backend developer.
+
+# Experience
+
+## Developer | Acme
+- Built the billing service
+- Cut deploy time by half
+- Mentored two juniors
+
+# Skills
+
+Go, Python
+`
+
+var mergeLabels = MergeLabels{Base: "base", Ours: "ours", Theirs: "theirs"}
+
+func TestMerge(t *testing.T) {
+	tests := []struct {
+		name          string
+		ours, theirs  string
+		want          string
+		wantConflicts int
+	}{
+		{
+			name:   "unchanged",
+			ours:   mergeBase,
+			theirs: mergeBase,
+			want:   mergeBase,
+		},
+		{
+			name:   "only ours changed",
+			ours:   strings.Replace(mergeBase, "Backend developer.", "Backend developer who likes Go.", 1),
+			theirs: mergeBase,
+			want:   strings.Replace(mergeBase, "Backend developer.", "Backend developer who likes Go.", 1),
+		},
+		{
+			name:   "different bullets",
+			ours:   strings.Replace(mergeBase, "- Built the billing service", "- Built the billing service in Go", 1),
+			theirs: strings.Replace(mergeBase, "- Cut deploy time by half", "- Cut deploy time by 50%", 1),
+			want: strings.NewReplacer(
+				"- Built the billing service", "- Built the billing service in Go",
+				"- Cut deploy time by half", "- Cut deploy time by 50%",
+			).Replace(mergeBase),
+		},
+		{
+			name:   "different sections",
+			ours:   strings.Replace(mergeBase, "Go, Python", "Go, Python, SQL", 1),
+			theirs: strings.Replace(mergeBase, "- Mentored two juniors", "- Mentored two junior engineers", 1),
+			want: strings.NewReplacer(
+				"Go, Python", "Go, Python, SQL",
+				"- Mentored two juniors", "- Mentored two junior engineers",
+			).Replace(mergeBase),
+		},
+		{
+			name:   "same change on both sides",
+			ours:   strings.Replace(mergeBase, "Go, Python", "Go", 1),
+			theirs: strings.Replace(mergeBase, "Go, Python", "Go", 1),
+			want:   strings.Replace(mergeBase, "Go, Python", "Go", 1),
+		},
+		{
+			name:   "section added by ours, bullet removed by theirs",
+			ours:   mergeBase + "\n# Hobbies\n\nChess\n",
+			theirs: strings.Replace(mergeBase, "- Mentored two juniors\n", "", 1),
+			want:   strings.Replace(mergeBase, "- Mentored two juniors\n", "", 1) + "\n# Hobbies\n\nChess\n",
+		},
+		{
+			name:   "different sections added at the same place",
+			ours:   mergeBase + "\n# Hobbies\n\nChess\n",
+			theirs: mergeBase + "\n# Languages\n\nDutch\n",
+			want:   mergeBase + "\n# Hobbies\n\nChess\n\n# Languages\n\nDutch\n",
+		},
+		{
+			name:   "same bullet",
+			ours:   strings.Replace(mergeBase, "- Cut deploy time by half", "- Halved deploy time", 1),
+			theirs: strings.Replace(mergeBase, "- Cut deploy time by half", "- Cut deploy time from 20 to 10 minutes", 1),
+			want: strings.Replace(mergeBase, "- Cut deploy time by half\n", `<<<<<<< ours
+- Halved deploy time
+||||||| base
+- Cut deploy time by half
+=======
+- Cut deploy time from 20 to 10 minutes
+>>>>>>> theirs
+`, 1),
+			wantConflicts: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Merge(mergeBase, tt.ours, tt.theirs, mergeLabels)
+			if got.Text != tt.want {
+				t.Errorf("Merge() text =\n%s\nwant\n%s", got.Text, tt.want)
+			}
+			if got.Conflicts != tt.wantConflicts {
+				t.Errorf("Merge() conflicts = %d, want %d", got.Conflicts, tt.wantConflicts)
+			}
+		})
+	}
+}
+
+func TestMerge_ContinuationLines(t *testing.T) {
+	base := "# Experience\n\n- Built the billing\n  service\n- Ran on-call\n"
+	ours := "# Experience\n\n- Built the billing\n  service in Go\n- Ran on-call\n"
+	theirs := "# Experience\n\n- Built the billing\n  service\n- Ran on-call for two teams\n"
+
+	got := Merge(base, ours, theirs, mergeLabels)
+	want := "# Experience\n\n- Built the billing\n  service in Go\n- Ran on-call for two teams\n"
+	if got.Text != want || got.Conflicts != 0 {
+		t.Errorf("Merge() = %d conflicts,\n%s\nwant\n%s", got.Conflicts, got.Text, want)
+	}
+}
+
+func TestMerge_SectionRemovedAndChanged(t *testing.T) {
+	ours := strings.Replace(mergeBase, "# Skills\n\nGo, Python\n", "", 1)
+	theirs := strings.Replace(mergeBase, "Go, Python", "Go, Rust", 1)
+
+	got := Merge(mergeBase, ours, theirs, mergeLabels)
+	if got.Conflicts != 1 {
+		t.Fatalf("Merge() conflicts = %d, want 1:\n%s", got.Conflicts, got.Text)
+	}
+	for _, want := range []string{"<<<<<<< ours\n||||||| base\n# Skills", "=======\n# Skills\n\nGo, Rust\n>>>>>>> theirs"} {
+		if !strings.Contains(got.Text, want) {
+			t.Errorf("Merge() text missing %q:\n%s", want, got.Text)
+		}
+	}
+}
+
+func TestMerge_InsertionNextToEdit(t *testing.T) {
+	base := "# Experience\n\n- A one\n- B two\n- C three\n"
+	ours := "# Experience\n\n- A one edited\n- B two\n- C three\n"
+	theirs := "# Experience\n\n- A one\n- New bullet\n- B two\n- C three\n"
+
+	got := Merge(base, ours, theirs, mergeLabels)
+	want := "# Experience\n\n- A one edited\n- New bullet\n- B two\n- C three\n"
+	if got.Text != want || got.Conflicts != 0 {
+		t.Errorf("Merge() = %d conflicts,\n%s\nwant\n%s", got.Conflicts, got.Text, want)
+	}
+}
+
+func TestMerge_AppendsOnBothSides(t *testing.T) {
+	base := "# Experience\n\n- A one\n- B two\n"
+	ours := base + "- Ours added\n"
+	theirs := base + "- Theirs added\n"
+
+	got := Merge(base, ours, theirs, mergeLabels)
+	want := base + "- Ours added\n- Theirs added\n"
+	if got.Text != want || got.Conflicts != 0 {
+		t.Errorf("Merge() = %d conflicts,\n%s\nwant\n%s", got.Conflicts, got.Text, want)
+	}
+
+	// The same bullet appended by both is kept once
+	got = Merge(base, ours, ours, mergeLabels)
+	if got.Text != ours || got.Conflicts != 0 {
+		t.Errorf("Merge() of equal appends =\n%s", got.Text)
+	}
+}
+
+func TestMerge_EditAndRemoveSameBullet(t *testing.T) {
+	base := "# Experience\n\n- A one\n- B two\n- C three\n"
+	ours := "# Experience\n\n- A one\n- B two edited\n- C three\n"
+	theirs := "# Experience\n\n- A one\n- C three\n- D four\n"
+
+	got := Merge(base, ours, theirs, mergeLabels)
+	want := "# Experience\n\n- A one\n<<<<<<< ours\n- B two edited\n||||||| base\n- B two\n=======\n>>>>>>> theirs\n- C three\n- D four\n"
+	if got.Text != want || got.Conflicts != 1 {
+		t.Errorf("Merge() = %d conflicts,\n%s\nwant\n%s", got.Conflicts, got.Text, want)
+	}
+}
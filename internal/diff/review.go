@@ -0,0 +1,179 @@
+package diff
+
+import (
+	"strings"
+)
+
+// reviewContext is the number of unchanged lines shown around a hunk.
+const reviewContext = 3
+
+// Choice is the reviewer's decision on a hunk.
+type Choice int
+
+const (
+	// Undecided hunks have not been reviewed yet; they keep the old text.
+	Undecided Choice = iota
+	// Accepted takes the new text.
+	Accepted
+	// Rejected keeps the old text.
+	Rejected
+	// Edited uses the reviewer's own text.
+	Edited
+)
+
+// String returns the choice as shown to the reviewer.
+func (c Choice) String() string {
+	switch c {
+	case Accepted:
+		return "accepted"
+	case Rejected:
+		return "rejected"
+	case Edited:
+		return "edited"
+	default:
+		return "undecided"
+	}
+}
+
+// ReviewHunk is one change between two versions, decided on its own.
+type ReviewHunk struct {
+	// Section is the "# " section the change is in, FrontmatterTitle, or
+	// empty before the first section.
+	Section string
+	// OldStart and NewStart are the 1-based line numbers of the change.
+	OldStart, NewStart int
+	// Old and New are the lines the change replaces and adds.
+	Old, New []string
+	// Before and After are unchanged lines around the change, for display.
+	Before, After []string
+	// Choice is the decision; Replacement holds the text when Edited.
+	Choice      Choice
+	Replacement []string
+}
+
+// Lines returns the lines the hunk contributes to the reviewed text.
+func (h *ReviewHunk) Lines() []string {
+	switch h.Choice {
+	case Accepted:
+		return h.New
+	case Edited:
+		return h.Replacement
+	default:
+		return h.Old
+	}
+}
+
+// Review is a hunk-by-hunk review of the changes between two versions.
+type Review struct {
+	// Hunks are the changes in document order.
+	Hunks []ReviewHunk
+	// segments interleave unchanged lines with hunk indexes (-1 for text)
+	segments []segment
+	newline  bool
+}
+
+// segment is a run of unchanged lines, or a hunk when hunk >= 0.
+type segment struct {
+	lines []string
+	hunk  int
+}
+
+// NewReview splits the changes from oldText to newText into hunks: each
+// run of changed lines is a hunk, labelled with the section it is in.
+func NewReview(oldText, newText string) *Review {
+	oldLines, newLines := SplitLines(oldText), SplitLines(newText)
+	oldSections, newSections := lineSections(oldLines), lineSections(newLines)
+	r := &Review{newline: newText == "" || strings.HasSuffix(newText, "\n")}
+
+	edits := Lines(oldLines, newLines)
+	o, n := 0, 0
+	for i := 0; i < len(edits); {
+		if edits[i].Kind == Equal {
+			if len(r.segments) == 0 || r.segments[len(r.segments)-1].hunk >= 0 {
+				r.segments = append(r.segments, segment{hunk: -1})
+			}
+			s := &r.segments[len(r.segments)-1]
+			s.lines = append(s.lines, edits[i].Text)
+			i, o, n = i+1, o+1, n+1
+			continue
+		}
+
+		h := ReviewHunk{OldStart: o + 1, NewStart: n + 1}
+		if edits[i].Kind == Delete {
+			h.Section = oldSections[o]
+		} else {
+			h.Section = newSections[n]
+		}
+		h.Before = newLines[max(n-reviewContext, 0):n]
+		for ; i < len(edits) && edits[i].Kind != Equal; i++ {
+			if edits[i].Kind == Delete {
+				h.Old = append(h.Old, edits[i].Text)
+				o++
+			} else {
+				h.New = append(h.New, edits[i].Text)
+				n++
+			}
+		}
+		h.After = newLines[n:min(n+reviewContext, len(newLines))]
+
+		r.segments = append(r.segments, segment{hunk: len(r.Hunks)})
+		r.Hunks = append(r.Hunks, h)
+	}
+	return r
+}
+
+// Text returns the reviewed version: unchanged lines plus each hunk's
+// lines for its choice.
+func (r *Review) Text() string {
+	var lines []string
+	for _, s := range r.segments {
+		if s.hunk >= 0 {
+			lines = append(lines, r.Hunks[s.hunk].Lines()...)
+		} else {
+			lines = append(lines, s.lines...)
+		}
+	}
+	text := strings.Join(lines, "\n")
+	if r.newline && text != "" {
+		text += "\n"
+	}
+	return text
+}
+
+// Count returns how many hunks have the given choice.
+func (r *Review) Count(c Choice) int {
+	count := 0
+	for _, h := range r.Hunks {
+		if h.Choice == c {
+			count++
+		}
+	}
+	return count
+}
+
+// lineSections returns the section title each line belongs to, as
+// Sections labels them.
+func lineSections(lines []string) []string {
+	titles := make([]string, len(lines))
+	start := 0
+	if len(lines) > 0 && strings.TrimSpace(lines[0]) == "---" {
+		for i := 1; i < len(lines); i++ {
+			if strings.TrimSpace(lines[i]) == "---" {
+				for j := 0; j <= i; j++ {
+					titles[j] = FrontmatterTitle
+				}
+				start = i + 1
+				break
+			}
+		}
+	}
+
+	current := ""
+	for i := start; i < len(lines); i++ {
+		if trimmed := strings.TrimSpace(lines[i]); strings.HasPrefix(trimmed, "# ") {
+			current = strings.TrimSpace(trimmed[2:])
+		}
+		titles[i] = current
+	}
+	return titles
+}
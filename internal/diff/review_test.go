@@ -0,0 +1,95 @@
+package diff
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+const reviewOld = `---
+name: Jane
+---
+
+# Summary
+
+Backend developer.
+
+# Experience
+
+- Built the billing service
+- Cut deploy time by half
+`
+
+const reviewNew = `---
+name: Jane Doe
+---
+
+# Summary
+
+Backend developer.
+
+# Experience
+
+- Built the billing service in Go
+- Cut deploy time by half
+- Led the on-call rotation
+`
+
+func TestNewReview(t *testing.T) {
+	r := NewReview(reviewOld, reviewNew)
+
+	type hunk struct {
+		section  string
+		old, new []string
+	}
+	var got []hunk
+	for _, h := range r.Hunks {
+		got = append(got, hunk{h.Section, h.Old, h.New})
+	}
+	want := []hunk{
+		{FrontmatterTitle, []string{"name: Jane"}, []string{"name: Jane Doe"}},
+		{"Experience", []string{"- Built the billing service"}, []string{"- Built the billing service in Go"}},
+		{"Experience", nil, []string{"- Led the on-call rotation"}},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("hunks = %+v\nwant %+v", got, want)
+	}
+	if h := r.Hunks[1]; h.OldStart != 11 || h.NewStart != 11 || len(h.Before) != 3 || h.After[0] != "- Cut deploy time by half" {
+		t.Errorf("hunk position/context = %+v", h)
+	}
+}
+
+func TestReview_Text(t *testing.T) {
+	r := NewReview(reviewOld, reviewNew)
+	if got := r.Text(); got != reviewOld {
+		t.Errorf("undecided review should keep the old text, got:\n%s", got)
+	}
+
+	for i := range r.Hunks {
+		r.Hunks[i].Choice = Accepted
+	}
+	if got := r.Text(); got != reviewNew {
+		t.Errorf("accepting everything should give the new text, got:\n%s", got)
+	}
+
+	r.Hunks[0].Choice = Rejected
+	r.Hunks[2].Choice = Edited
+	r.Hunks[2].Replacement = []string{"- Led on-call", "- Ran the retro"}
+	want := strings.NewReplacer(
+		"- Built the billing service\n", "- Built the billing service in Go\n",
+		"- Cut deploy time by half\n", "- Cut deploy time by half\n- Led on-call\n- Ran the retro\n",
+	).Replace(reviewOld)
+	if got := r.Text(); got != want {
+		t.Errorf("Text() =\n%s\nwant\n%s", got, want)
+	}
+	if r.Count(Accepted) != 1 || r.Count(Rejected) != 1 || r.Count(Edited) != 1 || r.Count(Undecided) != 0 {
+		t.Errorf("counts = %d/%d/%d", r.Count(Accepted), r.Count(Rejected), r.Count(Edited))
+	}
+}
+
+func TestNewReview_Identical(t *testing.T) {
+	r := NewReview(reviewOld, reviewOld)
+	if len(r.Hunks) != 0 || r.Text() != reviewOld {
+		t.Errorf("identical versions: %d hunks, text %q", len(r.Hunks), r.Text())
+	}
+}
@@ -0,0 +1,221 @@
+package diff
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// Change says how a section or entry differs between two CVs.
+type Change string
+
+// Section and entry changes.
+const (
+	Added    Change = "added"
+	Removed  Change = "removed"
+	Modified Change = "changed"
+)
+
+// FrontmatterTitle names the YAML frontmatter in section summaries.
+const FrontmatterTitle = "Frontmatter"
+
+// EntryChange is a changed "## " entry within a section.
+type EntryChange struct {
+	Title  string `json:"title"`
+	Change Change `json:"change"`
+}
+
+// SectionChange summarizes how one "# " section (or the frontmatter)
+// differs. Entries are listed for modified sections.
+type SectionChange struct {
+	Title   string        `json:"title"`
+	Change  Change        `json:"change"`
+	Entries []EntryChange `json:"entries,omitempty"`
+}
+
+// String formats the change on one line, e.g.
+// `Experience: added "Staff Engineer | Initech", changed "Developer | Acme"`.
+func (c SectionChange) String() string {
+	if len(c.Entries) == 0 {
+		return fmt.Sprintf("%s: %s", c.Title, c.Change)
+	}
+	parts := make([]string, len(c.Entries))
+	for i, e := range c.Entries {
+		parts[i] = fmt.Sprintf("%s %q", e.Change, e.Title)
+	}
+	return fmt.Sprintf("%s: %s", c.Title, strings.Join(parts, ", "))
+}
+
+// block is a titled run of lines: a section or an entry.
+type block struct {
+	title string
+	lines []string
+}
+
+// section is a "# " section with its "## " entries.
+type section struct {
+	block
+	entries []block
+}
+
+// Sections summarizes the differences between two markdown CVs by
+// section: sections and "## " entries added, removed or changed, in the
+// order they appear. Text before the first heading counts as an untitled
+// section, and the frontmatter as FrontmatterTitle.
+func Sections(oldText, newText string) []SectionChange {
+	oldSections := parseSections(oldText)
+	newSections := parseSections(newText)
+
+	var changes []SectionChange
+	for _, title := range mergedTitles(sectionTitles(oldSections), sectionTitles(newSections)) {
+		o, inOld := findSection(oldSections, title)
+		n, inNew := findSection(newSections, title)
+		label := title
+		if label == "" {
+			label = "(before the first section)"
+		}
+		switch {
+		case !inOld:
+			changes = append(changes, SectionChange{Title: label, Change: Added})
+		case !inNew:
+			changes = append(changes, SectionChange{Title: label, Change: Removed})
+		case !slices.Equal(o.lines, n.lines):
+			changes = append(changes, SectionChange{Title: label, Change: Modified, Entries: entryChanges(o, n)})
+		}
+	}
+	return changes
+}
+
+// entryChanges compares the entries of a section. It is empty when only
+// the text before the first entry changed.
+func entryChanges(o, n section) []EntryChange {
+	var changes []EntryChange
+	for _, title := range mergedTitles(blockTitles(o.entries), blockTitles(n.entries)) {
+		oe, inOld := findBlock(o.entries, title)
+		ne, inNew := findBlock(n.entries, title)
+		switch {
+		case !inOld:
+			changes = append(changes, EntryChange{Title: title, Change: Added})
+		case !inNew:
+			changes = append(changes, EntryChange{Title: title, Change: Removed})
+		case !slices.Equal(oe.lines, ne.lines):
+			changes = append(changes, EntryChange{Title: title, Change: Modified})
+		}
+	}
+	return changes
+}
+
+// parseSections splits a CV into its frontmatter and sections. Trailing
+// blank lines are dropped so spacing alone is not a change.
+func parseSections(text string) []section {
+	lines := SplitLines(strings.ReplaceAll(text, "\r\n", "\n"))
+	var sections []section
+
+	if len(lines) > 0 && strings.TrimSpace(lines[0]) == "---" {
+		for i := 1; i < len(lines); i++ {
+			if strings.TrimSpace(lines[i]) == "---" {
+				sections = append(sections, section{block: block{title: FrontmatterTitle, lines: lines[1:i]}})
+				lines = lines[i+1:]
+				break
+			}
+		}
+	}
+
+	current := -1
+	for _, line := range lines {
+		trimmed := strings.TrimSpace(line)
+		switch {
+		case strings.HasPrefix(trimmed, "# "):
+			sections = append(sections, section{block: block{title: strings.TrimSpace(trimmed[2:])}})
+			current = len(sections) - 1
+			continue
+		case current < 0:
+			if trimmed == "" {
+				continue
+			}
+			sections = append(sections, section{})
+			current = len(sections) - 1
+		}
+
+		s := &sections[current]
+		s.lines = append(s.lines, line)
+		if strings.HasPrefix(trimmed, "## ") {
+			s.entries = append(s.entries, block{title: strings.TrimSpace(trimmed[3:])})
+		}
+		if len(s.entries) > 0 {
+			e := &s.entries[len(s.entries)-1]
+			e.lines = append(e.lines, line)
+		}
+	}
+
+	for i := range sections {
+		sections[i].lines = trimBlank(sections[i].lines)
+		for j := range sections[i].entries {
+			sections[i].entries[j].lines = trimBlank(sections[i].entries[j].lines)
+		}
+	}
+	return sections
+}
+
+// trimBlank drops trailing blank lines.
+func trimBlank(lines []string) []string {
+	for len(lines) > 0 && strings.TrimSpace(lines[len(lines)-1]) == "" {
+		lines = lines[:len(lines)-1]
+	}
+	return lines
+}
+
+// mergedTitles returns the old titles in order followed by the new ones
+// that are not among them.
+func mergedTitles(old, new []string) []string {
+	titles := append([]string{}, old...)
+	seen := make(map[string]bool, len(old))
+	for _, t := range old {
+		seen[t] = true
+	}
+	for _, t := range new {
+		if !seen[t] {
+			titles = append(titles, t)
+			seen[t] = true
+		}
+	}
+	return titles
+}
+
+// sectionTitles returns the titles of sections in order.
+func sectionTitles(sections []section) []string {
+	titles := make([]string, len(sections))
+	for i, s := range sections {
+		titles[i] = s.title
+	}
+	return titles
+}
+
+// blockTitles returns the titles of blocks in order.
+func blockTitles(blocks []block) []string {
+	titles := make([]string, len(blocks))
+	for i, b := range blocks {
+		titles[i] = b.title
+	}
+	return titles
+}
+
+// findSection returns the first section with the given title.
+func findSection(sections []section, title string) (section, bool) {
+	for _, s := range sections {
+		if s.title == title {
+			return s, true
+		}
+	}
+	return section{}, false
+}
+
+// findBlock returns the first block with the given title.
+func findBlock(blocks []block, title string) (block, bool) {
+	for _, b := range blocks {
+		if b.title == title {
+			return b, true
+		}
+	}
+	return block{}, false
+}
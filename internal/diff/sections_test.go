@@ -0,0 +1,88 @@
+package diff
+
+import (
+	"reflect"
+	"testing"
+)
+
+const oldCV = `---
+name: Jane
+email: jane@example.com
+---
+
+# Experience
+
+## Senior Developer | Acme Corp
+*2020-01 - present*
+- Built things
+
+## Developer | Globex
+*2018-01 - 2019-12*
+
+# Skills
+
+Go, Python
+
+# Hobbies
+
+Chess
+`
+
+func TestSections(t *testing.T) {
+	newCV := `---
+name: Jane
+email: jane@example.org
+---
+
+# Experience
+
+## Staff Engineer | Initech
+*2024-01 - present*
+
+## Senior Developer | Acme Corp
+*2020-01 - 2023-12*
+- Built things
+
+## Developer | Globex
+*2018-01 - 2019-12*
+
+# Skills
+
+Go, Python
+
+# Projects
+
+## m2cv | github.com/richq/m2cv
+`
+
+	got := Sections(oldCV, newCV)
+	want := []SectionChange{
+		{Title: FrontmatterTitle, Change: Modified},
+		{Title: "Experience", Change: Modified, Entries: []EntryChange{
+			{Title: "Senior Developer | Acme Corp", Change: Modified},
+			{Title: "Staff Engineer | Initech", Change: Added},
+		}},
+		{Title: "Hobbies", Change: Removed},
+		{Title: "Projects", Change: Added},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Sections() =\n%+v\nwant\n%+v", got, want)
+	}
+
+	if s := got[1].String(); s != `Experience: changed "Senior Developer | Acme Corp", added "Staff Engineer | Initech"` {
+		t.Errorf("String() = %s", s)
+	}
+	if s := got[2].String(); s != "Hobbies: removed" {
+		t.Errorf("String() = %s", s)
+	}
+}
+
+func TestSections_Unchanged(t *testing.T) {
+	// Trailing blank lines are not a change
+	if got := Sections(oldCV, oldCV+"\n\n"); len(got) != 0 {
+		t.Errorf("Sections() = %+v", got)
+	}
+	if got := Sections("Intro text\n# Skills\nGo\n", "Intro text, revised\n# Skills\nGo\n"); len(got) != 1 || got[0].Title != "(before the first section)" {
+		t.Errorf("Sections() = %+v", got)
+	}
+}
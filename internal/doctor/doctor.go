@@ -0,0 +1,370 @@
+// Package doctor checks the environment and project m2cv depends on and
+// reports every problem at once, each with a suggested fix, instead of
+// letting them surface one command at a time.
+package doctor
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/richq/m2cv/internal/config"
+	"github.com/richq/m2cv/internal/cvlint"
+	"github.com/richq/m2cv/internal/executor"
+	"github.com/richq/m2cv/internal/preflight"
+	themepkg "github.com/richq/m2cv/internal/theme"
+)
+
+// Status is the outcome of a check.
+type Status string
+
+const (
+	// Pass means nothing needs doing.
+	Pass Status = "pass"
+	// Warn means m2cv works but something is off.
+	Warn Status = "warn"
+	// Fail means some commands will not work.
+	Fail Status = "fail"
+)
+
+// MinNodeMajor is the oldest Node.js major version resumed supports.
+const MinNodeMajor = 18
+
+// Check is one line of the report.
+type Check struct {
+	// Name identifies what was checked (e.g. "node", "config").
+	Name string `json:"name"`
+	// Status is pass, warn or fail.
+	Status Status `json:"status"`
+	// Detail describes what was found.
+	Detail string `json:"detail"`
+	// Fix suggests how to resolve a warn or fail.
+	Fix string `json:"fix,omitempty"`
+}
+
+// Report is the result of Run.
+type Report struct {
+	Checks []Check `json:"checks"`
+}
+
+// Failed reports whether any check failed.
+func (r *Report) Failed() bool {
+	for _, c := range r.Checks {
+		if c.Status == Fail {
+			return true
+		}
+	}
+	return false
+}
+
+// add appends a check.
+func (r *Report) add(name string, status Status, detail, fix string) {
+	r.Checks = append(r.Checks, Check{Name: name, Status: status, Detail: detail, Fix: fix})
+}
+
+// ExportFunc exports jsonPath to outputPath with resumed; see generator.Exporter.
+type ExportFunc func(ctx context.Context, jsonPath, outputPath, theme, projectDir string) error
+
+// Options configures Run. Zero values use the real environment.
+type Options struct {
+	// ConfigPath is m2cv.yml, or empty if it was not found.
+	ConfigPath string
+	// BaseCVPath overrides the config's base_cv_path (the --base-cv flag).
+	BaseCVPath string
+	// Export runs a test export. Nil skips the export check.
+	Export ExportFunc
+	// LookPath finds claude. Defaults to exec.LookPath.
+	LookPath func(name string) (string, error)
+	// FindNode finds node, npm and npx. Defaults to executor.FindNodeExecutable.
+	FindNode func(name string) (string, error)
+	// Version returns the output of "<path> --version". Defaults to running it.
+	Version func(ctx context.Context, path string) (string, error)
+}
+
+// Run performs every check and returns the report. Checks that depend on
+// an earlier failure (e.g. themes without a config) are skipped.
+func Run(ctx context.Context, opts Options) *Report {
+	if opts.LookPath == nil {
+		opts.LookPath = exec.LookPath
+	}
+	if opts.FindNode == nil {
+		opts.FindNode = executor.FindNodeExecutable
+	}
+	if opts.Version == nil {
+		opts.Version = commandVersion
+	}
+
+	r := &Report{}
+	checkTools(ctx, r, opts)
+
+	cfg := checkConfig(r, opts.ConfigPath)
+	if cfg == nil {
+		return r
+	}
+	projectDir := filepath.Dir(opts.ConfigPath)
+	checkBaseCV(r, cfg, opts)
+	checkThemes(r, cfg, projectDir)
+	checkResumed(ctx, r, cfg, projectDir, opts.Export)
+	checkWritable(r, projectDir)
+	return r
+}
+
+// checkTools reports the versions of claude, node, npm and npx.
+func checkTools(ctx context.Context, r *Report, opts Options) {
+	if path, err := opts.LookPath("claude"); err != nil {
+		r.add("claude", Fail, "claude CLI not found in PATH", "Install from https://claude.ai/download, then check with 'claude --version'")
+	} else {
+		r.add("claude", Pass, versionDetail(ctx, opts, path), "")
+	}
+
+	for _, name := range []string{"node", "npm", "npx"} {
+		path, err := opts.FindNode(name)
+		if err != nil {
+			r.add(name, Fail, name+" not found in PATH or version manager locations", fmt.Sprintf("Install Node.js %d or newer from https://nodejs.org", MinNodeMajor))
+			continue
+		}
+		detail := versionDetail(ctx, opts, path)
+		if name == "node" {
+			if major, ok := nodeMajor(detail); ok && major < MinNodeMajor {
+				r.add(name, Warn, detail, fmt.Sprintf("Upgrade to Node.js %d or newer; resumed and its PDF renderer need it", MinNodeMajor))
+				continue
+			}
+		}
+		r.add(name, Pass, detail, "")
+	}
+}
+
+// versionDetail formats "<version> (<path>)", tolerating tools that
+// cannot report a version.
+func versionDetail(ctx context.Context, opts Options, path string) string {
+	v, err := opts.Version(ctx, path)
+	if err != nil || v == "" {
+		return fmt.Sprintf("version unknown (%s)", path)
+	}
+	return fmt.Sprintf("%s (%s)", v, path)
+}
+
+// nodeVersionPattern captures the major version of "v20.11.1".
+var nodeVersionPattern = regexp.MustCompile(`^v?(\d+)\.`)
+
+// nodeMajor parses the major version from a node version detail.
+func nodeMajor(detail string) (int, bool) {
+	m := nodeVersionPattern.FindStringSubmatch(detail)
+	if m == nil {
+		return 0, false
+	}
+	major, err := strconv.Atoi(m[1])
+	return major, err == nil
+}
+
+// commandVersion runs "<path> --version" with a timeout and returns the
+// first line of its output.
+func commandVersion(ctx context.Context, path string) (string, error) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+	out, err := exec.CommandContext(ctx, path, "--version").Output()
+	if err != nil {
+		return "", err
+	}
+	line, _, _ := strings.Cut(strings.TrimSpace(string(out)), "\n")
+	return line, nil
+}
+
+// checkConfig loads and validates m2cv.yml, returning nil if it is unusable.
+func checkConfig(r *Report, configPath string) *config.Config {
+	if configPath == "" {
+		r.add("config", Fail, "m2cv.yml not found in this directory or its parents", "Run 'm2cv init', or pass --config / set M2CV_CONFIG")
+		return nil
+	}
+	layered, err := config.LoadLayered(configPath)
+	if err != nil {
+		r.add("config", Fail, fmt.Sprintf("%s: %v", configPath, err), "Fix the YAML syntax in "+configPath)
+		return nil
+	}
+
+	cfg := layered.Config
+	var problems []string
+	for _, p := range layered.Problems {
+		if p.File == configPath {
+			p.File = ""
+		}
+		problems = append(problems, p.String())
+	}
+	for _, p := range cfg.Validate(filepath.Dir(configPath), nil) {
+		if p.Key == "default_model" || strings.HasPrefix(p.Key, "models.") {
+			problems = append(problems, p.Message)
+		}
+	}
+	if cfg.BaseCVPath == "" {
+		problems = append(problems, "base_cv_path is not set")
+	}
+	if cfg.DefaultTheme == "" {
+		problems = append(problems, "default_theme is not set (falls back to even)")
+	}
+	for _, ref := range append([]string{cfg.DefaultTheme}, cfg.Themes...) {
+		if ref == "" {
+			continue
+		}
+		if _, err := themepkg.Normalize(ref); err != nil {
+			problems = append(problems, err.Error())
+		}
+	}
+	if style, err := themepkg.LoadStyle(filepath.Dir(configPath), cfg.ThemeOptions, ""); err == nil {
+		if _, err := style.CSS(); err != nil {
+			problems = append(problems, err.Error())
+		}
+	}
+	if len(problems) > 0 {
+		r.add("config", Warn, configPath+": "+strings.Join(problems, "; "), "Edit "+configPath+" with 'm2cv config set' / 'm2cv config unset', or use 'm2cv theme' to manage themes")
+		return cfg
+	}
+	r.add("config", Pass, configPath, "")
+	return cfg
+}
+
+// checkBaseCV checks that the base CV exists and lints it.
+func checkBaseCV(r *Report, cfg *config.Config, opts Options) {
+	path := opts.BaseCVPath
+	if path == "" {
+		path = cfg.BaseCVPath
+	}
+	if path == "" {
+		r.add("base cv", Fail, "no base CV configured", "Set base_cv_path in m2cv.yml or pass --base-cv")
+		return
+	}
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(filepath.Dir(opts.ConfigPath), path)
+	}
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		r.add("base cv", Fail, fmt.Sprintf("cannot read %s: %v", path, err), "Create "+path+" (see 'Markdown CV Format' in the README) or fix base_cv_path")
+		return
+	}
+
+	issues := cvlint.Lint(content)
+	if len(issues) == 0 {
+		r.add("base cv", Pass, path, "")
+		return
+	}
+	status := Warn
+	if cvlint.HasErrors(issues) {
+		status = Fail
+	}
+	details := make([]string, len(issues))
+	for i, issue := range issues {
+		details[i] = issue.String()
+	}
+	r.add("base cv", status, fmt.Sprintf("%s: %d lint issue(s): %s", path, len(issues), strings.Join(details, "; ")),
+		"Edit "+path+" to follow the 'Markdown CV Format' conventions in the README")
+}
+
+// checkThemes compares configured and installed themes.
+func checkThemes(r *Report, cfg *config.Config, projectDir string) {
+	infos, err := themepkg.NewManager(projectDir, nil).List(cfg)
+	if err != nil {
+		r.add("themes", Fail, err.Error(), "Check that node_modules is readable")
+		return
+	}
+
+	var installed, missing, unconfigured []string
+	for _, info := range infos {
+		switch {
+		case info.Configured && info.Installed:
+			installed = append(installed, info.Ref)
+		case info.Configured:
+			missing = append(missing, info.Ref)
+		default:
+			unconfigured = append(unconfigured, info.Ref)
+		}
+	}
+
+	switch {
+	case len(missing) > 0:
+		r.add("themes", Fail, "configured but not installed: "+strings.Join(missing, ", "), "Run: m2cv theme add "+strings.Join(missing, " "))
+	case len(installed) == 0:
+		r.add("themes", Fail, "no themes configured", "Run: m2cv theme add even")
+	case len(unconfigured) > 0:
+		r.add("themes", Warn, fmt.Sprintf("installed: %s; not in m2cv.yml: %s", strings.Join(installed, ", "), strings.Join(unconfigured, ", ")),
+			"Run 'm2cv theme add <theme>' to use them, or 'm2cv theme remove <theme>' to uninstall")
+	default:
+		r.add("themes", Pass, "installed: "+strings.Join(installed, ", "), "")
+	}
+}
+
+// sampleResume is the minimal JSON Resume used for the test export.
+const sampleResume = `{"basics": {"name": "m2cv doctor", "label": "Test export"}, "work": [{"name": "Acme", "position": "Engineer", "startDate": "2020-01"}]}`
+
+// checkResumed checks that resumed is installed and, if export is set,
+// that it can export a PDF with the default theme.
+func checkResumed(ctx context.Context, r *Report, cfg *config.Config, projectDir string, export ExportFunc) {
+	if err := preflight.CheckResumed(projectDir); err != nil {
+		r.add("resumed", Fail, "resumed not found in node_modules or PATH", "Run 'npm install resumed' in "+projectDir)
+		return
+	}
+	if export == nil {
+		r.add("resumed", Pass, "installed (export not tested)", "")
+		return
+	}
+
+	theme := cfg.DefaultTheme
+	if theme == "" {
+		theme = "even"
+	}
+	dir, err := os.MkdirTemp("", "m2cv-doctor-*")
+	if err != nil {
+		r.add("resumed", Warn, fmt.Sprintf("could not create a temp dir for the test export: %v", err), "Check TMPDIR")
+		return
+	}
+	defer os.RemoveAll(dir)
+
+	jsonPath := filepath.Join(dir, "resume.json")
+	pdfPath := filepath.Join(dir, "resume.pdf")
+	if err := os.WriteFile(jsonPath, []byte(sampleResume), 0644); err != nil {
+		r.add("resumed", Warn, fmt.Sprintf("could not write the test resume: %v", err), "Check TMPDIR")
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 2*time.Minute)
+	defer cancel()
+	start := time.Now()
+	if err := export(ctx, jsonPath, pdfPath, theme, projectDir); err != nil {
+		r.add("resumed", Fail, fmt.Sprintf("test export with %s failed: %v", theme, err),
+			"Run 'npm install' in "+projectDir+"; if the browser fails to start, see the puppeteer troubleshooting guide")
+		return
+	}
+	if info, err := os.Stat(pdfPath); err != nil || info.Size() == 0 {
+		r.add("resumed", Fail, fmt.Sprintf("test export with %s produced no PDF", theme), "Try 'm2cv generate' on an application to see resumed's output")
+		return
+	}
+	r.add("resumed", Pass, fmt.Sprintf("exported a test PDF with %s in %s", theme, time.Since(start).Round(100*time.Millisecond)), "")
+}
+
+// checkWritable checks that new applications can be created.
+func checkWritable(r *Report, projectDir string) {
+	appsDir := filepath.Join(projectDir, "applications")
+	dir := appsDir
+	if _, err := os.Stat(appsDir); os.IsNotExist(err) {
+		dir = projectDir
+	}
+
+	f, err := os.CreateTemp(dir, ".m2cv-doctor-*")
+	if err != nil {
+		r.add("applications", Fail, fmt.Sprintf("%s is not writable: %v", dir, err), "Fix the permissions of "+dir)
+		return
+	}
+	f.Close()
+	os.Remove(f.Name())
+
+	if dir != appsDir {
+		r.add("applications", Pass, appsDir+" does not exist yet; 'm2cv apply' will create it", "")
+		return
+	}
+	r.add("applications", Pass, appsDir+" is writable", "")
+}
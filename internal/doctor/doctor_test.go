@@ -0,0 +1,182 @@
+package doctor
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// fakeOptions finds every tool under /fake and reports canned versions.
+func fakeOptions(configPath string) Options {
+	return Options{
+		ConfigPath: configPath,
+		LookPath:   func(name string) (string, error) { return "/fake/" + name, nil },
+		FindNode:   func(name string) (string, error) { return "/fake/" + name, nil },
+		Version: func(ctx context.Context, path string) (string, error) {
+			if strings.HasSuffix(path, "node") {
+				return "v20.11.1", nil
+			}
+			return "1.0.0", nil
+		},
+	}
+}
+
+// newProject writes m2cv.yml, a base CV and installed packages.
+func newProject(t *testing.T, configContent, cv string, packages ...string) string {
+	t.Helper()
+	dir := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", filepath.Join(dir, ".config"))
+	files := map[string]string{"m2cv.yml": configContent + "version: 2\n", "base-cv.md": cv}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for _, pkg := range packages {
+		if err := os.MkdirAll(filepath.Join(dir, "node_modules", pkg), 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return filepath.Join(dir, "m2cv.yml")
+}
+
+// statuses maps check names to their status.
+func statuses(r *Report) map[string]Status {
+	m := make(map[string]Status)
+	for _, c := range r.Checks {
+		m[c.Name] = c.Status
+	}
+	return m
+}
+
+const goodCV = "---\nname: Jane\nemail: jane@example.com\n---\n# Experience\n## Dev | Acme\n"
+
+func TestRun_Healthy(t *testing.T) {
+	configPath := newProject(t, "base_cv_path: base-cv.md\ndefault_theme: even\nthemes: [even]\n", goodCV, "resumed", "jsonresume-theme-even")
+	opts := fakeOptions(configPath)
+	var exportedTheme string
+	opts.Export = func(ctx context.Context, jsonPath, outputPath, theme, projectDir string) error {
+		exportedTheme = theme
+		return os.WriteFile(outputPath, []byte("%PDF"), 0644)
+	}
+
+	r := Run(context.Background(), opts)
+	if r.Failed() {
+		t.Errorf("report failed: %+v", r.Checks)
+	}
+	for _, c := range r.Checks {
+		if c.Status != Pass {
+			t.Errorf("%s = %s (%s), want pass", c.Name, c.Status, c.Detail)
+		}
+	}
+	if len(r.Checks) != 9 {
+		t.Errorf("got %d checks, want 9", len(r.Checks))
+	}
+	if exportedTheme != "even" {
+		t.Errorf("test export used theme %q", exportedTheme)
+	}
+	if r.Checks[1].Detail != "v20.11.1 (/fake/node)" {
+		t.Errorf("node detail = %q", r.Checks[1].Detail)
+	}
+}
+
+func TestRun_Problems(t *testing.T) {
+	configPath := newProject(t, "base_cv_path: base-cv.md\ndefault_theme: even\nthemes: [even, flat]\n", "# Hobbies\n", "jsonresume-theme-even", "jsonresume-theme-kendall")
+	// No global resumed either
+	t.Setenv("PATH", "")
+
+	opts := fakeOptions(configPath)
+	opts.LookPath = func(name string) (string, error) { return "", errors.New("not found") }
+	opts.FindNode = func(name string) (string, error) {
+		if name == "npx" {
+			return "", errors.New("not found")
+		}
+		return "/fake/" + name, nil
+	}
+	opts.Version = func(ctx context.Context, path string) (string, error) { return "v16.20.0", nil }
+
+	r := Run(context.Background(), opts)
+	want := map[string]Status{
+		"claude":       Fail,
+		"node":         Warn,
+		"npm":          Pass,
+		"npx":          Fail,
+		"config":       Pass,
+		"base cv":      Warn,
+		"themes":       Fail,
+		"resumed":      Fail,
+		"applications": Pass,
+	}
+	got := statuses(r)
+	for name, status := range want {
+		if got[name] != status {
+			t.Errorf("%s = %s, want %s", name, got[name], status)
+		}
+	}
+	for _, c := range r.Checks {
+		if c.Status != Pass && c.Fix == "" {
+			t.Errorf("%s has no suggested fix", c.Name)
+		}
+		if c.Name == "themes" && !strings.Contains(c.Fix, "m2cv theme add flat") {
+			t.Errorf("themes fix = %q", c.Fix)
+		}
+	}
+}
+
+func TestRun_NoConfig(t *testing.T) {
+	r := Run(context.Background(), fakeOptions(""))
+	got := statuses(r)
+	if got["config"] != Fail || !r.Failed() {
+		t.Errorf("missing config should fail: %+v", r.Checks)
+	}
+	if _, ok := got["themes"]; ok {
+		t.Error("project checks should be skipped without a config")
+	}
+}
+
+func TestRun_InvalidConfig(t *testing.T) {
+	configPath := newProject(t, "base_cv_path: [oops", goodCV)
+	r := Run(context.Background(), fakeOptions(configPath))
+	if got := statuses(r); got["config"] != Fail {
+		t.Errorf("config = %s, want fail", got["config"])
+	}
+}
+
+func TestRun_UnknownConfigKey(t *testing.T) {
+	configPath := newProject(t, "base_cv_path: base-cv.md\ndefault_them: even\ndefault_model: gpt-4\n", goodCV)
+	r := Run(context.Background(), fakeOptions(configPath))
+	for _, c := range r.Checks {
+		if c.Name != "config" {
+			continue
+		}
+		if c.Status != Warn || !strings.Contains(c.Detail, `did you mean "default_theme"`) || !strings.Contains(c.Detail, "gpt-4") {
+			t.Errorf("config check = %+v", c)
+		}
+	}
+}
+
+func TestRun_ExportFailure(t *testing.T) {
+	configPath := newProject(t, "base_cv_path: missing.md\ndefault_theme: even\nthemes: [even]\n", goodCV, "resumed", "jsonresume-theme-even")
+	opts := fakeOptions(configPath)
+	opts.Export = func(ctx context.Context, jsonPath, outputPath, theme, projectDir string) error {
+		return errors.New("browser crashed")
+	}
+
+	r := Run(context.Background(), opts)
+	got := statuses(r)
+	if got["resumed"] != Fail || got["base cv"] != Fail {
+		t.Errorf("statuses = %v", got)
+	}
+}
+
+func TestNodeMajor(t *testing.T) {
+	if major, ok := nodeMajor("v20.11.1 (/usr/bin/node)"); !ok || major != 20 {
+		t.Errorf("nodeMajor() = %d, %v", major, ok)
+	}
+	if _, ok := nodeMajor("version unknown (/usr/bin/node)"); ok {
+		t.Error("nodeMajor() should fail on unknown versions")
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"os"
 	"os/exec"
+	"strconv"
 	"strings"
 )
 
@@ -30,6 +31,13 @@ type InteractiveConfig struct {
 	SystemPrompt string
 	// Model is the Claude model to use (may be empty for default)
 	Model string
+	// SessionID names the conversation so its transcript can be found later.
+	// With Resume set, the existing session is continued instead.
+	SessionID string
+	// Resume continues SessionID rather than starting a new conversation
+	Resume bool
+	// ExtraArgs are extra claude CLI arguments, added before the prompt
+	ExtraArgs []string
 }
 
 // claudeExecutor is the default implementation of ClaudeExecutor.
@@ -43,7 +51,12 @@ type ExecuteOption func(*executeConfig)
 // executeConfig holds configuration for a single Execute call.
 type executeConfig struct {
 	model        string
+	maxTurns     int
+	extraArgs    []string
 	outputFormat string
+	progress     ProgressReporter
+	partialDir   string
+	result       *Result
 }
 
 // NewClaudeExecutor creates a new ClaudeExecutor.
@@ -75,6 +88,34 @@ func WithModel(model string) ExecuteOption {
 	}
 }
 
+// WithMaxTurns limits the number of agentic turns (claude --max-turns).
+// Zero leaves the CLI default.
+func WithMaxTurns(n int) ExecuteOption {
+	return func(c *executeConfig) {
+		c.maxTurns = n
+	}
+}
+
+// WithExtraArgs appends extra claude CLI arguments after m2cv's own, so
+// settings m2cv doesn't model (e.g. --fallback-model) can be passed through.
+func WithExtraArgs(args ...string) ExecuteOption {
+	return func(c *executeConfig) {
+		c.extraArgs = append(c.extraArgs, args...)
+	}
+}
+
+// runArgs returns the arguments selecting the model and its parameters.
+func (c *executeConfig) runArgs() []string {
+	var args []string
+	if c.model != "" {
+		args = append(args, "--model", c.model)
+	}
+	if c.maxTurns > 0 {
+		args = append(args, "--max-turns", strconv.Itoa(c.maxTurns))
+	}
+	return append(args, c.extraArgs...)
+}
+
 // WithOutputFormat sets the output format (text, json, etc.).
 func WithOutputFormat(format string) ExecuteOption {
 	return func(c *executeConfig) {
@@ -90,7 +131,10 @@ func WithOutputFormat(format string) ExecuteOption {
 //   - -p flag (print mode)
 //   - --output-format text (plain text output)
 //
-// Use WithModel and WithOutputFormat to customize behavior.
+// Use WithModel, WithMaxTurns, WithExtraArgs and WithOutputFormat to
+// customize behavior.
+// Use WithProgress to stream events as they arrive instead, and WithResult
+// to capture the result envelope (token usage, cost, session id).
 func (e *claudeExecutor) Execute(ctx context.Context, prompt string, opts ...ExecuteOption) (string, error) {
 	// Apply options
 	cfg := &executeConfig{
@@ -100,11 +144,18 @@ func (e *claudeExecutor) Execute(ctx context.Context, prompt string, opts ...Exe
 		opt(cfg)
 	}
 
+	if cfg.progress != nil {
+		return e.executeStreaming(ctx, prompt, cfg)
+	}
+
+	// The result envelope is only available in JSON output
+	if cfg.result != nil {
+		cfg.outputFormat = "json"
+	}
+
 	// Build command arguments
 	args := []string{"-p", "--output-format", cfg.outputFormat}
-	if cfg.model != "" {
-		args = append(args, "--model", cfg.model)
-	}
+	args = append(args, cfg.runArgs()...)
 
 	// Create command with context for cancellation support
 	cmd := exec.CommandContext(ctx, e.claudePath, args...)
@@ -133,6 +184,16 @@ func (e *claudeExecutor) Execute(ctx context.Context, prompt string, opts ...Exe
 		return "", fmt.Errorf("claude execution failed: %w", err)
 	}
 
+	if cfg.result != nil {
+		res, err := parseResultEnvelope(stdout.Bytes())
+		if err != nil {
+			return "", err
+		}
+		res.Model = cfg.model
+		*cfg.result = *res
+		return res.Text, nil
+	}
+
 	return stdout.String(), nil
 }
 
@@ -140,9 +201,17 @@ func (e *claudeExecutor) Execute(ctx context.Context, prompt string, opts ...Exe
 // The terminal is passed through to allow user interaction.
 func (e *claudeExecutor) ExecuteInteractive(ctx context.Context, cfg InteractiveConfig) error {
 	args := []string{"--mcp-config", cfg.MCPConfigPath}
+	if cfg.SessionID != "" {
+		if cfg.Resume {
+			args = append(args, "--resume", cfg.SessionID)
+		} else {
+			args = append(args, "--session-id", cfg.SessionID)
+		}
+	}
 	if cfg.Model != "" {
 		args = append(args, "--model", cfg.Model)
 	}
+	args = append(args, cfg.ExtraArgs...)
 	args = append(args, cfg.SystemPrompt)
 
 	cmd := exec.CommandContext(ctx, e.claudePath, args...)
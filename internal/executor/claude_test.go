@@ -99,6 +99,32 @@ cat > /dev/null
 	}
 }
 
+// TestClaudeExecutor_WithProfile verifies --max-turns and extra args follow the model
+func TestClaudeExecutor_WithProfile(t *testing.T) {
+	fakeClaude := writeFakeClaude(t, `#!/bin/sh
+echo "args: $@"
+cat > /dev/null
+`)
+	executor := NewClaudeExecutor(WithClaudePath(fakeClaude))
+
+	result, err := executor.Execute(context.Background(), "prompt",
+		WithModel("haiku"), WithMaxTurns(3), WithExtraArgs("--fallback-model", "sonnet"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(result, "--model haiku --max-turns 3 --fallback-model sonnet") {
+		t.Errorf("args = %q", result)
+	}
+
+	result, err = executor.Execute(context.Background(), "prompt", WithMaxTurns(0))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(result, "--max-turns") {
+		t.Errorf("zero max turns should be omitted: %q", result)
+	}
+}
+
 // TestClaudeExecutor_WithOutputFormat verifies --output-format flag
 func TestClaudeExecutor_WithOutputFormat(t *testing.T) {
 	tmpDir := t.TempDir()
@@ -325,3 +351,40 @@ func TestClaudeExecutor_ImplementationPattern(t *testing.T) {
 
 // Ensure exec package is used correctly (compile check)
 var _ = exec.Command
+
+// TestClaudeExecutor_InteractiveSessionFlags verifies new sessions are named
+// with --session-id and resumed ones use --resume.
+func TestClaudeExecutor_InteractiveSessionFlags(t *testing.T) {
+	argsFile := filepath.Join(t.TempDir(), "args")
+	fakeClaude := writeFakeClaude(t, `#!/bin/sh
+echo "$@" > "`+argsFile+`"
+`)
+	executor := NewClaudeExecutor(WithClaudePath(fakeClaude))
+
+	tests := []struct {
+		name    string
+		cfg     InteractiveConfig
+		want    string
+		wantNot string
+	}{
+		{"no session", InteractiveConfig{MCPConfigPath: "mcp.json", SystemPrompt: "hi"}, "--mcp-config mcp.json hi", "session"},
+		{"new session", InteractiveConfig{MCPConfigPath: "mcp.json", SessionID: "abc", SystemPrompt: "hi"}, "--session-id abc", "--resume"},
+		{"resume", InteractiveConfig{MCPConfigPath: "mcp.json", SessionID: "abc", Resume: true, SystemPrompt: "hi"}, "--resume abc", "--session-id"},
+		{"extra args", InteractiveConfig{MCPConfigPath: "mcp.json", Model: "opus", ExtraArgs: []string{"--effort", "high"}, SystemPrompt: "hi"}, "--model opus --effort high hi", "session"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := executor.ExecuteInteractive(context.Background(), tt.cfg); err != nil {
+				t.Fatalf("ExecuteInteractive() error = %v", err)
+			}
+			data, err := os.ReadFile(argsFile)
+			if err != nil {
+				t.Fatal(err)
+			}
+			args := string(data)
+			if !strings.Contains(args, tt.want) || strings.Contains(args, tt.wantNot) {
+				t.Errorf("args = %q, want %q and not %q", args, tt.want, tt.wantNot)
+			}
+		})
+	}
+}
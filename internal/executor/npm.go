@@ -17,6 +17,13 @@ type NPMExecutor interface {
 	// Install installs npm packages in the specified directory.
 	Install(ctx context.Context, dir string, packages ...string) error
 
+	// Uninstall removes npm packages from the specified directory.
+	Uninstall(ctx context.Context, dir string, packages ...string) error
+
+	// Update updates npm packages in the specified directory to the newest
+	// versions allowed by package.json.
+	Update(ctx context.Context, dir string, packages ...string) error
+
 	// CheckInstalled checks if a package is installed in node_modules.
 	CheckInstalled(ctx context.Context, dir string, pkg string) (bool, error)
 
@@ -77,6 +84,20 @@ func (e *npmExecutor) Install(ctx context.Context, dir string, packages ...strin
 	return e.runNPM(ctx, dir, args...)
 }
 
+// Uninstall removes npm packages.
+// Runs: npm uninstall <packages...>
+func (e *npmExecutor) Uninstall(ctx context.Context, dir string, packages ...string) error {
+	args := append([]string{"uninstall"}, packages...)
+	return e.runNPM(ctx, dir, args...)
+}
+
+// Update updates npm packages.
+// Runs: npm update <packages...>
+func (e *npmExecutor) Update(ctx context.Context, dir string, packages ...string) error {
+	args := append([]string{"update"}, packages...)
+	return e.runNPM(ctx, dir, args...)
+}
+
 // CheckInstalled checks if a package exists in node_modules.
 // This is a filesystem check, not an npm command.
 func (e *npmExecutor) CheckInstalled(ctx context.Context, dir string, pkg string) (bool, error) {
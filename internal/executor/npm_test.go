@@ -309,3 +309,40 @@ func TestNPMExecutor_FindsNPMInPath(t *testing.T) {
 		t.Error("expected executor to be created")
 	}
 }
+
+// TestNPMExecutor_UninstallAndUpdate runs npm uninstall and npm update with packages
+func TestNPMExecutor_UninstallAndUpdate(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	fakeNpm := filepath.Join(tmpDir, "npm")
+	argsFile := filepath.Join(tmpDir, "npm_args.txt")
+	script := `#!/bin/sh
+echo "$@" >> ` + argsFile + `
+exit 0
+`
+	if err := os.WriteFile(fakeNpm, []byte(script), 0755); err != nil {
+		t.Fatalf("failed to create fake npm: %v", err)
+	}
+
+	executor, err := NewNPMExecutor(WithNPMPath(fakeNpm))
+	if err != nil {
+		t.Fatalf("failed to create executor: %v", err)
+	}
+
+	ctx := context.Background()
+	if err := executor.Uninstall(ctx, tmpDir, "jsonresume-theme-flat"); err != nil {
+		t.Errorf("Uninstall() error = %v", err)
+	}
+	if err := executor.Update(ctx, tmpDir, "jsonresume-theme-even", "@acme/jsonresume-theme-brand"); err != nil {
+		t.Errorf("Update() error = %v", err)
+	}
+
+	args, err := os.ReadFile(argsFile)
+	if err != nil {
+		t.Fatalf("failed to read args file: %v", err)
+	}
+	want := "uninstall jsonresume-theme-flat\nupdate jsonresume-theme-even @acme/jsonresume-theme-brand\n"
+	if string(args) != want {
+		t.Errorf("npm args = %q, want %q", args, want)
+	}
+}
@@ -0,0 +1,72 @@
+package executor
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// Result is the envelope claude reports at the end of a print-mode run,
+// carrying the result text alongside token usage, cost and timing.
+type Result struct {
+	// Text is the final result text.
+	Text string
+	// SessionID identifies the claude session that produced the result.
+	SessionID string
+	// Model is the model that served the request, when reported.
+	Model string
+	// InputTokens is the number of uncached input tokens.
+	InputTokens int
+	// OutputTokens is the number of generated tokens.
+	OutputTokens int
+	// CacheCreationInputTokens is the number of input tokens written to cache.
+	CacheCreationInputTokens int
+	// CacheReadInputTokens is the number of input tokens read from cache.
+	CacheReadInputTokens int
+	// CostUSD is the total cost of the run in US dollars.
+	CostUSD float64
+	// DurationMS is the wall-clock duration of the run in milliseconds.
+	DurationMS int64
+	// NumTurns is the number of agent turns taken.
+	NumTurns int
+}
+
+// WithResult requests the result envelope and stores it in r on success.
+// Without streaming this switches claude to --output-format json.
+func WithResult(r *Result) ExecuteOption {
+	return func(c *executeConfig) {
+		c.result = r
+	}
+}
+
+// fill copies the envelope fields into r, leaving Text and Model untouched.
+func (raw *rawStreamEvent) fill(r *Result) {
+	r.SessionID = raw.SessionID
+	r.CostUSD = raw.TotalCostUSD
+	r.DurationMS = raw.DurationMS
+	r.NumTurns = raw.NumTurns
+	if raw.Usage != nil {
+		r.InputTokens = raw.Usage.InputTokens
+		r.OutputTokens = raw.Usage.OutputTokens
+		r.CacheCreationInputTokens = raw.Usage.CacheCreationInputTokens
+		r.CacheReadInputTokens = raw.Usage.CacheReadInputTokens
+	}
+}
+
+// parseResultEnvelope decodes the JSON printed by --output-format json.
+// Returns an error if the output is not an envelope or reports a failure.
+func parseResultEnvelope(output []byte) (*Result, error) {
+	var raw rawStreamEvent
+	if err := json.Unmarshal(output, &raw); err != nil {
+		return nil, fmt.Errorf("failed to parse claude JSON output: %w", err)
+	}
+	if raw.Type != "result" {
+		return nil, fmt.Errorf("unexpected claude JSON output type %q", raw.Type)
+	}
+	if raw.IsError {
+		return nil, fmt.Errorf("claude reported an error: %s", raw.Result)
+	}
+
+	r := &Result{Text: raw.Result}
+	raw.fill(r)
+	return r, nil
+}
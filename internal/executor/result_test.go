@@ -0,0 +1,91 @@
+package executor
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestClaudeExecutor_WithResultUsesJSON(t *testing.T) {
+	fakeClaude := writeFakeClaude(t, `#!/bin/sh
+cat > /dev/null
+case "$*" in
+  *"--output-format json"*) ;;
+  *) echo "expected json output format, got: $*" >&2; exit 1 ;;
+esac
+echo '{"type":"result","subtype":"success","is_error":false,"duration_ms":1500,"num_turns":1,"result":"# Tailored CV","session_id":"sess-1","total_cost_usd":0.0123,"usage":{"input_tokens":100,"cache_creation_input_tokens":20,"cache_read_input_tokens":300,"output_tokens":50}}'
+`)
+
+	exec := NewClaudeExecutor(WithClaudePath(fakeClaude))
+	var res Result
+	text, err := exec.Execute(context.Background(), "prompt", WithModel("sonnet"), WithResult(&res))
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+
+	if text != "# Tailored CV" {
+		t.Errorf("text = %q, want %q", text, "# Tailored CV")
+	}
+	want := Result{
+		Text:                     "# Tailored CV",
+		SessionID:                "sess-1",
+		Model:                    "sonnet",
+		InputTokens:              100,
+		OutputTokens:             50,
+		CacheCreationInputTokens: 20,
+		CacheReadInputTokens:     300,
+		CostUSD:                  0.0123,
+		DurationMS:               1500,
+		NumTurns:                 1,
+	}
+	if res != want {
+		t.Errorf("result = %+v, want %+v", res, want)
+	}
+}
+
+func TestClaudeExecutor_WithResultStreaming(t *testing.T) {
+	fakeClaude := writeFakeClaude(t, `#!/bin/sh
+cat > /dev/null
+echo '{"type":"system","subtype":"init","session_id":"sess-2","model":"claude-sonnet-4"}'
+echo '{"type":"stream_event","event":{"type":"content_block_delta","delta":{"type":"text_delta","text":"done"}}}'
+echo '{"type":"result","subtype":"success","result":"done","session_id":"sess-2","total_cost_usd":0.5,"duration_ms":42,"usage":{"input_tokens":7,"output_tokens":3}}'
+`)
+
+	exec := NewClaudeExecutor(WithClaudePath(fakeClaude))
+	var res Result
+	_, err := exec.Execute(context.Background(), "prompt", WithProgress(&recordingProgress{}), WithPartialOutputDir(t.TempDir()), WithResult(&res))
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+
+	if res.Model != "claude-sonnet-4" || res.SessionID != "sess-2" {
+		t.Errorf("model/session = %q/%q, want claude-sonnet-4/sess-2", res.Model, res.SessionID)
+	}
+	if res.InputTokens != 7 || res.OutputTokens != 3 || res.CostUSD != 0.5 || res.DurationMS != 42 {
+		t.Errorf("unexpected usage in result: %+v", res)
+	}
+}
+
+func TestParseResultEnvelope_Errors(t *testing.T) {
+	tests := []struct {
+		name        string
+		output      string
+		errContains string
+	}{
+		{"not json", "plain text", "failed to parse"},
+		{"wrong type", `{"type":"assistant"}`, "unexpected claude JSON output type"},
+		{"error result", `{"type":"result","is_error":true,"result":"rate limited"}`, "rate limited"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := parseResultEnvelope([]byte(tt.output))
+			if err == nil {
+				t.Fatal("expected error")
+			}
+			if !strings.Contains(err.Error(), tt.errContains) {
+				t.Errorf("error = %v, want to contain %q", err, tt.errContains)
+			}
+		})
+	}
+}
@@ -0,0 +1,282 @@
+package executor
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"strings"
+)
+
+// StreamEvent is a single decoded event from claude's stream-json output.
+// Only the fields m2cv cares about are populated; unknown event types are
+// passed through with just Type and Subtype set.
+type StreamEvent struct {
+	// Type is the event type (system, assistant, stream_event, result).
+	Type string
+	// Subtype further qualifies the event (e.g., "init", "success").
+	Subtype string
+	// SessionID is the claude session the event belongs to.
+	SessionID string
+	// Text is any assistant text delivered by this event.
+	Text string
+	// OutputTokens is the output token count reported so far, if known.
+	OutputTokens int
+	// Result is the final result text (result events only).
+	Result string
+	// IsError reports whether a result event signals failure.
+	IsError bool
+	// Model is the model reported by the init event.
+	Model string
+
+	// envelope holds the full decoded result envelope (result events only).
+	envelope *rawStreamEvent
+}
+
+// ProgressReporter receives events while a streaming Execute runs.
+// Event is called once per decoded event; Done is called exactly once
+// when the run finishes, with the error Execute is about to return.
+type ProgressReporter interface {
+	Event(ev StreamEvent)
+	Done(err error)
+}
+
+// WithProgress enables streaming mode and reports events to p.
+// In streaming mode claude is run with --output-format stream-json and
+// partial output is written to a temp file so a cancelled run isn't lost.
+func WithProgress(p ProgressReporter) ExecuteOption {
+	return func(c *executeConfig) {
+		c.progress = p
+	}
+}
+
+// WithPartialOutputDir sets the directory used for the partial output file
+// in streaming mode. Defaults to os.TempDir(); the generator pipelines use
+// the application folder so the file is found where the version would be.
+func WithPartialOutputDir(dir string) ExecuteOption {
+	return func(c *executeConfig) {
+		c.partialDir = dir
+	}
+}
+
+// rawStreamEvent mirrors the JSON shape of a stream-json line.
+type rawStreamEvent struct {
+	Type         string  `json:"type"`
+	Subtype      string  `json:"subtype"`
+	SessionID    string  `json:"session_id"`
+	Model        string  `json:"model"`
+	Result       string  `json:"result"`
+	IsError      bool    `json:"is_error"`
+	TotalCostUSD float64 `json:"total_cost_usd"`
+	DurationMS   int64   `json:"duration_ms"`
+	NumTurns     int     `json:"num_turns"`
+	Message      *struct {
+		Content []struct {
+			Type string `json:"type"`
+			Text string `json:"text"`
+		} `json:"content"`
+		Usage *rawUsage `json:"usage"`
+	} `json:"message"`
+	Event *struct {
+		Type  string `json:"type"`
+		Delta *struct {
+			Type string `json:"type"`
+			Text string `json:"text"`
+		} `json:"delta"`
+		Usage *rawUsage `json:"usage"`
+	} `json:"event"`
+	Usage *rawUsage `json:"usage"`
+}
+
+// rawUsage mirrors the token usage object reported by claude.
+type rawUsage struct {
+	InputTokens              int `json:"input_tokens"`
+	OutputTokens             int `json:"output_tokens"`
+	CacheCreationInputTokens int `json:"cache_creation_input_tokens"`
+	CacheReadInputTokens     int `json:"cache_read_input_tokens"`
+}
+
+// parseStreamEvent decodes a single stream-json line.
+// Returns the decoded event and whether the text it carries is an
+// incremental delta (as opposed to a complete assistant message).
+func parseStreamEvent(line []byte) (StreamEvent, bool, error) {
+	var raw rawStreamEvent
+	if err := json.Unmarshal(line, &raw); err != nil {
+		return StreamEvent{}, false, err
+	}
+
+	ev := StreamEvent{
+		Type:      raw.Type,
+		Subtype:   raw.Subtype,
+		SessionID: raw.SessionID,
+		Result:    raw.Result,
+		IsError:   raw.IsError,
+		Model:     raw.Model,
+	}
+
+	delta := false
+	switch raw.Type {
+	case "assistant":
+		if raw.Message != nil {
+			var text strings.Builder
+			for _, block := range raw.Message.Content {
+				if block.Type == "text" {
+					text.WriteString(block.Text)
+				}
+			}
+			ev.Text = text.String()
+			if raw.Message.Usage != nil {
+				ev.OutputTokens = raw.Message.Usage.OutputTokens
+			}
+		}
+	case "stream_event":
+		if raw.Event != nil {
+			if raw.Event.Delta != nil && raw.Event.Delta.Type == "text_delta" {
+				ev.Text = raw.Event.Delta.Text
+				delta = true
+			}
+			if raw.Event.Usage != nil {
+				ev.OutputTokens = raw.Event.Usage.OutputTokens
+			}
+		}
+	case "result":
+		if raw.Usage != nil {
+			ev.OutputTokens = raw.Usage.OutputTokens
+		}
+		ev.envelope = &raw
+	}
+
+	return ev, delta, nil
+}
+
+// executeStreaming runs claude with --output-format stream-json, decoding
+// events as they arrive. Assistant text is appended to a partial output file
+// which is removed on success and kept (and reported) on failure.
+func (e *claudeExecutor) executeStreaming(ctx context.Context, prompt string, cfg *executeConfig) (result string, err error) {
+	defer func() {
+		cfg.progress.Done(err)
+	}()
+
+	args := []string{"-p", "--output-format", "stream-json", "--verbose", "--include-partial-messages"}
+	args = append(args, cfg.runArgs()...)
+
+	cmd := exec.CommandContext(ctx, e.claudePath, args...)
+	cmd.Stdin = strings.NewReader(prompt)
+
+	// stdout is read incrementally; stderr is still buffered for error reporting
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return "", fmt.Errorf("failed to open claude stdout: %w", err)
+	}
+
+	partial, err := os.CreateTemp(cfg.partialDir, "m2cv-partial-*.md")
+	if err != nil {
+		return "", fmt.Errorf("failed to create partial output file: %w", err)
+	}
+	partialPath := partial.Name()
+
+	if err := cmd.Start(); err != nil {
+		partial.Close()
+		os.Remove(partialPath)
+		return "", fmt.Errorf("failed to start claude: %w (not found or not executable)", err)
+	}
+
+	text, final, model, readErr := readStream(stdout, partial, cfg.progress)
+	waitErr := cmd.Wait()
+	partial.Close()
+
+	switch {
+	case waitErr != nil:
+		err = fmt.Errorf("claude execution failed: %w", waitErr)
+		if stderrContent := strings.TrimSpace(stderr.String()); stderrContent != "" {
+			err = fmt.Errorf("claude execution failed: %w\nstderr: %s", waitErr, stderrContent)
+		}
+	case readErr != nil:
+		err = fmt.Errorf("failed to read claude output: %w", readErr)
+	case final != nil && final.IsError:
+		err = fmt.Errorf("claude reported an error: %s", final.Result)
+	}
+
+	if err != nil {
+		if text == "" {
+			os.Remove(partialPath)
+			return "", err
+		}
+		return "", fmt.Errorf("%w\npartial output saved to: %s", err, partialPath)
+	}
+
+	os.Remove(partialPath)
+	if final != nil && final.Result != "" {
+		text = final.Result
+	}
+	if cfg.result != nil {
+		if model == "" {
+			model = cfg.model
+		}
+		*cfg.result = Result{Text: text, Model: model}
+		if final != nil {
+			cfg.result.SessionID = final.SessionID
+			if final.envelope != nil {
+				final.envelope.fill(cfg.result)
+			}
+		}
+	}
+	return text, nil
+}
+
+// readStream decodes stream-json lines from r until EOF. Assistant text is
+// accumulated, mirrored to partial, and every event is passed to progress.
+// Returns the accumulated text, the final result event (if one was seen)
+// and the model reported by the init event.
+func readStream(r io.Reader, partial io.Writer, progress ProgressReporter) (string, *StreamEvent, string, error) {
+	var (
+		text       strings.Builder
+		final      *StreamEvent
+		model      string
+		sawDeltas  bool
+		reader     = bufio.NewReader(r)
+		readErr    error
+		lineBuffer []byte
+	)
+
+	for {
+		lineBuffer, readErr = reader.ReadBytes('\n')
+		if line := bytes.TrimSpace(lineBuffer); len(line) > 0 {
+			ev, delta, err := parseStreamEvent(line)
+			if err == nil {
+				// With partial messages enabled the complete assistant message
+				// repeats text already delivered as deltas, so skip it.
+				if delta {
+					sawDeltas = true
+				}
+				if ev.Text != "" && (delta || !sawDeltas) {
+					text.WriteString(ev.Text)
+					_, _ = io.WriteString(partial, ev.Text)
+				}
+				if ev.Type == "system" && ev.Model != "" {
+					model = ev.Model
+				}
+				if ev.Type == "result" {
+					e := ev
+					final = &e
+				}
+				progress.Event(ev)
+			}
+		}
+		if readErr != nil {
+			break
+		}
+	}
+
+	if errors.Is(readErr, io.EOF) {
+		readErr = nil
+	}
+	return text.String(), final, model, readErr
+}
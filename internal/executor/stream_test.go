@@ -0,0 +1,205 @@
+package executor
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// recordingProgress captures events for assertions.
+type recordingProgress struct {
+	mu     sync.Mutex
+	events []StreamEvent
+	done   int
+	err    error
+}
+
+func (r *recordingProgress) Event(ev StreamEvent) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.events = append(r.events, ev)
+}
+
+func (r *recordingProgress) Done(err error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.done++
+	r.err = err
+}
+
+// writeFakeClaude writes an executable shell script and returns its path.
+func writeFakeClaude(t *testing.T, script string) string {
+	t.Helper()
+	fakeClaude := filepath.Join(t.TempDir(), "claude")
+	if err := os.WriteFile(fakeClaude, []byte(script), 0755); err != nil {
+		t.Fatalf("failed to create fake claude: %v", err)
+	}
+	return fakeClaude
+}
+
+func TestParseStreamEvent(t *testing.T) {
+	tests := []struct {
+		name      string
+		line      string
+		wantType  string
+		wantText  string
+		wantDelta bool
+		wantToks  int
+	}{
+		{
+			name:     "system init",
+			line:     `{"type":"system","subtype":"init","session_id":"abc"}`,
+			wantType: "system",
+		},
+		{
+			name:      "text delta",
+			line:      `{"type":"stream_event","event":{"type":"content_block_delta","delta":{"type":"text_delta","text":"Hel"}}}`,
+			wantType:  "stream_event",
+			wantText:  "Hel",
+			wantDelta: true,
+		},
+		{
+			name:     "assistant message",
+			line:     `{"type":"assistant","message":{"content":[{"type":"text","text":"Hello"},{"type":"tool_use"}],"usage":{"output_tokens":7}}}`,
+			wantType: "assistant",
+			wantText: "Hello",
+			wantToks: 7,
+		},
+		{
+			name:     "result",
+			line:     `{"type":"result","subtype":"success","result":"Hello","usage":{"output_tokens":9}}`,
+			wantType: "result",
+			wantToks: 9,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ev, delta, err := parseStreamEvent([]byte(tt.line))
+			if err != nil {
+				t.Fatalf("parseStreamEvent() error = %v", err)
+			}
+			if ev.Type != tt.wantType {
+				t.Errorf("Type = %q, want %q", ev.Type, tt.wantType)
+			}
+			if ev.Text != tt.wantText {
+				t.Errorf("Text = %q, want %q", ev.Text, tt.wantText)
+			}
+			if delta != tt.wantDelta {
+				t.Errorf("delta = %v, want %v", delta, tt.wantDelta)
+			}
+			if ev.OutputTokens != tt.wantToks {
+				t.Errorf("OutputTokens = %d, want %d", ev.OutputTokens, tt.wantToks)
+			}
+		})
+	}
+}
+
+func TestClaudeExecutor_StreamingUsesResult(t *testing.T) {
+	fakeClaude := writeFakeClaude(t, `#!/bin/sh
+cat > /dev/null
+echo '{"type":"system","subtype":"init","session_id":"s1"}'
+echo '{"type":"stream_event","event":{"type":"content_block_delta","delta":{"type":"text_delta","text":"# CV"}}}'
+echo '{"type":"stream_event","event":{"type":"content_block_delta","delta":{"type":"text_delta","text":" body"}}}'
+echo '{"type":"assistant","message":{"content":[{"type":"text","text":"# CV body"}]}}'
+echo '{"type":"result","subtype":"success","result":"# CV body","session_id":"s1"}'
+`)
+
+	progress := &recordingProgress{}
+	partialDir := t.TempDir()
+	exec := NewClaudeExecutor(WithClaudePath(fakeClaude))
+
+	result, err := exec.Execute(context.Background(), "prompt", WithProgress(progress), WithPartialOutputDir(partialDir))
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	if result != "# CV body" {
+		t.Errorf("result = %q, want %q", result, "# CV body")
+	}
+	if len(progress.events) != 5 {
+		t.Errorf("got %d events, want 5", len(progress.events))
+	}
+	if progress.done != 1 || progress.err != nil {
+		t.Errorf("Done called %d times with err %v, want once with nil", progress.done, progress.err)
+	}
+
+	// Partial output file is removed on success
+	entries, _ := os.ReadDir(partialDir)
+	if len(entries) != 0 {
+		t.Errorf("partial output dir should be empty on success, has %d entries", len(entries))
+	}
+}
+
+func TestClaudeExecutor_StreamingArgs(t *testing.T) {
+	fakeClaude := writeFakeClaude(t, `#!/bin/sh
+cat > /dev/null
+echo "{\"type\":\"result\",\"result\":\"$*\"}"
+`)
+
+	exec := NewClaudeExecutor(WithClaudePath(fakeClaude))
+	result, err := exec.Execute(context.Background(), "prompt", WithProgress(&recordingProgress{}), WithModel("sonnet"), WithPartialOutputDir(t.TempDir()))
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+
+	for _, want := range []string{"-p", "--output-format stream-json", "--verbose", "--model sonnet"} {
+		if !strings.Contains(result, want) {
+			t.Errorf("args %q missing %q", result, want)
+		}
+	}
+}
+
+func TestClaudeExecutor_StreamingKeepsPartialOnFailure(t *testing.T) {
+	fakeClaude := writeFakeClaude(t, `#!/bin/sh
+cat > /dev/null
+echo '{"type":"stream_event","event":{"type":"content_block_delta","delta":{"type":"text_delta","text":"half a CV"}}}'
+echo "interrupted" >&2
+exit 1
+`)
+
+	progress := &recordingProgress{}
+	partialDir := t.TempDir()
+	exec := NewClaudeExecutor(WithClaudePath(fakeClaude))
+
+	_, err := exec.Execute(context.Background(), "prompt", WithProgress(progress), WithPartialOutputDir(partialDir))
+	if err == nil {
+		t.Fatal("expected error when process fails")
+	}
+	if !strings.Contains(err.Error(), "interrupted") {
+		t.Errorf("error should contain stderr, got: %v", err)
+	}
+	if !strings.Contains(err.Error(), "partial output saved to") {
+		t.Errorf("error should mention partial output, got: %v", err)
+	}
+	if progress.err == nil {
+		t.Error("Done should receive the error")
+	}
+
+	matches, _ := filepath.Glob(filepath.Join(partialDir, "m2cv-partial-*.md"))
+	if len(matches) != 1 {
+		t.Fatalf("expected 1 partial file, got %d", len(matches))
+	}
+	data, _ := os.ReadFile(matches[0])
+	if string(data) != "half a CV" {
+		t.Errorf("partial content = %q, want %q", string(data), "half a CV")
+	}
+}
+
+func TestClaudeExecutor_StreamingResultError(t *testing.T) {
+	fakeClaude := writeFakeClaude(t, `#!/bin/sh
+cat > /dev/null
+echo '{"type":"result","subtype":"error_max_turns","is_error":true,"result":"max turns reached"}'
+`)
+
+	exec := NewClaudeExecutor(WithClaudePath(fakeClaude))
+	_, err := exec.Execute(context.Background(), "prompt", WithProgress(&recordingProgress{}), WithPartialOutputDir(t.TempDir()))
+	if err == nil {
+		t.Fatal("expected error for is_error result")
+	}
+	if !strings.Contains(err.Error(), "max turns reached") {
+		t.Errorf("error should contain result text, got: %v", err)
+	}
+}
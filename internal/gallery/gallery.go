@@ -0,0 +1,300 @@
+// Package gallery renders one resume in many themes side by side so a
+// theme can be picked by looking at real output rather than descriptions.
+package gallery
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"html/template"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	themepkg "github.com/richq/m2cv/internal/theme"
+)
+
+// DefaultWorkers bounds concurrent exports; each one starts a headless browser.
+const DefaultWorkers = 4
+
+// IndexFile is the gallery's index page.
+const IndexFile = "index.html"
+
+// Exporter exports a JSON Resume file to PDF in a theme.
+// *generator.Exporter satisfies it.
+type Exporter interface {
+	ExportPDF(ctx context.Context, jsonPath, outputPath, theme, projectDir string) error
+}
+
+// Options describes a gallery build.
+type Options struct {
+	// JSONPath is the resume.json to render.
+	JSONPath string
+	// OutDir receives one PDF per theme and index.html.
+	OutDir string
+	// ProjectDir contains node_modules with resumed and the themes.
+	ProjectDir string
+	// Themes are the theme references to render.
+	Themes []string
+	// Style is applied on top of every theme.
+	Style themepkg.Style
+	// Workers bounds concurrent exports. Defaults to DefaultWorkers.
+	Workers int
+	// Exporter renders PDFs.
+	Exporter Exporter
+	// OnDone, if set, is called as each theme finishes (one call at a time).
+	OnDone func(Entry)
+}
+
+// Entry is the outcome for one theme.
+type Entry struct {
+	// Theme is the theme reference.
+	Theme string
+	// File is the PDF file name inside OutDir.
+	File string
+	// Size is the PDF size in bytes.
+	Size int64
+	// Pages is the PDF page count, or 0 if it could not be determined.
+	Pages int
+	// Duration is how long the export took.
+	Duration time.Duration
+	// Err is set if the export failed.
+	Err error
+}
+
+// Build exports JSONPath in every theme using a bounded worker pool and
+// writes index.html. Entries are returned in theme order; per-theme
+// failures are recorded in Entry.Err rather than returned.
+func Build(ctx context.Context, opts Options) ([]Entry, error) {
+	if err := os.MkdirAll(opts.OutDir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create gallery folder: %w", err)
+	}
+	absJSON, err := filepath.Abs(opts.JSONPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve %s: %w", opts.JSONPath, err)
+	}
+	absOut, err := filepath.Abs(opts.OutDir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve %s: %w", opts.OutDir, err)
+	}
+
+	workers := opts.Workers
+	if workers <= 0 {
+		workers = DefaultWorkers
+	}
+
+	files := FileNames(opts.ProjectDir, opts.Themes)
+	entries := make([]Entry, len(opts.Themes))
+	jobs := make(chan int)
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				entries[i] = export(ctx, opts, absJSON, absOut, opts.Themes[i], files[i])
+				if opts.OnDone != nil {
+					mu.Lock()
+					opts.OnDone(entries[i])
+					mu.Unlock()
+				}
+			}
+		}()
+	}
+
+feed:
+	for i := range opts.Themes {
+		select {
+		case jobs <- i:
+		case <-ctx.Done():
+			break feed
+		}
+	}
+	close(jobs)
+	wg.Wait()
+
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	if err := writeIndex(filepath.Join(opts.OutDir, IndexFile), filepath.Base(opts.JSONPath), entries); err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
+
+// export renders one theme to file and measures the result.
+func export(ctx context.Context, opts Options, jsonPath, outDir, theme, file string) Entry {
+	entry := Entry{Theme: theme, File: file}
+	path := filepath.Join(outDir, entry.File)
+	start := time.Now()
+
+	styled, cleanup, err := opts.Style.Wrap(opts.ProjectDir, theme)
+	if err == nil {
+		err = opts.Exporter.ExportPDF(ctx, jsonPath, path, styled, opts.ProjectDir)
+		cleanup()
+	}
+	entry.Duration = time.Since(start)
+	if err != nil {
+		// Don't leave a stale PDF from an earlier run next to an error
+		os.Remove(path)
+		entry.Err = err
+		return entry
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		entry.Err = fmt.Errorf("failed to read %s: %w", path, err)
+		return entry
+	}
+	entry.Size = int64(len(data))
+	entry.Pages = CountPages(data)
+	return entry
+}
+
+// FileName returns the PDF file name for a theme reference, flattening
+// scopes and schemes: "@acme/jsonresume-theme-brand" -> "acme-jsonresume-theme-brand.pdf".
+// Local themes are named after their directory: "file:themes/brand" -> "file-brand.pdf".
+// Names can collide; FileNames makes them distinct.
+func FileName(theme string) string {
+	if themepkg.IsLocal(theme) {
+		return "file-" + filepath.Base(themepkg.LocalPath("", theme)) + ".pdf"
+	}
+	name := strings.NewReplacer("@", "", "/", "-", ":", "-").Replace(theme)
+	return name + ".pdf"
+}
+
+// FileNames returns a distinct PDF file name for each theme, in order.
+// A theme whose FileName is already taken, such as "file:../shared/brand"
+// after "file:themes/brand", gets a short hash of its resolved path (or of
+// the reference for npm themes): "file-brand-1a2b3c4d.pdf".
+func FileNames(projectDir string, themes []string) []string {
+	names := make([]string, len(themes))
+	used := make(map[string]bool)
+	for i, theme := range themes {
+		name := FileName(theme)
+		if used[name] {
+			key := theme
+			if themepkg.IsLocal(theme) {
+				key = themepkg.LocalPath(projectDir, theme)
+			}
+			sum := sha256.Sum256([]byte(key))
+			base := strings.TrimSuffix(name, ".pdf") + "-" + hex.EncodeToString(sum[:4])
+			name = base + ".pdf"
+			// The same directory listed twice hashes the same
+			for n := 2; used[name]; n++ {
+				name = fmt.Sprintf("%s-%d.pdf", base, n)
+			}
+		}
+		used[name] = true
+		names[i] = name
+	}
+	return names
+}
+
+// pagePattern matches page objects but not the /Pages tree node.
+var pagePattern = regexp.MustCompile(`/Type\s*/Page\b`)
+
+// CountPages counts the page objects in a PDF. This is a heuristic that
+// works for the uncompressed object tables resumed's browser writes; it
+// returns 0 if no pages are found.
+func CountPages(data []byte) int {
+	return len(pagePattern.FindAll(data, -1))
+}
+
+// indexEntry is an Entry formatted for the index page.
+type indexEntry struct {
+	Entry
+	SizeText string
+	Error    string
+}
+
+// writeIndex writes the gallery index page.
+func writeIndex(path, source string, entries []Entry) error {
+	data := struct {
+		Source    string
+		Generated string
+		Entries   []indexEntry
+	}{
+		Source:    source,
+		Generated: time.Now().Format("2006-01-02 15:04"),
+	}
+	for _, e := range entries {
+		ie := indexEntry{Entry: e, SizeText: formatSize(e.Size)}
+		if e.Err != nil {
+			ie.Error = e.Err.Error()
+		}
+		data.Entries = append(data.Entries, ie)
+	}
+
+	var b bytes.Buffer
+	if err := indexTemplate.Execute(&b, data); err != nil {
+		return fmt.Errorf("failed to render gallery index: %w", err)
+	}
+	if err := os.WriteFile(path, b.Bytes(), 0644); err != nil {
+		return fmt.Errorf("failed to write gallery index: %w", err)
+	}
+	return nil
+}
+
+// formatSize renders a byte count for people.
+func formatSize(n int64) string {
+	switch {
+	case n >= 1<<20:
+		return fmt.Sprintf("%.1f MB", float64(n)/(1<<20))
+	case n >= 1<<10:
+		return fmt.Sprintf("%.1f KB", float64(n)/(1<<10))
+	}
+	return fmt.Sprintf("%d B", n)
+}
+
+var indexTemplate = template.Must(template.New("index").Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>m2cv theme gallery</title>
+<style>
+  body { font: 14px/1.5 sans-serif; margin: 2em; color: #222; }
+  table { border-collapse: collapse; margin-bottom: 2em; }
+  th, td { padding: 4px 12px; border-bottom: 1px solid #ddd; text-align: left; }
+  td.num { text-align: right; }
+  .error { color: #b00020; white-space: pre-wrap; font-family: monospace; }
+  .grid { display: grid; grid-template-columns: repeat(auto-fill, minmax(420px, 1fr)); gap: 16px; }
+  .card h2 { font-size: 15px; margin: 0 0 4px; }
+  .card object { width: 100%; height: 560px; border: 1px solid #ccc; }
+</style>
+</head>
+<body>
+<h1>Theme gallery</h1>
+<p>{{.Source}} rendered in {{len .Entries}} theme(s) on {{.Generated}}.</p>
+<table>
+  <tr><th>Theme</th><th>PDF</th><th>Size</th><th>Pages</th></tr>
+{{- range .Entries}}
+  <tr>
+    <td>{{.Theme}}</td>
+    {{- if .Error}}
+    <td colspan="3" class="error">{{.Error}}</td>
+    {{- else}}
+    <td><a href="{{.File}}">{{.File}}</a></td>
+    <td class="num">{{.SizeText}}</td>
+    <td class="num">{{if .Pages}}{{.Pages}}{{else}}?{{end}}</td>
+    {{- end}}
+  </tr>
+{{- end}}
+</table>
+<div class="grid">
+{{- range .Entries}}{{if not .Error}}
+  <div class="card">
+    <h2><a href="{{.File}}">{{.Theme}}</a></h2>
+    <object data="{{.File}}" type="application/pdf"><a href="{{.File}}">{{.File}}</a></object>
+  </div>
+{{- end}}{{end}}
+</div>
+</body>
+</html>
+`))
@@ -0,0 +1,209 @@
+package gallery
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	themepkg "github.com/richq/m2cv/internal/theme"
+)
+
+// fakeExporter writes a two-page PDF stub and tracks concurrency.
+type fakeExporter struct {
+	active  atomic.Int32
+	maxSeen atomic.Int32
+	mu      sync.Mutex
+	themes  []string
+	fail    string
+}
+
+func (f *fakeExporter) ExportPDF(ctx context.Context, jsonPath, outputPath, theme, projectDir string) error {
+	n := f.active.Add(1)
+	defer f.active.Add(-1)
+	for {
+		max := f.maxSeen.Load()
+		if n <= max || f.maxSeen.CompareAndSwap(max, n) {
+			break
+		}
+	}
+	f.mu.Lock()
+	f.themes = append(f.themes, theme)
+	f.mu.Unlock()
+
+	time.Sleep(10 * time.Millisecond)
+	if theme == f.fail {
+		os.WriteFile(outputPath, []byte("partial"), 0644)
+		return errors.New("resumed export failed: boom")
+	}
+	if !filepath.IsAbs(jsonPath) || !filepath.IsAbs(outputPath) {
+		return errors.New("paths must be absolute")
+	}
+	pdf := "%PDF-1.4\n1 0 obj << /Type /Pages /Count 2 >>\n2 0 obj << /Type /Page >>\n3 0 obj <</Type/Page/Parent 1 0 R>>\n"
+	return os.WriteFile(outputPath, []byte(pdf), 0644)
+}
+
+func TestBuild(t *testing.T) {
+	dir := t.TempDir()
+	jsonPath := filepath.Join(dir, "resume.json")
+	if err := os.WriteFile(jsonPath, []byte(`{}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	outDir := filepath.Join(dir, "gallery")
+
+	themes := []string{"even", "flat", "@acme/jsonresume-theme-brand", "broken", "macchiato", "kendall"}
+	exporter := &fakeExporter{fail: "broken"}
+	var done []string
+	entries, err := Build(context.Background(), Options{
+		JSONPath: jsonPath,
+		OutDir:   outDir,
+		Themes:   themes,
+		Workers:  2,
+		Exporter: exporter,
+		OnDone:   func(e Entry) { done = append(done, e.Theme) },
+	})
+	if err != nil {
+		t.Fatalf("Build() error = %v", err)
+	}
+
+	if got := exporter.maxSeen.Load(); got > 2 {
+		t.Errorf("max concurrent exports = %d, want <= 2", got)
+	}
+	if len(exporter.themes) != len(themes) || len(done) != len(themes) {
+		t.Errorf("exported %v, done %v", exporter.themes, done)
+	}
+
+	for i, e := range entries {
+		if e.Theme != themes[i] {
+			t.Errorf("entries[%d] = %s, want theme order", i, e.Theme)
+		}
+	}
+	brand := entries[2]
+	if brand.File != "acme-jsonresume-theme-brand.pdf" || brand.Pages != 2 || brand.Size == 0 || brand.Err != nil {
+		t.Errorf("brand entry = %+v", brand)
+	}
+	if entries[3].Err == nil {
+		t.Error("broken theme should record its error")
+	}
+	if _, err := os.Stat(filepath.Join(outDir, "broken.pdf")); !os.IsNotExist(err) {
+		t.Error("failed export should not leave a PDF")
+	}
+
+	index, err := os.ReadFile(filepath.Join(outDir, IndexFile))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{`<a href="even.pdf">even.pdf</a>`, "resumed export failed: boom", "resume.json rendered in 6 theme(s)"} {
+		if !strings.Contains(string(index), want) {
+			t.Errorf("index missing %q:\n%s", want, index)
+		}
+	}
+}
+
+func TestBuild_AppliesStyle(t *testing.T) {
+	dir := t.TempDir()
+	jsonPath := filepath.Join(dir, "resume.json")
+	if err := os.WriteFile(jsonPath, []byte(`{}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.MkdirAll(filepath.Join(dir, "node_modules", "jsonresume-theme-even"), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	exporter := &fakeExporter{}
+	entries, err := Build(context.Background(), Options{
+		JSONPath:   jsonPath,
+		OutDir:     filepath.Join(dir, "gallery"),
+		ProjectDir: dir,
+		Themes:     []string{"even"},
+		Style:      themepkg.Style{AccentColor: "teal"},
+		Exporter:   exporter,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !themepkg.IsLocal(exporter.themes[0]) {
+		t.Errorf("exporter got %q, want the wrapped theme", exporter.themes[0])
+	}
+	if entries[0].Theme != "even" || entries[0].File != "even.pdf" {
+		t.Errorf("entry = %+v, should keep the theme's own name", entries[0])
+	}
+}
+
+func TestBuild_Cancelled(t *testing.T) {
+	dir := t.TempDir()
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := Build(ctx, Options{
+		JSONPath: filepath.Join(dir, "resume.json"),
+		OutDir:   filepath.Join(dir, "gallery"),
+		Themes:   []string{"even", "flat"},
+		Workers:  1,
+		Exporter: &fakeExporter{},
+	})
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("Build() error = %v, want context.Canceled", err)
+	}
+}
+
+func TestCountPages(t *testing.T) {
+	if n := CountPages([]byte("/Type /Pages /Kids [] /Type /Page /Type/Page")); n != 2 {
+		t.Errorf("CountPages() = %d, want 2", n)
+	}
+	if n := CountPages([]byte("not a pdf")); n != 0 {
+		t.Errorf("CountPages() = %d, want 0", n)
+	}
+}
+
+func TestFormatSize(t *testing.T) {
+	for n, want := range map[int64]string{512: "512 B", 2048: "2.0 KB", 3 << 20: "3.0 MB"} {
+		if got := formatSize(n); got != want {
+			t.Errorf("formatSize(%d) = %s, want %s", n, got, want)
+		}
+	}
+}
+
+func TestFileName(t *testing.T) {
+	tests := map[string]string{
+		"even":                         "even.pdf",
+		"@acme/jsonresume-theme-brand": "acme-jsonresume-theme-brand.pdf",
+		"npm:fancy-resume":             "npm-fancy-resume.pdf",
+		"file:themes/brand":            "file-brand.pdf",
+		"file:../shared/brand":         "file-brand.pdf",
+	}
+	for theme, want := range tests {
+		if got := FileName(theme); got != want {
+			t.Errorf("FileName(%q) = %q, want %q", theme, got, want)
+		}
+	}
+}
+
+func TestFileNames(t *testing.T) {
+	themes := []string{"file:themes/brand", "even", "file:../shared/brand", "file:themes/brand"}
+	names := FileNames("/project", themes)
+
+	if names[0] != "file-brand.pdf" || names[1] != "even.pdf" {
+		t.Errorf("first names should be unchanged: %q", names)
+	}
+	seen := make(map[string]bool)
+	for i, name := range names {
+		if seen[name] {
+			t.Errorf("duplicate file name %q for %s in %q", name, themes[i], names)
+		}
+		seen[name] = true
+	}
+	if !strings.HasPrefix(names[2], "file-brand-") || !strings.HasSuffix(names[2], ".pdf") {
+		t.Errorf("colliding local theme name = %q", names[2])
+	}
+
+	// The suffix depends on the resolved path, so names are stable per theme
+	if again := FileNames("/project", themes); again[2] != names[2] {
+		t.Errorf("FileNames() not deterministic: %q vs %q", again[2], names[2])
+	}
+}
@@ -0,0 +1,208 @@
+package generator
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/richq/m2cv/internal/application"
+	"github.com/richq/m2cv/internal/assets"
+	"github.com/richq/m2cv/internal/config"
+	"github.com/richq/m2cv/internal/provenance"
+	themepkg "github.com/richq/m2cv/internal/theme"
+)
+
+// Build steps of the generate pipeline, as recorded in the export's
+// provenance sidecar.
+const (
+	// StepConvert converts the optimized CV to resume.json via Claude.
+	StepConvert = "convert"
+	// StepExport renders resume.json to resume.pdf with resumed.
+	StepExport = "export"
+)
+
+// inputLabels name step inputs in the reasons a step is out of date.
+var inputLabels = map[string]string{
+	"optimized_cv":  "optimized CV",
+	"prompt":        "prompt",
+	"model":         "model",
+	"max_turns":     "max turns",
+	"args":          "claude arguments",
+	"resume":        "resume.json",
+	"theme":         "theme",
+	"theme_version": "theme version",
+	"theme_options": "theme options",
+}
+
+// Step is the state of one build step.
+type Step struct {
+	// Inputs fingerprints what the step would run with now.
+	Inputs provenance.Fingerprint
+	// Reasons say why the step has to run; empty when it is up to date.
+	Reasons []string
+}
+
+// Stale reports whether the step has to run.
+func (s Step) Stale() bool {
+	return len(s.Reasons) > 0
+}
+
+// BuildPlan says which steps of a generate run are out of date.
+type BuildPlan struct {
+	// CVPath is the optimized CV the build converts.
+	CVPath string
+	// Convert and Export are the two steps, in order.
+	Convert Step
+	Export  Step
+}
+
+// UpToDate reports whether resume.json and resume.pdf are both current.
+func (p *BuildPlan) UpToDate() bool {
+	return !p.Convert.Stale() && !p.Export.Stale()
+}
+
+// Reasons lists why the build is out of date: the conversion's reasons,
+// or the export's when only the export is stale.
+func (p *BuildPlan) Reasons() []string {
+	if p.Convert.Stale() {
+		return p.Convert.Reasons
+	}
+	return p.Export.Reasons
+}
+
+// Plan compares the current inputs of req's build steps with the ones
+// recorded when resume.json and resume.pdf were last built. A step is
+// stale when its output is missing, it has no record, or an input
+// changed; the export is also stale whenever the conversion is.
+func Plan(req GenerateRequest) (*BuildPlan, error) {
+	cvPath, err := resolveCVPath(req)
+	if err != nil {
+		return nil, err
+	}
+	cvContent, err := os.ReadFile(cvPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read optimized CV at %s: %w", cvPath, err)
+	}
+	promptTemplate, err := assets.GetPrompt(ConvertPrompt)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load prompt template: %w", err)
+	}
+	profile := resolveProfile(req.Models, "generate", ConvertPrompt, req.Model)
+
+	jsonPath := filepath.Join(req.AppDir, "resume.json")
+	pdfPath := filepath.Join(req.AppDir, "resume.pdf")
+	exportInputs, err := exportFingerprint(req, jsonPath)
+	if err != nil {
+		return nil, err
+	}
+
+	// An unreadable record just means everything is rebuilt
+	previous, err := provenance.Read(pdfPath)
+	if err != nil {
+		previous = nil
+	}
+
+	plan := &BuildPlan{
+		CVPath:  cvPath,
+		Convert: Step{Inputs: convertFingerprint(cvContent, promptTemplate, profile)},
+		Export:  Step{Inputs: exportInputs},
+	}
+	plan.Convert.Reasons = staleReasons(previous, StepConvert, plan.Convert.Inputs, jsonPath)
+	if plan.Convert.Stale() {
+		plan.Export.Reasons = []string{"resume.json is rebuilt"}
+	} else {
+		plan.Export.Reasons = staleReasons(previous, StepExport, plan.Export.Inputs, pdfPath)
+	}
+	return plan, nil
+}
+
+// staleReasons explains why step must run again to produce output, or
+// returns nil when its recorded inputs match inputs.
+func staleReasons(previous *provenance.Metadata, step string, inputs provenance.Fingerprint, output string) []string {
+	if _, err := os.Stat(output); err != nil {
+		if previous == nil {
+			return []string{"not built yet"}
+		}
+		return []string{filepath.Base(output) + " missing"}
+	}
+	var recorded provenance.Fingerprint
+	if previous != nil {
+		recorded = previous.Steps[step]
+	}
+	if recorded == nil {
+		return []string{"no build record"}
+	}
+
+	var reasons []string
+	for _, name := range inputs.Changed(recorded) {
+		label := inputLabels[name]
+		if label == "" {
+			label = name
+		}
+		reasons = append(reasons, label+" changed")
+	}
+	return reasons
+}
+
+// resolveCVPath returns the request's CV, defaulting to the latest
+// optimized version.
+func resolveCVPath(req GenerateRequest) (string, error) {
+	if req.CVPath != "" {
+		return req.CVPath, nil
+	}
+	latest, err := application.LatestVersionPath(req.AppDir)
+	if err != nil {
+		return "", fmt.Errorf("failed to find optimized CV: %w", err)
+	}
+	if latest == "" {
+		return "", fmt.Errorf("%w in %s. Run 'm2cv optimize %s' first", ErrNoOptimizedCV, req.AppDir, filepath.Base(req.AppDir))
+	}
+	return latest, nil
+}
+
+// convertFingerprint identifies the inputs of the conversion: the CV
+// content, the prompt template and the model profile.
+func convertFingerprint(cvContent []byte, promptTemplate string, profile config.ModelProfile) provenance.Fingerprint {
+	inputs := provenance.Fingerprint{
+		"optimized_cv": provenance.HashBytes(cvContent),
+		"prompt":       provenance.HashBytes([]byte(promptTemplate)),
+		"model":        profile.Model,
+	}
+	if profile.MaxTurns > 0 {
+		inputs["max_turns"] = strconv.Itoa(profile.MaxTurns)
+	}
+	if len(profile.Args) > 0 {
+		inputs["args"] = strings.Join(profile.Args, " ")
+	}
+	return inputs
+}
+
+// exportFingerprint identifies the inputs of the export: resume.json (if
+// it exists), the theme and its installed version, and the theme options.
+func exportFingerprint(req GenerateRequest, jsonPath string) (provenance.Fingerprint, error) {
+	resume, err := provenance.HashFile(jsonPath)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("failed to read %s: %w", jsonPath, err)
+	}
+	version, err := themepkg.Version(req.ProjectDir, req.Theme)
+	if err != nil {
+		return nil, err
+	}
+	style, err := themepkg.LoadStyle(req.ProjectDir, req.ThemeOptions, req.AppDir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load theme options: %w", err)
+	}
+	options, err := style.Hash()
+	if err != nil {
+		return nil, fmt.Errorf("failed to apply theme options: %w", err)
+	}
+	return provenance.Fingerprint{
+		"resume":        resume,
+		"theme":         req.Theme,
+		"theme_version": version,
+		"theme_options": options,
+	}, nil
+}
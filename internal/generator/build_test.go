@@ -0,0 +1,177 @@
+package generator
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/richq/m2cv/internal/config"
+	"github.com/richq/m2cv/internal/provenance"
+)
+
+// builtApp converts a CV with a stub, then records a fake export of
+// resume.pdf so the whole build is up to date.
+func builtApp(t *testing.T) GenerateRequest {
+	t.Helper()
+	projectDir := t.TempDir()
+	appDir := filepath.Join(projectDir, "applications", "acme")
+	themeDir := filepath.Join(projectDir, "node_modules", "jsonresume-theme-even")
+	for _, dir := range []string{appDir, themeDir} {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	writeTestFile(t, filepath.Join(themeDir, "package.json"), `{"version": "1.0.0"}`)
+	writeTestFile(t, filepath.Join(appDir, "optimized-cv-1.md"), "# Jane")
+
+	req := GenerateRequest{
+		AppDir:     appDir,
+		ProjectDir: projectDir,
+		Theme:      "even",
+		Executor:   &stubExecutor{output: `{"basics": {"name": "Jane"}}`},
+	}
+	result, err := Convert(context.Background(), req)
+	if err != nil {
+		t.Fatalf("Convert() error = %v", err)
+	}
+
+	plan, err := Plan(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if plan.Convert.Stale() || !slices.Equal(plan.Export.Reasons, []string{"resume.pdf missing"}) {
+		t.Fatalf("plan after Convert = %+v", plan)
+	}
+
+	pdfPath := filepath.Join(appDir, "resume.pdf")
+	writeTestFile(t, pdfPath, "%PDF")
+	meta, err := provenance.Read(result.JSONPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	meta.SetStep(StepExport, plan.Export.Inputs)
+	if err := provenance.Write(pdfPath, meta); err != nil {
+		t.Fatal(err)
+	}
+	return req
+}
+
+func writeTestFile(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestPlan_NotBuilt(t *testing.T) {
+	appDir := t.TempDir()
+	writeTestFile(t, filepath.Join(appDir, "optimized-cv-1.md"), "# Jane")
+
+	plan, err := Plan(GenerateRequest{AppDir: appDir, ProjectDir: appDir, Theme: "even"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !slices.Equal(plan.Reasons(), []string{"not built yet"}) || !plan.Export.Stale() {
+		t.Errorf("plan = %+v", plan)
+	}
+}
+
+func TestPlan_Changes(t *testing.T) {
+	tests := []struct {
+		name    string
+		change  func(t *testing.T, req *GenerateRequest)
+		convert bool
+		reasons []string
+	}{
+		{
+			name: "new CV version",
+			change: func(t *testing.T, req *GenerateRequest) {
+				writeTestFile(t, filepath.Join(req.AppDir, "optimized-cv-2.md"), "# Jane Doe")
+			},
+			convert: true,
+			reasons: []string{"optimized CV changed"},
+		},
+		{
+			name:    "model",
+			change:  func(t *testing.T, req *GenerateRequest) { req.Models = config.ModelSettings{Default: "opus"} },
+			convert: true,
+			reasons: []string{"model changed"},
+		},
+		{
+			name:    "theme",
+			change:  func(t *testing.T, req *GenerateRequest) { req.Theme = "flat" },
+			reasons: []string{"theme changed", "theme version changed"},
+		},
+		{
+			name: "theme package",
+			change: func(t *testing.T, req *GenerateRequest) {
+				writeTestFile(t, filepath.Join(req.ProjectDir, "node_modules", "jsonresume-theme-even", "package.json"), `{"version": "1.1.0"}`)
+			},
+			reasons: []string{"theme version changed"},
+		},
+		{
+			name:    "theme options",
+			change:  func(t *testing.T, req *GenerateRequest) { req.ThemeOptions.AccentColor = "#0a7" },
+			reasons: []string{"theme options changed"},
+		},
+		{
+			name: "edited resume.json",
+			change: func(t *testing.T, req *GenerateRequest) {
+				writeTestFile(t, filepath.Join(req.AppDir, "resume.json"), `{}`)
+			},
+			reasons: []string{"resume.json changed"},
+		},
+		{
+			name:    "deleted PDF",
+			change:  func(t *testing.T, req *GenerateRequest) { os.Remove(filepath.Join(req.AppDir, "resume.pdf")) },
+			reasons: []string{"resume.pdf missing"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := builtApp(t)
+			plan, err := Plan(req)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !plan.UpToDate() {
+				t.Fatalf("fresh build is stale: %v", plan.Reasons())
+			}
+
+			tt.change(t, &req)
+			if plan, err = Plan(req); err != nil {
+				t.Fatal(err)
+			}
+			if plan.Convert.Stale() != tt.convert || !plan.Export.Stale() {
+				t.Errorf("convert stale = %v, export stale = %v", plan.Convert.Stale(), plan.Export.Stale())
+			}
+			if !slices.Equal(plan.Reasons(), tt.reasons) {
+				t.Errorf("Reasons() = %v, want %v", plan.Reasons(), tt.reasons)
+			}
+		})
+	}
+}
+
+func TestGenerate_SkipsUpToDateBuild(t *testing.T) {
+	req := builtApp(t)
+	stub := &stubExecutor{}
+	req.Executor = stub
+
+	result, err := Generate(context.Background(), req)
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+	if !slices.Equal(result.Skipped, []string{StepConvert, StepExport}) || stub.prompt != "" {
+		t.Errorf("Skipped = %v, prompt sent = %v", result.Skipped, stub.prompt != "")
+	}
+
+	// --force converts again, however unchanged
+	req.Force = true
+	stub.err = context.Canceled
+	if _, err := Generate(context.Background(), req); err == nil || stub.prompt == "" {
+		t.Errorf("forced Generate() should convert, err = %v", err)
+	}
+}
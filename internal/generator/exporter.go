@@ -10,6 +10,7 @@ import (
 	"strings"
 
 	"github.com/richq/m2cv/internal/executor"
+	themepkg "github.com/richq/m2cv/internal/theme"
 )
 
 // Exporter exports JSON Resume documents to PDF using resumed.
@@ -39,15 +40,18 @@ func NewExporterWithOptions(opts *executor.FindOptions) (*Exporter, error) {
 	return &Exporter{npxPath: npxPath}, nil
 }
 
-// CheckThemeInstalled checks if a JSON Resume theme is installed in node_modules.
+// CheckThemeInstalled checks if a JSON Resume theme is installed in node_modules,
+// or for a local theme that its directory exists.
 // Returns nil if the theme is installed, or an error with installation instructions.
 func (e *Exporter) CheckThemeInstalled(projectDir, theme string) error {
-	themePackage := "jsonresume-theme-" + theme
-	themePath := filepath.Join(projectDir, "node_modules", themePackage)
+	themePath := themepkg.Dir(projectDir, theme)
 
 	info, err := os.Stat(themePath)
 	if os.IsNotExist(err) {
-		return fmt.Errorf("theme %q not installed. Run: npm install %s", theme, themePackage)
+		if themepkg.IsLocal(theme) {
+			return fmt.Errorf("theme %q not found: no directory at %s", theme, themePath)
+		}
+		return fmt.Errorf("theme %q not installed. Run: npm install %s", theme, themepkg.Package(theme))
 	}
 	if err != nil {
 		return fmt.Errorf("error checking theme %q: %w", theme, err)
@@ -65,25 +69,36 @@ func (e *Exporter) CheckThemeInstalled(projectDir, theme string) error {
 //   - ctx: context for cancellation
 //   - jsonPath: path to the JSON Resume file to export
 //   - outputPath: path for the output PDF file
-//   - theme: JSON Resume theme name (e.g., "even", "stackoverflow")
+//   - theme: JSON Resume theme reference (e.g., "even", "file:themes/brand")
 //   - projectDir: project directory containing node_modules with resumed and theme
 //
 // The projectDir is critical - resumed resolves themes from node_modules relative to
 // the working directory, so cmd.Dir must be set correctly.
 func (e *Exporter) ExportPDF(ctx context.Context, jsonPath, outputPath, theme, projectDir string) error {
+	return e.resumed(ctx, "export", jsonPath, outputPath, theme, projectDir)
+}
+
+// RenderHTML renders a JSON Resume file to HTML with a theme using
+// resumed render. Paths are resolved like ExportPDF.
+func (e *Exporter) RenderHTML(ctx context.Context, jsonPath, outputPath, theme, projectDir string) error {
+	return e.resumed(ctx, "render", jsonPath, outputPath, theme, projectDir)
+}
+
+// resumed runs a resumed subcommand (export or render) in projectDir.
+func (e *Exporter) resumed(ctx context.Context, command, jsonPath, outputPath, theme, projectDir string) error {
 	// Validate theme is installed before attempting export
 	if err := e.CheckThemeInstalled(projectDir, theme); err != nil {
 		return err
 	}
 
-	// Build command: npx resumed export <jsonPath> --output <outputPath> --theme <themePackage>
-	themePackage := "jsonresume-theme-" + theme
+	// Build command: npx resumed <command> <jsonPath> --output <outputPath> --theme <theme>
+	// where <theme> is the package name, or the absolute directory of a local theme
 	args := []string{
 		"resumed",
-		"export",
+		command,
 		jsonPath,
 		"--output", outputPath,
-		"--theme", themePackage,
+		"--theme", themepkg.Resolve(projectDir, theme),
 	}
 
 	cmd := exec.CommandContext(ctx, e.npxPath, args...)
@@ -103,9 +118,9 @@ func (e *Exporter) ExportPDF(ctx context.Context, jsonPath, outputPath, theme, p
 	if err := cmd.Wait(); err != nil {
 		stderrContent := strings.TrimSpace(stderr.String())
 		if stderrContent != "" {
-			return fmt.Errorf("resumed export failed: %w\nstderr: %s", err, stderrContent)
+			return fmt.Errorf("resumed %s failed: %w\nstderr: %s", command, err, stderrContent)
 		}
-		return fmt.Errorf("resumed export failed: %w", err)
+		return fmt.Errorf("resumed %s failed: %w", command, err)
 	}
 
 	return nil
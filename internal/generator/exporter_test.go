@@ -125,6 +125,31 @@ func TestExporter_CheckThemeInstalled(t *testing.T) {
 			wantErr:     true,
 			errContains: "not a directory",
 		},
+		{
+			name: "local theme directory exists",
+			setupFunc: func() string {
+				projectDir := filepath.Join(tmpDir, "project5")
+				if err := os.MkdirAll(filepath.Join(projectDir, "themes", "brand"), 0755); err != nil {
+					t.Fatalf("failed to create theme dir: %v", err)
+				}
+				return projectDir
+			},
+			theme:   "file:themes/brand",
+			wantErr: false,
+		},
+		{
+			name: "local theme directory missing",
+			setupFunc: func() string {
+				projectDir := filepath.Join(tmpDir, "project6")
+				if err := os.MkdirAll(projectDir, 0755); err != nil {
+					t.Fatalf("failed to create project dir: %v", err)
+				}
+				return projectDir
+			},
+			theme:       "./themes/missing",
+			wantErr:     true,
+			errContains: "no directory at",
+		},
 	}
 
 	for _, tt := range tests {
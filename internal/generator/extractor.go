@@ -1,5 +1,6 @@
 // Package generator provides the core pipeline components for resume generation:
-// JSON extraction from Claude output, schema validation, and PDF export.
+// JSON extraction from Claude output, schema validation, and PDF export, plus
+// the optimize and generate pipelines that run them end to end.
 package generator
 
 import (
@@ -0,0 +1,51 @@
+package generator
+
+import (
+	"github.com/richq/m2cv/internal/config"
+	"github.com/richq/m2cv/internal/executor"
+)
+
+// Prompt names, as used for model profiles and provenance.
+const (
+	// ConvertPrompt converts an optimized CV to JSON Resume.
+	ConvertPrompt = "md-to-json-resume"
+	// OptimizePrompt tailors the base CV to a job description.
+	OptimizePrompt = "optimize"
+	// OptimizeATSPrompt tailors the base CV for applicant tracking systems.
+	OptimizeATSPrompt = "optimize-ats"
+	// OptimizeUpdatePrompt updates a tailored CV for base CV changes.
+	OptimizeUpdatePrompt = "optimize-update"
+)
+
+// OptimizePromptName returns the tailoring prompt for the given mode.
+func OptimizePromptName(atsMode bool) string {
+	if atsMode {
+		return OptimizeATSPrompt
+	}
+	return OptimizePrompt
+}
+
+// resolveProfile returns the profile for prompt run by command. A non-empty
+// model (e.g. from a --model flag) overrides the profile's.
+func resolveProfile(models config.ModelSettings, command, prompt, model string) config.ModelProfile {
+	profile := models.Resolve(command, prompt)
+	if model != "" {
+		profile.Model = model
+	}
+	return profile
+}
+
+// ProfileOptions returns the Execute options applying profile.
+func ProfileOptions(profile config.ModelProfile) []executor.ExecuteOption {
+	var opts []executor.ExecuteOption
+	if profile.Model != "" {
+		opts = append(opts, executor.WithModel(profile.Model))
+	}
+	if profile.MaxTurns > 0 {
+		opts = append(opts, executor.WithMaxTurns(profile.MaxTurns))
+	}
+	if len(profile.Args) > 0 {
+		opts = append(opts, executor.WithExtraArgs(profile.Args...))
+	}
+	return opts
+}
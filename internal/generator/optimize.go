@@ -0,0 +1,192 @@
+package generator
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/richq/m2cv/internal/application"
+	"github.com/richq/m2cv/internal/assets"
+	"github.com/richq/m2cv/internal/config"
+	"github.com/richq/m2cv/internal/diff"
+	"github.com/richq/m2cv/internal/executor"
+	"github.com/richq/m2cv/internal/provenance"
+	"github.com/richq/m2cv/internal/usage"
+)
+
+// OptimizeRequest describes a single run of the optimize pipeline.
+type OptimizeRequest struct {
+	// AppDir is the application folder containing the job description.
+	AppDir string
+	// BaseCVPath is the resolved path of the base CV markdown.
+	BaseCVPath string
+	// ProjectDir, if set, receives snapshots of the base CV and Claude's
+	// output (see provenance.SaveSnapshot), so later changes to the base CV
+	// can be summarized and edits to the output rebased.
+	ProjectDir string
+	// PreviousPath, if set, selects an update instead of tailoring from
+	// scratch: that optimized version is revised for the base CV changes
+	// since PreviousBaseCV, keeping any manual edits made to it.
+	PreviousPath   string
+	PreviousBaseCV []byte
+	// Rebase merges the manual edits made to the latest version into a
+	// fresh optimization (a three-way merge against the Claude output the
+	// edits started from). Snapshots in ProjectDir supply that output.
+	Rebase bool
+	// Model overrides the model from Models, e.g. from a --model flag.
+	Model string
+	// Models resolves the model, max turns and extra args of the
+	// "optimize" command and its prompt.
+	Models config.ModelSettings
+	// ATSMode selects the optimize-ats prompt.
+	ATSMode bool
+	// Executor runs the tailoring prompt. Defaults to NewClaudeExecutor().
+	Executor executor.ClaudeExecutor
+	// ExecuteOptions are passed through to Execute (e.g. WithProgress).
+	ExecuteOptions []executor.ExecuteOption
+	// ToolVersion and ToolCommit identify the m2cv build for provenance.
+	ToolVersion string
+	ToolCommit  string
+}
+
+// OptimizeResult reports the version written by the pipeline.
+type OptimizeResult struct {
+	// OutputPath is the new optimized-cv-N.md.
+	OutputPath string
+	// Conflicts counts the conflict markers written by a rebase.
+	Conflicts int
+	// Warnings lists non-fatal problems (usage or provenance not recorded).
+	Warnings []string
+}
+
+// Optimize tailors the base CV to the application's job description via
+// Claude and writes the result as the next optimized-cv-N.md. Usage is
+// appended to the application's ledger and a provenance sidecar is written
+// for the new version. With PreviousPath set, the previous version is
+// updated for the base CV changes instead (the optimize-update prompt).
+// With Rebase set, the new version merges the latest version's manual
+// edits into the fresh optimization.
+func Optimize(ctx context.Context, req OptimizeRequest) (*OptimizeResult, error) {
+	startedAt := time.Now()
+	result := &OptimizeResult{}
+
+	if req.Rebase && req.PreviousPath != "" {
+		return nil, fmt.Errorf("a rebase can't also update a previous version")
+	}
+	var rebase *rebaseInputs
+	if req.Rebase {
+		var err error
+		if rebase, err = findRebaseInputs(req.AppDir, req.ProjectDir); err != nil {
+			return nil, err
+		}
+	}
+
+	baseCV, err := os.ReadFile(req.BaseCVPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read base CV at %s: %w", req.BaseCVPath, err)
+	}
+
+	jdPath, err := application.FindJobDescription(req.AppDir)
+	if err != nil {
+		return nil, err
+	}
+	jobDescription, err := os.ReadFile(jdPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read job description at %s: %w", jdPath, err)
+	}
+
+	// Select and build prompt
+	promptName := OptimizePromptName(req.ATSMode)
+	var previous []byte
+	if req.PreviousPath != "" {
+		promptName = OptimizeUpdatePrompt
+		if previous, err = os.ReadFile(req.PreviousPath); err != nil {
+			return nil, fmt.Errorf("failed to read optimized CV at %s: %w", req.PreviousPath, err)
+		}
+	}
+	promptTemplate, err := assets.GetPrompt(promptName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load prompt template: %w", err)
+	}
+	prompt := strings.ReplaceAll(promptTemplate, "{{.BaseCV}}", string(baseCV))
+	prompt = strings.ReplaceAll(prompt, "{{.JobDescription}}", string(jobDescription))
+	if req.PreviousPath != "" {
+		changes := diff.Unified("base CV (before)", "base CV (now)", string(req.PreviousBaseCV), string(baseCV), 3)
+		prompt = strings.ReplaceAll(prompt, "{{.BaseCVDiff}}", changes)
+		prompt = strings.ReplaceAll(prompt, "{{.TailoredCV}}", string(previous))
+	}
+
+	// Execute Claude, capturing the result envelope for the usage ledger
+	exec := req.Executor
+	if exec == nil {
+		exec = executor.NewClaudeExecutor()
+	}
+	var usageResult executor.Result
+	profile := resolveProfile(req.Models, "optimize", promptName, req.Model)
+	// A cancelled run's partial output is kept next to the versions
+	opts := append([]executor.ExecuteOption{executor.WithPartialOutputDir(req.AppDir)}, req.ExecuteOptions...)
+	opts = append(opts, ProfileOptions(profile)...)
+	opts = append(opts, executor.WithResult(&usageResult))
+
+	output, err := exec.Execute(ctx, prompt, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to optimize CV: %w", err)
+	}
+	if err := usage.Append(req.AppDir, usage.NewRecord(filepath.Base(req.AppDir), "optimize", &usageResult)); err != nil {
+		result.Warnings = append(result.Warnings, fmt.Sprintf("failed to record usage: %v", err))
+	}
+
+	// Write versioned output
+	result.OutputPath, err = application.NextVersionPath(req.AppDir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to determine output path: %w", err)
+	}
+	text := output
+	if rebase != nil {
+		merged := rebase.merge(output)
+		text, result.Conflicts = merged.Text, merged.Conflicts
+	}
+	if err := os.WriteFile(result.OutputPath, []byte(text), 0644); err != nil {
+		return nil, fmt.Errorf("failed to write optimized CV: %w", err)
+	}
+
+	// Record provenance alongside the new version
+	meta := &provenance.Metadata{
+		Command:     "optimize",
+		ToolVersion: req.ToolVersion,
+		ToolCommit:  req.ToolCommit,
+		Prompt:      &provenance.Prompt{Name: promptName, SHA256: provenance.HashBytes([]byte(promptTemplate))},
+		Model:       profile.Model,
+		ATSMode:     req.ATSMode,
+		StartedAt:   startedAt.UTC(),
+	}
+	meta.AddInput(provenance.RoleBaseCV, req.BaseCVPath, baseCV)
+	meta.AddInput(provenance.RoleJobDescription, jdPath, jobDescription)
+	if req.PreviousPath != "" {
+		meta.AddInput(provenance.RoleOptimizedCV, req.PreviousPath, previous)
+	}
+	if rebase != nil {
+		meta.AddInput(provenance.RoleOptimizedCV, rebase.oursPath, rebase.ours)
+	}
+	if text != output {
+		meta.ModelOutputSHA256 = provenance.HashBytes([]byte(output))
+	}
+	if err := recordOutputs(meta, result.OutputPath, result.OutputPath); err != nil {
+		result.Warnings = append(result.Warnings, fmt.Sprintf("failed to record provenance: %v", err))
+	}
+	if req.ProjectDir != "" {
+		if _, err := provenance.SaveSnapshot(req.ProjectDir, baseCV); err != nil {
+			result.Warnings = append(result.Warnings, fmt.Sprintf("failed to snapshot base CV: %v", err))
+		}
+		// Keep Claude's output too, so a later rebase can merge edits made
+		// to this version in place
+		if _, err := provenance.SaveSnapshot(req.ProjectDir, []byte(output)); err != nil {
+			result.Warnings = append(result.Warnings, fmt.Sprintf("failed to snapshot optimized CV: %v", err))
+		}
+	}
+
+	return result, nil
+}
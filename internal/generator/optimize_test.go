@@ -0,0 +1,185 @@
+package generator
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/richq/m2cv/internal/application"
+	"github.com/richq/m2cv/internal/config"
+	"github.com/richq/m2cv/internal/executor"
+	"github.com/richq/m2cv/internal/provenance"
+	"github.com/richq/m2cv/internal/usage"
+)
+
+// newOptimizeFixture creates a base CV and an application with a job description.
+func newOptimizeFixture(t *testing.T) (baseCVPath, appDir string) {
+	t.Helper()
+	dir := t.TempDir()
+	baseCVPath = filepath.Join(dir, "base-cv.md")
+	if err := os.WriteFile(baseCVPath, []byte("# Base CV"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	appDir = filepath.Join(dir, "applications", "acme")
+	if err := os.MkdirAll(appDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(appDir, "job.txt"), []byte("Go engineer wanted"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return baseCVPath, appDir
+}
+
+func TestOptimize_WritesVersionWithProvenance(t *testing.T) {
+	baseCVPath, appDir := newOptimizeFixture(t)
+	stub := &stubExecutor{output: "# Tailored CV"}
+
+	result, err := Optimize(context.Background(), OptimizeRequest{
+		AppDir:     appDir,
+		BaseCVPath: baseCVPath,
+		ATSMode:    true,
+		Executor:   stub,
+	})
+	if err != nil {
+		t.Fatalf("Optimize() error = %v", err)
+	}
+	if len(result.Warnings) != 0 {
+		t.Errorf("unexpected warnings: %v", result.Warnings)
+	}
+	if result.OutputPath != application.VersionPath(appDir, 1) {
+		t.Errorf("OutputPath = %s", result.OutputPath)
+	}
+	if !strings.Contains(stub.prompt, "# Base CV") || !strings.Contains(stub.prompt, "Go engineer wanted") {
+		t.Error("prompt should contain the base CV and job description")
+	}
+
+	meta, err := provenance.Read(result.OutputPath)
+	if err != nil {
+		t.Fatalf("provenance.Read() error = %v", err)
+	}
+	if meta.Prompt.Name != "optimize-ats" || !meta.ATSMode || len(meta.Inputs) != 2 {
+		t.Errorf("unexpected metadata: %+v", meta)
+	}
+
+	records, err := usage.Load(appDir)
+	if err != nil || len(records) != 1 || records[0].Command != "optimize" {
+		t.Errorf("expected one optimize usage record, got %+v (%v)", records, err)
+	}
+}
+
+func TestOptimize_Errors(t *testing.T) {
+	baseCVPath, appDir := newOptimizeFixture(t)
+
+	_, err := Optimize(context.Background(), OptimizeRequest{AppDir: appDir, BaseCVPath: baseCVPath + ".missing", Executor: &stubExecutor{}})
+	if err == nil || !strings.Contains(err.Error(), "failed to read base CV") {
+		t.Errorf("missing base CV error = %v", err)
+	}
+
+	_, err = Optimize(context.Background(), OptimizeRequest{AppDir: t.TempDir(), BaseCVPath: baseCVPath, Executor: &stubExecutor{}})
+	if !errors.Is(err, application.ErrNoJobDescription) {
+		t.Errorf("missing job description error = %v", err)
+	}
+
+	_, err = Optimize(context.Background(), OptimizeRequest{AppDir: appDir, BaseCVPath: baseCVPath, Executor: &stubExecutor{err: errors.New("boom")}})
+	if err == nil || !strings.Contains(err.Error(), "failed to optimize CV") {
+		t.Errorf("executor error = %v", err)
+	}
+	if versions, _ := application.ListVersions(appDir); len(versions) != 0 {
+		t.Errorf("failed run should not write a version, got %v", versions)
+	}
+}
+
+// nopProgress enables streaming without reporting anything.
+type nopProgress struct{}
+
+func (nopProgress) Event(executor.StreamEvent) {}
+func (nopProgress) Done(error)                 {}
+
+func TestOptimize_CancelKeepsPartialOutput(t *testing.T) {
+	baseCVPath, appDir := newOptimizeFixture(t)
+	fakeClaude := filepath.Join(t.TempDir(), "claude")
+	script := `#!/bin/sh
+cat > /dev/null
+echo '{"type":"stream_event","event":{"type":"content_block_delta","delta":{"type":"text_delta","text":"half a CV"}}}'
+exec sleep 30
+`
+	if err := os.WriteFile(fakeClaude, []byte(script), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	// Cancel (as Ctrl-C does) once claude has streamed some text
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() {
+		for {
+			matches, _ := filepath.Glob(filepath.Join(appDir, "m2cv-partial-*.md"))
+			if len(matches) == 1 {
+				if data, _ := os.ReadFile(matches[0]); len(data) > 0 {
+					cancel()
+					return
+				}
+			}
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(10 * time.Millisecond):
+			}
+		}
+	}()
+
+	_, err := Optimize(ctx, OptimizeRequest{
+		AppDir:         appDir,
+		BaseCVPath:     baseCVPath,
+		Executor:       executor.NewClaudeExecutor(executor.WithClaudePath(fakeClaude)),
+		ExecuteOptions: []executor.ExecuteOption{executor.WithProgress(nopProgress{})},
+	})
+	if err == nil || !strings.Contains(err.Error(), "partial output saved to: "+filepath.Join(appDir, "m2cv-partial-")) {
+		t.Fatalf("Optimize() error = %v, want the partial output in the application folder", err)
+	}
+	if versions, _ := application.ListVersions(appDir); len(versions) != 0 {
+		t.Errorf("cancelled run should not write a version, got %v", versions)
+	}
+}
+
+func TestOptimize_ModelProfile(t *testing.T) {
+	models := config.ModelSettings{
+		Default: "sonnet",
+		Profiles: map[string]config.ModelProfile{
+			"optimize":     {Model: "opus", MaxTurns: 2},
+			"optimize-ats": {Model: "claude-opus-4-1"},
+		},
+	}
+	for _, tt := range []struct {
+		ats   bool
+		flag  string
+		model string
+	}{
+		{false, "", "opus"},
+		{true, "", "claude-opus-4-1"},
+		{true, "haiku", "haiku"},
+	} {
+		baseCVPath, appDir := newOptimizeFixture(t)
+		result, err := Optimize(context.Background(), OptimizeRequest{
+			AppDir:     appDir,
+			BaseCVPath: baseCVPath,
+			Model:      tt.flag,
+			Models:     models,
+			ATSMode:    tt.ats,
+			Executor:   &stubExecutor{output: "# CV"},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		meta, err := provenance.Read(result.OutputPath)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if meta.Model != tt.model {
+			t.Errorf("ats=%v flag=%q: model = %q, want %q", tt.ats, tt.flag, meta.Model, tt.model)
+		}
+	}
+}
@@ -0,0 +1,338 @@
+package generator
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/richq/m2cv/internal/assets"
+	"github.com/richq/m2cv/internal/config"
+	"github.com/richq/m2cv/internal/executor"
+	"github.com/richq/m2cv/internal/provenance"
+	themepkg "github.com/richq/m2cv/internal/theme"
+	"github.com/richq/m2cv/internal/usage"
+)
+
+// GenerateRequest describes a single run of the generate pipeline.
+type GenerateRequest struct {
+	// AppDir is the application folder (e.g. "applications/acme").
+	AppDir string
+	// ProjectDir contains node_modules with resumed and the theme.
+	ProjectDir string
+	// CVPath is the optimized CV to convert. Empty means the latest version.
+	CVPath string
+	// Theme is the JSON Resume theme name.
+	Theme string
+	// ThemeOptions are the project's theme options; the application's
+	// application.yml is layered over them at export time.
+	ThemeOptions config.ThemeOptions
+	// Model overrides the model from Models, e.g. from a --model flag.
+	Model string
+	// Models resolves the model, max turns and extra args of the
+	// "generate" command and its conversion prompt.
+	Models config.ModelSettings
+	// Executor runs the conversion prompt. Defaults to NewClaudeExecutor().
+	Executor executor.ClaudeExecutor
+	// ExecuteOptions are passed through to Execute (e.g. WithProgress).
+	ExecuteOptions []executor.ExecuteOption
+	// Force runs every step of Generate, even those whose inputs are
+	// unchanged since the last build.
+	Force bool
+	// ToolVersion and ToolCommit identify the m2cv build for provenance.
+	ToolVersion string
+	ToolCommit  string
+}
+
+// GenerateResult reports the files written by the pipeline.
+type GenerateResult struct {
+	// CVPath is the optimized CV that was converted.
+	CVPath string
+	// JSONPath is the written resume.json.
+	JSONPath string
+	// PDFPath is the exported resume.pdf.
+	PDFPath string
+	// Warnings lists non-fatal problems (usage or provenance not recorded).
+	Warnings []string
+	// Skipped lists the build steps (StepConvert, StepExport) that were
+	// up to date and not run.
+	Skipped []string
+}
+
+// ErrNoOptimizedCV is returned when the application has no optimized CV yet.
+var ErrNoOptimizedCV = errors.New("no optimized CV found")
+
+// Generate converts an optimized CV to JSON Resume via Claude, validates it,
+// writes resume.json and exports resume.pdf with resumed. Usage is appended
+// to the application's ledger and a provenance sidecar is written for the export.
+//
+// Steps whose inputs are unchanged since the last build (see Plan) are
+// skipped unless req.Force is set: an unchanged conversion only re-exports
+// the PDF, and an unchanged build does nothing.
+func Generate(ctx context.Context, req GenerateRequest) (*GenerateResult, error) {
+	plan, err := Plan(req)
+	if err != nil {
+		return nil, err
+	}
+	if !req.Force && !plan.Convert.Stale() {
+		if !plan.Export.Stale() {
+			return &GenerateResult{
+				CVPath:   plan.CVPath,
+				JSONPath: filepath.Join(req.AppDir, "resume.json"),
+				PDFPath:  filepath.Join(req.AppDir, "resume.pdf"),
+				Skipped:  []string{StepConvert, StepExport},
+			}, nil
+		}
+		result, err := Export(ctx, req)
+		if err != nil {
+			return nil, err
+		}
+		result.Skipped = []string{StepConvert}
+		return result, nil
+	}
+
+	req.CVPath = plan.CVPath
+	result, meta, err := convert(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	result.PDFPath = filepath.Join(req.AppDir, "resume.pdf")
+	exportInputs, err := exportFingerprint(req, result.JSONPath)
+	if err != nil {
+		return nil, err
+	}
+	if err := exportPDF(ctx, req, result.JSONPath, result.PDFPath); err != nil {
+		return nil, err
+	}
+
+	// Record provenance for the export
+	meta.Theme = req.Theme
+	meta.SetStep(StepExport, exportInputs)
+	if err := recordOutputs(meta, result.PDFPath, result.JSONPath, result.PDFPath); err != nil {
+		result.Warnings = append(result.Warnings, fmt.Sprintf("failed to record provenance: %v", err))
+	}
+
+	return result, nil
+}
+
+// Convert runs only the Claude half of Generate: the optimized CV is
+// converted, validated and written to resume.json, with usage and a
+// provenance sidecar recorded. No PDF is exported.
+func Convert(ctx context.Context, req GenerateRequest) (*GenerateResult, error) {
+	result, meta, err := convert(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	err = meta.AddOutput(result.JSONPath)
+	if err == nil {
+		keepExport(meta, result.JSONPath)
+		err = provenance.Write(result.JSONPath, meta)
+	}
+	if err != nil {
+		result.Warnings = append(result.Warnings, fmt.Sprintf("failed to record provenance: %v", err))
+	}
+	return result, nil
+}
+
+// keepExport appends the last export's step, theme and PDF hash from the
+// sidecar at artifactPath to meta. resume.json and resume.pdf share a
+// sidecar, so without this a conversion would erase the record of the
+// resume.pdf still on disk; the kept step no longer matches the new
+// resume.json, so the export is planned again.
+func keepExport(meta *provenance.Metadata, artifactPath string) {
+	previous, err := provenance.Read(artifactPath)
+	if err != nil {
+		return
+	}
+	step, ok := previous.Steps[StepExport]
+	if !ok {
+		return
+	}
+	meta.Theme = previous.Theme
+	meta.SetStep(StepExport, step)
+	for _, f := range previous.Outputs {
+		if filepath.Base(f.Path) == "resume.pdf" {
+			meta.Outputs = append(meta.Outputs, f)
+		}
+	}
+}
+
+// convert writes resume.json for Generate and Convert and returns the
+// provenance to record once the caller's outputs exist.
+func convert(ctx context.Context, req GenerateRequest) (*GenerateResult, *provenance.Metadata, error) {
+	startedAt := time.Now()
+	result := &GenerateResult{}
+
+	// Resolve the CV to convert
+	cvPath, err := resolveCVPath(req)
+	if err != nil {
+		return nil, nil, err
+	}
+	result.CVPath = cvPath
+
+	cvContent, err := os.ReadFile(cvPath)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to read optimized CV at %s: %w", cvPath, err)
+	}
+
+	// Build the conversion prompt
+	promptTemplate, err := assets.GetPrompt(ConvertPrompt)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to load prompt template: %w", err)
+	}
+	prompt := strings.ReplaceAll(promptTemplate, "{{.CV}}", string(cvContent))
+
+	// Execute Claude, capturing the result envelope for the usage ledger
+	exec := req.Executor
+	if exec == nil {
+		exec = executor.NewClaudeExecutor()
+	}
+	var usageResult executor.Result
+	profile := resolveProfile(req.Models, "generate", ConvertPrompt, req.Model)
+	// A cancelled run's partial output is kept next to the versions
+	opts := append([]executor.ExecuteOption{executor.WithPartialOutputDir(req.AppDir)}, req.ExecuteOptions...)
+	opts = append(opts, ProfileOptions(profile)...)
+	opts = append(opts, executor.WithResult(&usageResult))
+
+	output, err := exec.Execute(ctx, prompt, opts...)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to convert CV to JSON Resume: %w", err)
+	}
+	if err := usage.Append(req.AppDir, usage.NewRecord(filepath.Base(req.AppDir), "generate", &usageResult)); err != nil {
+		result.Warnings = append(result.Warnings, fmt.Sprintf("failed to record usage: %v", err))
+	}
+
+	// Extract and validate JSON Resume
+	jsonResume, err := ExtractJSON([]byte(output))
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to extract JSON from Claude output: %w", err)
+	}
+
+	validator, err := NewValidator()
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to initialize validator: %w", err)
+	}
+	if err := validator.Validate(jsonResume); err != nil {
+		return nil, nil, fmt.Errorf("JSON Resume validation failed: %w. Try running 'm2cv generate' again or check the optimized CV", err)
+	}
+
+	// Write resume.json (useful for debugging)
+	result.JSONPath = filepath.Join(req.AppDir, "resume.json")
+	if err := os.WriteFile(result.JSONPath, jsonResume, 0644); err != nil {
+		return nil, nil, fmt.Errorf("failed to write resume.json: %w", err)
+	}
+
+	meta := &provenance.Metadata{
+		Command:     "generate",
+		ToolVersion: req.ToolVersion,
+		ToolCommit:  req.ToolCommit,
+		Prompt:      &provenance.Prompt{Name: ConvertPrompt, SHA256: provenance.HashBytes([]byte(promptTemplate))},
+		Model:       profile.Model,
+		StartedAt:   startedAt.UTC(),
+	}
+	meta.AddInput(provenance.RoleOptimizedCV, cvPath, cvContent)
+	meta.SetStep(StepConvert, convertFingerprint(cvContent, promptTemplate, profile))
+	return result, meta, nil
+}
+
+// Export re-exports resume.pdf from the application's existing resume.json
+// without calling Claude, e.g. after the theme changed. The export's
+// provenance sidecar is updated with the theme and new output hashes.
+func Export(ctx context.Context, req GenerateRequest) (*GenerateResult, error) {
+	startedAt := time.Now()
+	result := &GenerateResult{
+		JSONPath: filepath.Join(req.AppDir, "resume.json"),
+		PDFPath:  filepath.Join(req.AppDir, "resume.pdf"),
+	}
+	if _, err := os.Stat(result.JSONPath); err != nil {
+		return nil, fmt.Errorf("no resume.json to export in %s. Run 'm2cv generate %s' first", req.AppDir, filepath.Base(req.AppDir))
+	}
+
+	exportInputs, err := exportFingerprint(req, result.JSONPath)
+	if err != nil {
+		return nil, err
+	}
+	if err := exportPDF(ctx, req, result.JSONPath, result.PDFPath); err != nil {
+		return nil, err
+	}
+
+	// Keep the conversion's inputs and prompt; only the export changed
+	meta, err := provenance.Read(result.PDFPath)
+	if err != nil {
+		meta = &provenance.Metadata{Command: "generate", Model: resolveProfile(req.Models, "generate", ConvertPrompt, req.Model).Model}
+	}
+	meta.ToolVersion, meta.ToolCommit = req.ToolVersion, req.ToolCommit
+	meta.Theme = req.Theme
+	meta.SetStep(StepExport, exportInputs)
+	meta.Outputs = nil
+	meta.StartedAt, meta.CreatedAt = startedAt.UTC(), time.Time{}
+	if in, ok := meta.Input(provenance.RoleOptimizedCV); ok {
+		result.CVPath = in.Path
+	}
+	if err := recordOutputs(meta, result.PDFPath, result.JSONPath, result.PDFPath); err != nil {
+		result.Warnings = append(result.Warnings, fmt.Sprintf("failed to record provenance: %v", err))
+	}
+
+	return result, nil
+}
+
+// exportPDF renders jsonPath to pdfPath with resumed in the request's
+// theme and theme options. The PDF is written to a temp file next to
+// pdfPath and renamed into place, so a cancelled or failed export never
+// leaves a truncated resume.pdf behind.
+func exportPDF(ctx context.Context, req GenerateRequest, jsonPath, pdfPath string) error {
+	exporter, err := NewExporter()
+	if err != nil {
+		return fmt.Errorf("failed to initialize exporter: %w", err)
+	}
+
+	style, err := themepkg.LoadStyle(req.ProjectDir, req.ThemeOptions, req.AppDir)
+	if err != nil {
+		return fmt.Errorf("failed to load theme options: %w", err)
+	}
+	theme, cleanup, err := style.Wrap(req.ProjectDir, req.Theme)
+	if err != nil {
+		return fmt.Errorf("failed to apply theme options: %w", err)
+	}
+	defer cleanup()
+
+	tmp, err := os.CreateTemp(filepath.Dir(pdfPath), ".resume-*.pdf")
+	if err != nil {
+		return fmt.Errorf("failed to create temp PDF: %w", err)
+	}
+	tmpPath := tmp.Name()
+	tmp.Close()
+	defer os.Remove(tmpPath)
+
+	// resumed resolves paths against projectDir, so pass absolute ones
+	absJSON, err := filepath.Abs(jsonPath)
+	if err != nil {
+		return fmt.Errorf("failed to resolve %s: %w", jsonPath, err)
+	}
+	absTmp, err := filepath.Abs(tmpPath)
+	if err != nil {
+		return fmt.Errorf("failed to resolve %s: %w", tmpPath, err)
+	}
+
+	if err := exporter.ExportPDF(ctx, absJSON, absTmp, theme, req.ProjectDir); err != nil {
+		return fmt.Errorf("failed to export PDF: %w", err)
+	}
+	if err := os.Rename(tmpPath, pdfPath); err != nil {
+		return fmt.Errorf("failed to write %s: %w", pdfPath, err)
+	}
+	return nil
+}
+
+// recordOutputs hashes outputs into meta and writes the sidecar for artifactPath.
+func recordOutputs(meta *provenance.Metadata, artifactPath string, outputs ...string) error {
+	for _, output := range outputs {
+		if err := meta.AddOutput(output); err != nil {
+			return err
+		}
+	}
+	return provenance.Write(artifactPath, meta)
+}
@@ -0,0 +1,158 @@
+package generator
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/richq/m2cv/internal/executor"
+	"github.com/richq/m2cv/internal/provenance"
+	"github.com/richq/m2cv/internal/usage"
+)
+
+// stubExecutor returns a canned response and records the prompt it received.
+type stubExecutor struct {
+	output string
+	err    error
+	prompt string
+}
+
+func (s *stubExecutor) Execute(ctx context.Context, prompt string, opts ...executor.ExecuteOption) (string, error) {
+	s.prompt = prompt
+	return s.output, s.err
+}
+
+func (s *stubExecutor) ExecuteInteractive(ctx context.Context, cfg executor.InteractiveConfig) error {
+	return nil
+}
+
+func TestGenerate_NoOptimizedCV(t *testing.T) {
+	_, err := Generate(context.Background(), GenerateRequest{AppDir: t.TempDir(), Executor: &stubExecutor{}})
+	if !errors.Is(err, ErrNoOptimizedCV) {
+		t.Errorf("Generate() error = %v, want ErrNoOptimizedCV", err)
+	}
+}
+
+func TestGenerate_ConversionFailure(t *testing.T) {
+	appDir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(appDir, "optimized-cv-1.md"), []byte("# My CV"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	stub := &stubExecutor{err: errors.New("claude down")}
+	_, err := Generate(context.Background(), GenerateRequest{AppDir: appDir, Executor: stub})
+	if err == nil || !strings.Contains(err.Error(), "failed to convert CV to JSON Resume") {
+		t.Errorf("Generate() error = %v", err)
+	}
+	if !strings.Contains(stub.prompt, "# My CV") {
+		t.Error("prompt should contain the latest optimized CV")
+	}
+}
+
+func TestGenerate_InvalidJSONStillRecordsUsage(t *testing.T) {
+	appDir := t.TempDir()
+	cvPath := filepath.Join(appDir, "optimized-cv-2.md")
+	if err := os.WriteFile(cvPath, []byte("# Explicit"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	stub := &stubExecutor{output: "sorry, no JSON here"}
+	_, err := Generate(context.Background(), GenerateRequest{AppDir: appDir, CVPath: cvPath, Executor: stub})
+	if err == nil || !strings.Contains(err.Error(), "failed to extract JSON") {
+		t.Errorf("Generate() error = %v", err)
+	}
+
+	records, err := usage.Load(appDir)
+	if err != nil {
+		t.Fatalf("usage.Load() error = %v", err)
+	}
+	if len(records) != 1 || records[0].Command != "generate" {
+		t.Errorf("expected one generate usage record, got %+v", records)
+	}
+}
+
+func TestExport_NoResumeJSON(t *testing.T) {
+	appDir := filepath.Join(t.TempDir(), "acme")
+	if err := os.MkdirAll(appDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	_, err := Export(context.Background(), GenerateRequest{AppDir: appDir, Theme: "even"})
+	if err == nil || !strings.Contains(err.Error(), "Run 'm2cv generate acme' first") {
+		t.Errorf("Export() error = %v", err)
+	}
+}
+
+func TestConvert_WritesJSONAndProvenance(t *testing.T) {
+	appDir := t.TempDir()
+	cvPath := filepath.Join(appDir, "optimized-cv-1.md")
+	if err := os.WriteFile(cvPath, []byte("# Jane"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	stub := &stubExecutor{output: "```json\n{\"basics\": {\"name\": \"Jane\"}}\n```"}
+	result, err := Convert(context.Background(), GenerateRequest{AppDir: appDir, Executor: stub})
+	if err != nil {
+		t.Fatalf("Convert() error = %v", err)
+	}
+	if result.PDFPath != "" {
+		t.Errorf("Convert should not export, got PDFPath %q", result.PDFPath)
+	}
+
+	content, err := os.ReadFile(result.JSONPath)
+	if err != nil || !strings.Contains(string(content), "Jane") {
+		t.Fatalf("resume.json = %q, %v", content, err)
+	}
+
+	meta, err := provenance.Read(result.JSONPath)
+	if err != nil {
+		t.Fatalf("provenance.Read() error = %v", err)
+	}
+	in, ok := meta.Input(provenance.RoleOptimizedCV)
+	if !ok || in.Path != cvPath {
+		t.Errorf("optimized CV input = %+v", in)
+	}
+	if len(meta.Outputs) != 1 || meta.Outputs[0].Path != result.JSONPath {
+		t.Errorf("outputs = %+v", meta.Outputs)
+	}
+}
+
+func TestConvert_KeepsExportStep(t *testing.T) {
+	appDir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(appDir, "optimized-cv-1.md"), []byte("# Jane"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	jsonPath := filepath.Join(appDir, "resume.json")
+	pdfPath := filepath.Join(appDir, "resume.pdf")
+	exported := &provenance.Metadata{
+		Command: "generate",
+		Theme:   "even",
+		Outputs: []provenance.File{{Path: jsonPath, SHA256: "old-json"}, {Path: pdfPath, SHA256: "old-pdf"}},
+	}
+	exported.SetStep(StepExport, provenance.Fingerprint{"resume": "old-json", "theme": "even"})
+	if err := provenance.Write(pdfPath, exported); err != nil {
+		t.Fatal(err)
+	}
+
+	stub := &stubExecutor{output: "```json\n{\"basics\": {\"name\": \"Jane\"}}\n```"}
+	result, err := Convert(context.Background(), GenerateRequest{AppDir: appDir, Executor: stub})
+	if err != nil {
+		t.Fatalf("Convert() error = %v", err)
+	}
+
+	meta, err := provenance.Read(pdfPath)
+	if err != nil {
+		t.Fatalf("provenance.Read() error = %v", err)
+	}
+	if meta.Steps[StepExport]["resume"] != "old-json" || meta.Theme != "even" {
+		t.Errorf("export step = %v, theme = %q, want the previous export", meta.Steps[StepExport], meta.Theme)
+	}
+	if _, ok := meta.Steps[StepConvert]; !ok {
+		t.Error("convert step not recorded")
+	}
+	if len(meta.Outputs) != 2 || meta.Outputs[0].Path != result.JSONPath || meta.Outputs[1] != exported.Outputs[1] {
+		t.Errorf("outputs = %+v", meta.Outputs)
+	}
+}
@@ -0,0 +1,85 @@
+package generator
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/richq/m2cv/internal/application"
+	"github.com/richq/m2cv/internal/diff"
+	"github.com/richq/m2cv/internal/provenance"
+)
+
+// ErrNoRebaseBase is returned when the Claude output an edited CV started
+// from can't be found, so a rebase has nothing to merge against.
+var ErrNoRebaseBase = errors.New("previous optimization output not found")
+
+// rebaseInputs are the three sides of a rebase.
+type rebaseInputs struct {
+	// basePath is the version Claude last wrote, and base its output.
+	basePath string
+	base     []byte
+	// oursPath is the latest version, including manual edits.
+	oursPath string
+	ours     []byte
+}
+
+// findRebaseInputs finds the latest version of appDir and the Claude
+// output it started from: the newest version with a provenance record,
+// read from its snapshot in projectDir, or from the file itself if it was
+// not edited since.
+func findRebaseInputs(appDir, projectDir string) (*rebaseInputs, error) {
+	versions, err := application.ListVersions(appDir)
+	if err != nil {
+		return nil, err
+	}
+	if len(versions) == 0 {
+		return nil, fmt.Errorf("%w in %s: nothing to rebase. Run 'm2cv optimize %s' without --rebase", ErrNoOptimizedCV, appDir, filepath.Base(appDir))
+	}
+
+	in := &rebaseInputs{oursPath: application.VersionPath(appDir, versions[len(versions)-1])}
+	if in.ours, err = os.ReadFile(in.oursPath); err != nil {
+		return nil, fmt.Errorf("failed to read optimized CV at %s: %w", in.oursPath, err)
+	}
+
+	for i := len(versions) - 1; i >= 0; i-- {
+		path := application.VersionPath(appDir, versions[i])
+		meta, err := provenance.Read(path)
+		if err != nil || meta.ModelOutput() == "" {
+			continue
+		}
+		in.basePath = path
+
+		sum := meta.ModelOutput()
+		if projectDir != "" {
+			in.base, err = provenance.LoadSnapshot(projectDir, sum)
+			if err == nil {
+				return in, nil
+			}
+			if !errors.Is(err, provenance.ErrNoSnapshot) {
+				return nil, err
+			}
+		}
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read optimized CV at %s: %w", path, err)
+		}
+		if provenance.HashBytes(content) != sum {
+			return nil, fmt.Errorf("%w: %s was edited and its original output was not kept", ErrNoRebaseBase, filepath.Base(path))
+		}
+		in.base = content
+		return in, nil
+	}
+	return nil, fmt.Errorf("%w: no version in %s has a provenance record", ErrNoRebaseBase, appDir)
+}
+
+// merge combines the manual edits (base to ours) with the fresh
+// optimization theirs.
+func (in *rebaseInputs) merge(theirs string) diff.MergeResult {
+	return diff.Merge(string(in.base), string(in.ours), theirs, diff.MergeLabels{
+		Base:   filepath.Base(in.basePath) + " (previous optimization)",
+		Ours:   filepath.Base(in.oursPath) + " (edited)",
+		Theirs: "fresh optimization",
+	})
+}
@@ -0,0 +1,125 @@
+package generator
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/richq/m2cv/internal/application"
+	"github.com/richq/m2cv/internal/provenance"
+)
+
+const rebaseFirstOutput = `# Experience
+
+- Built the billing service
+- Cut deploy time by half
+`
+
+func TestOptimize_Rebase(t *testing.T) {
+	baseCVPath, appDir := newOptimizeFixture(t)
+	projectDir := filepath.Dir(baseCVPath)
+
+	first, err := Optimize(context.Background(), OptimizeRequest{
+		AppDir:     appDir,
+		BaseCVPath: baseCVPath,
+		ProjectDir: projectDir,
+		Executor:   &stubExecutor{output: rebaseFirstOutput},
+	})
+	if err != nil {
+		t.Fatalf("Optimize() error = %v", err)
+	}
+
+	// Edit the first bullet by hand, in place; the fresh run changes the second
+	edited := strings.Replace(rebaseFirstOutput, "billing service", "billing service in Go", 1)
+	if err := os.WriteFile(first.OutputPath, []byte(edited), 0644); err != nil {
+		t.Fatal(err)
+	}
+	fresh := strings.Replace(rebaseFirstOutput, "by half", "from 20 to 10 minutes", 1)
+
+	result, err := Optimize(context.Background(), OptimizeRequest{
+		AppDir:     appDir,
+		BaseCVPath: baseCVPath,
+		ProjectDir: projectDir,
+		Rebase:     true,
+		Executor:   &stubExecutor{output: fresh},
+	})
+	if err != nil {
+		t.Fatalf("Optimize(Rebase) error = %v", err)
+	}
+	if result.OutputPath != application.VersionPath(appDir, 2) || result.Conflicts != 0 {
+		t.Errorf("result = %+v", result)
+	}
+	got, _ := os.ReadFile(result.OutputPath)
+	want := "# Experience\n\n- Built the billing service in Go\n- Cut deploy time from 20 to 10 minutes\n"
+	if string(got) != want {
+		t.Errorf("rebased CV =\n%s\nwant\n%s", got, want)
+	}
+
+	meta, err := provenance.Read(result.OutputPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if meta.ModelOutput() != provenance.HashBytes([]byte(fresh)) {
+		t.Error("provenance should record the fresh output as the model output")
+	}
+	if ours, ok := meta.Input(provenance.RoleOptimizedCV); !ok || ours.Path != first.OutputPath {
+		t.Errorf("edited version not recorded as input: %+v", meta.Inputs)
+	}
+	if _, err := provenance.LoadSnapshot(projectDir, provenance.HashBytes([]byte(fresh))); err != nil {
+		t.Errorf("fresh output not snapshotted: %v", err)
+	}
+}
+
+func TestOptimize_RebaseErrors(t *testing.T) {
+	baseCVPath, appDir := newOptimizeFixture(t)
+	stub := &stubExecutor{output: "# Tailored CV"}
+
+	_, err := Optimize(context.Background(), OptimizeRequest{AppDir: appDir, BaseCVPath: baseCVPath, Rebase: true, Executor: stub})
+	if !errors.Is(err, ErrNoOptimizedCV) {
+		t.Errorf("no versions error = %v", err)
+	}
+
+	// Edited in place with no snapshot of the original output
+	first, err := Optimize(context.Background(), OptimizeRequest{AppDir: appDir, BaseCVPath: baseCVPath, Executor: stub})
+	if err != nil {
+		t.Fatal(err)
+	}
+	os.WriteFile(first.OutputPath, []byte("# Edited CV"), 0644)
+	unused := &stubExecutor{}
+	_, err = Optimize(context.Background(), OptimizeRequest{AppDir: appDir, BaseCVPath: baseCVPath, Rebase: true, Executor: unused})
+	if !errors.Is(err, ErrNoRebaseBase) {
+		t.Errorf("edited without snapshot error = %v", err)
+	}
+	if unused.prompt != "" {
+		t.Error("Claude should not run when there is nothing to merge against")
+	}
+	if versions, _ := application.ListVersions(appDir); len(versions) != 1 {
+		t.Errorf("failed rebase should not write a version, got %v", versions)
+	}
+}
+
+func TestFindRebaseInputs_HandWrittenVersion(t *testing.T) {
+	baseCVPath, appDir := newOptimizeFixture(t)
+
+	// Version 1 from Claude, untouched; version 2 written by hand
+	first, err := Optimize(context.Background(), OptimizeRequest{AppDir: appDir, BaseCVPath: baseCVPath, Executor: &stubExecutor{output: rebaseFirstOutput}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	second := application.VersionPath(appDir, 2)
+	os.WriteFile(second, []byte("# Hand written"), 0644)
+
+	in, err := findRebaseInputs(appDir, "")
+	if err != nil {
+		t.Fatalf("findRebaseInputs() error = %v", err)
+	}
+	if in.basePath != first.OutputPath || string(in.base) != rebaseFirstOutput {
+		t.Errorf("base = %s %q", in.basePath, in.base)
+	}
+	if in.oursPath != second || string(in.ours) != "# Hand written" {
+		t.Errorf("ours = %s %q", in.oursPath, in.ours)
+	}
+}
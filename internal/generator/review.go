@@ -0,0 +1,84 @@
+package generator
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/richq/m2cv/internal/application"
+	"github.com/richq/m2cv/internal/provenance"
+)
+
+// ReviewRequest describes the result of reviewing the changes between two
+// optimized versions.
+type ReviewRequest struct {
+	// AppDir is the application folder.
+	AppDir string
+	// FromPath and ToPath are the versions compared; the changes from
+	// FromPath to ToPath were reviewed.
+	FromPath string
+	ToPath   string
+	// Text is the reviewed CV.
+	Text string
+	// StartedAt is when the review began.
+	StartedAt time.Time
+	// ToolVersion and ToolCommit identify the m2cv build for provenance.
+	ToolVersion string
+	ToolCommit  string
+}
+
+// ReviewResult reports the version written for a review.
+type ReviewResult struct {
+	// OutputPath is the new optimized-cv-N.md.
+	OutputPath string
+	// Warnings lists non-fatal problems (provenance not recorded).
+	Warnings []string
+}
+
+// SaveReview writes the reviewed CV as the next optimized-cv-N.md with a
+// provenance sidecar. The base CV, job description and Claude output
+// recorded for ToPath carry over, so m2cv outdated and optimize --rebase
+// treat the reviewed version like the one it was reviewed from.
+func SaveReview(req ReviewRequest) (*ReviewResult, error) {
+	from, err := os.ReadFile(req.FromPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read optimized CV at %s: %w", req.FromPath, err)
+	}
+	to, err := os.ReadFile(req.ToPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read optimized CV at %s: %w", req.ToPath, err)
+	}
+
+	result := &ReviewResult{}
+	result.OutputPath, err = application.NextVersionPath(req.AppDir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to determine output path: %w", err)
+	}
+	if err := os.WriteFile(result.OutputPath, []byte(req.Text), 0644); err != nil {
+		return nil, fmt.Errorf("failed to write reviewed CV: %w", err)
+	}
+
+	meta := &provenance.Metadata{
+		Command:     "review",
+		ToolVersion: req.ToolVersion,
+		ToolCommit:  req.ToolCommit,
+		StartedAt:   req.StartedAt.UTC(),
+	}
+	// A version without a record (e.g. written by hand) has nothing to carry
+	if reviewed, err := provenance.Read(req.ToPath); err == nil {
+		for _, role := range []string{provenance.RoleBaseCV, provenance.RoleJobDescription} {
+			if f, ok := reviewed.Input(role); ok {
+				meta.Inputs = append(meta.Inputs, f)
+			}
+		}
+		meta.Model = reviewed.Model
+		meta.ATSMode = reviewed.ATSMode
+		meta.ModelOutputSHA256 = reviewed.ModelOutput()
+	}
+	meta.AddInput(provenance.RoleOptimizedCV, req.FromPath, from)
+	meta.AddInput(provenance.RoleOptimizedCV, req.ToPath, to)
+	if err := recordOutputs(meta, result.OutputPath, result.OutputPath); err != nil {
+		result.Warnings = append(result.Warnings, fmt.Sprintf("failed to record provenance: %v", err))
+	}
+	return result, nil
+}
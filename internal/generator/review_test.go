@@ -0,0 +1,61 @@
+package generator
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/richq/m2cv/internal/application"
+	"github.com/richq/m2cv/internal/provenance"
+)
+
+func TestSaveReview(t *testing.T) {
+	baseCVPath, appDir := newOptimizeFixture(t)
+
+	from := application.VersionPath(appDir, 1)
+	os.WriteFile(from, []byte("# CV v1\n"), 0644)
+	to, err := Optimize(context.Background(), OptimizeRequest{
+		AppDir:     appDir,
+		BaseCVPath: baseCVPath,
+		ATSMode:    true,
+		Executor:   &stubExecutor{output: "# CV v2\n"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	result, err := SaveReview(ReviewRequest{
+		AppDir:    appDir,
+		FromPath:  from,
+		ToPath:    to.OutputPath,
+		Text:      "# CV reviewed\n",
+		StartedAt: time.Now(),
+	})
+	if err != nil {
+		t.Fatalf("SaveReview() error = %v", err)
+	}
+	if result.OutputPath != application.VersionPath(appDir, 3) || len(result.Warnings) != 0 {
+		t.Errorf("result = %+v", result)
+	}
+	if got, _ := os.ReadFile(result.OutputPath); string(got) != "# CV reviewed\n" {
+		t.Errorf("reviewed CV = %q", got)
+	}
+
+	meta, err := provenance.Read(result.OutputPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if meta.Command != "review" || !meta.ATSMode {
+		t.Errorf("unexpected metadata: %+v", meta)
+	}
+	if _, ok := meta.Input(provenance.RoleBaseCV); !ok {
+		t.Error("base CV input should carry over from the reviewed version")
+	}
+	if meta.ModelOutput() != provenance.HashBytes([]byte("# CV v2\n")) {
+		t.Error("model output should be the reviewed version's Claude output")
+	}
+	if len(meta.Inputs) != 4 {
+		t.Errorf("inputs = %+v, want base CV, job description and both versions", meta.Inputs)
+	}
+}
@@ -66,14 +66,18 @@ func (s *Service) Init(ctx context.Context, opts InitOptions) error {
 		}
 	}
 
-	// 3. Install resumed and theme package
-	themePackage := ThemePackageName(opts.Theme)
-	if err := s.npmExecutor.Install(ctx, opts.ProjectDir, "resumed", themePackage); err != nil {
+	// 3. Install resumed and theme package (local themes are not installed)
+	packages := []string{"resumed"}
+	if themePackage := ThemePackageName(opts.Theme); themePackage != "" {
+		packages = append(packages, themePackage)
+	}
+	if err := s.npmExecutor.Install(ctx, opts.ProjectDir, packages...); err != nil {
 		return err
 	}
 
 	// 4. Create and save config
 	cfg := &config.Config{
+		Version:      config.CurrentVersion,
 		BaseCVPath:   opts.BaseCVPath,
 		DefaultTheme: opts.Theme,
 		Themes:       []string{opts.Theme},
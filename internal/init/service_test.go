@@ -69,6 +69,14 @@ func (m *mockNPMExecutor) Install(ctx context.Context, dir string, packages ...s
 	return m.installErr
 }
 
+func (m *mockNPMExecutor) Uninstall(ctx context.Context, dir string, packages ...string) error {
+	return nil
+}
+
+func (m *mockNPMExecutor) Update(ctx context.Context, dir string, packages ...string) error {
+	return nil
+}
+
 func (m *mockNPMExecutor) CheckInstalled(ctx context.Context, dir string, pkg string) (bool, error) {
 	return m.checkInstalled, m.checkErr
 }
@@ -7,6 +7,7 @@ import (
 	"slices"
 
 	"github.com/charmbracelet/huh"
+	themepkg "github.com/richq/m2cv/internal/theme"
 )
 
 // AvailableThemes lists the supported JSON Resume themes.
@@ -63,12 +64,14 @@ func SelectTheme() (string, error) {
 	return selected, nil
 }
 
-// IsValidTheme checks if the theme name is in the available list.
+// IsValidTheme checks if the theme name is in the curated list.
+// Any other theme can still be used; see the theme package.
 func IsValidTheme(theme string) bool {
 	return slices.Contains(AvailableThemes, theme)
 }
 
-// ThemePackageName returns the full npm package name for a theme.
+// ThemePackageName returns the full npm package name for a theme reference,
+// or "" for a local theme directory.
 func ThemePackageName(theme string) string {
-	return "jsonresume-theme-" + theme
+	return themepkg.Package(theme)
 }
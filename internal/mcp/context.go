@@ -1,40 +1,83 @@
-// Package mcp provides MCP server functionality for interactive CV optimization.
+// Package mcp provides MCP server functionality for interactive CV optimization
+// and for serving a whole project to MCP clients (serve-mcp).
 package mcp
 
 import (
-	"encoding/base64"
 	"encoding/json"
 	"fmt"
+	"os"
+
+	"github.com/richq/m2cv/internal/config"
 )
 
 // InteractiveContext contains all data needed by the MCP server subprocess.
 type InteractiveContext struct {
 	// ApplicationDir is the path to the application folder (e.g., "applications/acme-corp")
 	ApplicationDir string `json:"application_dir"`
+	// ProjectDir is the directory containing m2cv.yml and node_modules (for PDF export)
+	ProjectDir string `json:"project_dir,omitempty"`
+	// Theme is the JSON Resume theme used for PDF export
+	Theme string `json:"theme,omitempty"`
+	// ThemeOptions are the project's theme options for PDF export
+	ThemeOptions config.ThemeOptions `json:"theme_options,omitempty"`
 	// BaseCV is the contents of the user's base CV markdown
 	BaseCV string `json:"base_cv"`
+	// BaseCVPath is the path the base CV was read from (for provenance)
+	BaseCVPath string `json:"base_cv_path,omitempty"`
 	// JobDescription is the contents of the job description
 	JobDescription string `json:"job_description"`
+	// JobDescriptionPath is the path the job description was read from (for provenance)
+	JobDescriptionPath string `json:"job_description_path,omitempty"`
 	// ATSMode indicates whether to optimize for ATS systems
 	ATSMode bool `json:"ats_mode"`
 	// Model is the Claude model to use (may be empty for default)
 	Model string `json:"model,omitempty"`
+	// Models resolves the model for conversions run by the generate tool
+	Models config.ModelSettings `json:"models,omitempty"`
+	// PromptSHA256 is the hash of the interactive prompt template (for provenance)
+	PromptSHA256 string `json:"prompt_sha256,omitempty"`
+	// ToolVersion and ToolCommit identify the m2cv build (for provenance)
+	ToolVersion string `json:"tool_version,omitempty"`
+	ToolCommit  string `json:"tool_commit,omitempty"`
 }
 
-// Encode serializes the context to a base64-encoded JSON string.
-func (c *InteractiveContext) Encode() (string, error) {
+// WriteContextFile writes the context to a new private file (mode 0600) in
+// dir, or the system temp directory when dir is empty, and returns its path.
+// Passing the path rather than the content keeps the CV out of argv, where
+// it would be visible in ps output and subject to argument size limits.
+// The caller is responsible for removing the file when the session ends.
+func (c *InteractiveContext) WriteContextFile(dir string) (string, error) {
 	data, err := json.Marshal(c)
 	if err != nil {
 		return "", fmt.Errorf("failed to marshal context: %w", err)
 	}
-	return base64.StdEncoding.EncodeToString(data), nil
+
+	f, err := os.CreateTemp(dir, "m2cv-context-*.json")
+	if err != nil {
+		return "", fmt.Errorf("failed to create context file: %w", err)
+	}
+	path := f.Name()
+
+	// CreateTemp already uses 0600; enforce it in case of an unusual umask or platform
+	err = f.Chmod(0600)
+	if err == nil {
+		_, err = f.Write(data)
+	}
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		os.Remove(path)
+		return "", fmt.Errorf("failed to write context file: %w", err)
+	}
+	return path, nil
 }
 
-// DecodeContext deserializes a base64-encoded JSON string into an InteractiveContext.
-func DecodeContext(encoded string) (*InteractiveContext, error) {
-	data, err := base64.StdEncoding.DecodeString(encoded)
+// LoadContextFile reads a context written by WriteContextFile.
+func LoadContextFile(path string) (*InteractiveContext, error) {
+	data, err := os.ReadFile(path)
 	if err != nil {
-		return nil, fmt.Errorf("failed to decode base64: %w", err)
+		return nil, fmt.Errorf("failed to read context file: %w", err)
 	}
 
 	var ctx InteractiveContext
@@ -1,10 +1,13 @@
 package mcp
 
 import (
+	"os"
+	"path/filepath"
+	"runtime"
 	"testing"
 )
 
-func TestInteractiveContextEncodeDecode(t *testing.T) {
+func TestInteractiveContextWriteLoad(t *testing.T) {
 	original := &InteractiveContext{
 		ApplicationDir: "applications/test-job",
 		BaseCV:         "# John Doe\n\nSoftware Engineer",
@@ -13,50 +16,59 @@ func TestInteractiveContextEncodeDecode(t *testing.T) {
 		Model:          "claude-sonnet-4-20250514",
 	}
 
-	// Encode
-	encoded, err := original.Encode()
+	// Write
+	path, err := original.WriteContextFile(t.TempDir())
 	if err != nil {
-		t.Fatalf("Encode failed: %v", err)
+		t.Fatalf("WriteContextFile failed: %v", err)
 	}
 
-	if encoded == "" {
-		t.Fatal("Encoded string is empty")
+	if runtime.GOOS != "windows" {
+		info, err := os.Stat(path)
+		if err != nil {
+			t.Fatalf("Stat failed: %v", err)
+		}
+		if perm := info.Mode().Perm(); perm != 0600 {
+			t.Errorf("context file mode = %o, want 600", perm)
+		}
 	}
 
-	// Decode
-	decoded, err := DecodeContext(encoded)
+	// Load
+	loaded, err := LoadContextFile(path)
 	if err != nil {
-		t.Fatalf("DecodeContext failed: %v", err)
+		t.Fatalf("LoadContextFile failed: %v", err)
 	}
 
 	// Verify fields
-	if decoded.ApplicationDir != original.ApplicationDir {
-		t.Errorf("ApplicationDir mismatch: got %q, want %q", decoded.ApplicationDir, original.ApplicationDir)
+	if loaded.ApplicationDir != original.ApplicationDir {
+		t.Errorf("ApplicationDir mismatch: got %q, want %q", loaded.ApplicationDir, original.ApplicationDir)
 	}
-	if decoded.BaseCV != original.BaseCV {
-		t.Errorf("BaseCV mismatch: got %q, want %q", decoded.BaseCV, original.BaseCV)
+	if loaded.BaseCV != original.BaseCV {
+		t.Errorf("BaseCV mismatch: got %q, want %q", loaded.BaseCV, original.BaseCV)
 	}
-	if decoded.JobDescription != original.JobDescription {
-		t.Errorf("JobDescription mismatch: got %q, want %q", decoded.JobDescription, original.JobDescription)
+	if loaded.JobDescription != original.JobDescription {
+		t.Errorf("JobDescription mismatch: got %q, want %q", loaded.JobDescription, original.JobDescription)
 	}
-	if decoded.ATSMode != original.ATSMode {
-		t.Errorf("ATSMode mismatch: got %v, want %v", decoded.ATSMode, original.ATSMode)
+	if loaded.ATSMode != original.ATSMode {
+		t.Errorf("ATSMode mismatch: got %v, want %v", loaded.ATSMode, original.ATSMode)
 	}
-	if decoded.Model != original.Model {
-		t.Errorf("Model mismatch: got %q, want %q", decoded.Model, original.Model)
+	if loaded.Model != original.Model {
+		t.Errorf("Model mismatch: got %q, want %q", loaded.Model, original.Model)
 	}
 }
 
-func TestDecodeContextInvalidBase64(t *testing.T) {
-	_, err := DecodeContext("not-valid-base64!!!")
+func TestLoadContextFileMissing(t *testing.T) {
+	_, err := LoadContextFile(filepath.Join(t.TempDir(), "missing.json"))
 	if err == nil {
-		t.Error("Expected error for invalid base64, got nil")
+		t.Error("Expected error for missing file, got nil")
 	}
 }
 
-func TestDecodeContextInvalidJSON(t *testing.T) {
-	// Valid base64 but invalid JSON
-	_, err := DecodeContext("bm90LWpzb24=") // "not-json"
+func TestLoadContextFileInvalidJSON(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "context.json")
+	if err := os.WriteFile(path, []byte("not-json"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	_, err := LoadContextFile(path)
 	if err == nil {
 		t.Error("Expected error for invalid JSON, got nil")
 	}
@@ -0,0 +1,133 @@
+package mcp
+
+import (
+	"context"
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// shutdownTimeout bounds how long graceful shutdown waits for in-flight requests.
+const shutdownTimeout = 5 * time.Second
+
+// HTTPConfig configures the SSE transport.
+type HTTPConfig struct {
+	// Addr is the bind address, e.g. "127.0.0.1:8808" (":0" picks a free port).
+	Addr string
+	// BaseURL, when set, is the externally reachable URL clients use, e.g.
+	// "https://cv.example.com" behind a reverse proxy. By default it is the
+	// listener address for a specific host; a wildcard bind (":8808",
+	// "0.0.0.0:8808") has no such address, so clients are sent a relative
+	// message endpoint resolved against the URL they connected to.
+	BaseURL string
+	// Token, when set, is required as "Authorization: Bearer <token>" on every request.
+	Token string
+	// Ready, when set, is called with the SSE endpoint URL once the listener is up.
+	Ready func(endpoint string)
+}
+
+// ServeHTTP serves the MCP server over HTTP with server-sent events until ctx
+// is cancelled, then shuts down gracefully. Any number of clients may connect;
+// each gets its own session with the same tools, resources and prompts.
+func (s *Server) ServeHTTP(ctx context.Context, cfg HTTPConfig) error {
+	if cfg.BaseURL != "" {
+		if err := checkBaseURL(cfg.BaseURL); err != nil {
+			return err
+		}
+	}
+	ln, err := net.Listen("tcp", cfg.Addr)
+	if err != nil {
+		return fmt.Errorf("failed to listen on %s: %w", cfg.Addr, err)
+	}
+	baseURL := cfg.BaseURL
+	if baseURL == "" {
+		baseURL = listenerURL(ln.Addr())
+	}
+
+	// SSE streams only end when their request context does, so every request
+	// derives from a base context that is cancelled on shutdown
+	baseCtx, cancelRequests := context.WithCancel(context.Background())
+	defer cancelRequests()
+
+	sseServer := server.NewSSEServer(s.mcpServer, server.WithBaseURL(baseURL))
+	httpServer := &http.Server{
+		Handler:           requireBearer(cfg.Token, sseServer),
+		ReadHeaderTimeout: 10 * time.Second,
+		BaseContext:       func(net.Listener) context.Context { return baseCtx },
+	}
+
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- httpServer.Serve(ln)
+	}()
+	if cfg.Ready != nil {
+		endpoint := sseServer.CompleteSseEndpoint()
+		if baseURL == "" {
+			endpoint = "http://" + net.JoinHostPort("localhost", fmt.Sprint(ln.Addr().(*net.TCPAddr).Port)) + endpoint
+		}
+		cfg.Ready(endpoint)
+	}
+
+	select {
+	case err := <-errCh:
+		return fmt.Errorf("MCP HTTP server failed: %w", err)
+	case <-ctx.Done():
+	}
+
+	// Close client streams (and abort in-flight tool calls), then wait for
+	// handlers to return
+	cancelRequests()
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+	if err := httpServer.Shutdown(shutdownCtx); err != nil {
+		return fmt.Errorf("failed to shut down MCP HTTP server: %w", err)
+	}
+	if err := <-errCh; err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return fmt.Errorf("MCP HTTP server failed: %w", err)
+	}
+	return nil
+}
+
+// checkBaseURL rejects base URLs the SSE server would silently ignore.
+func checkBaseURL(baseURL string) error {
+	u, err := url.Parse(baseURL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" || u.RawQuery != "" {
+		return fmt.Errorf("invalid base URL %q: want http(s)://host[:port][/path]", baseURL)
+	}
+	return nil
+}
+
+// listenerURL returns the base URL for a listener, or "" for a wildcard
+// bind, whose address ("[::]:8808") no client can reach; the message
+// endpoint then stays relative to the URL the client connected to.
+func listenerURL(addr net.Addr) string {
+	if tcp, ok := addr.(*net.TCPAddr); ok && tcp.IP.IsUnspecified() {
+		return ""
+	}
+	return "http://" + addr.String()
+}
+
+// requireBearer rejects requests without the expected bearer token.
+// An empty token disables the check.
+func requireBearer(token string, next http.Handler) http.Handler {
+	if token == "" {
+		return next
+	}
+	want := []byte("Bearer " + token)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got := []byte(strings.TrimSpace(r.Header.Get("Authorization")))
+		if subtle.ConstantTimeCompare(got, want) != 1 {
+			w.Header().Set("WWW-Authenticate", `Bearer realm="m2cv"`)
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
@@ -0,0 +1,209 @@
+package mcp
+
+import (
+	"bufio"
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRequireBearer(t *testing.T) {
+	ok := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) { w.WriteHeader(http.StatusNoContent) })
+
+	tests := []struct {
+		token, header string
+		want          int
+	}{
+		{"", "", http.StatusNoContent},
+		{"s3cret", "Bearer s3cret", http.StatusNoContent},
+		{"s3cret", "", http.StatusUnauthorized},
+		{"s3cret", "Bearer wrong", http.StatusUnauthorized},
+		{"s3cret", "s3cret", http.StatusUnauthorized},
+	}
+	for _, tt := range tests {
+		req, _ := http.NewRequest(http.MethodGet, "/sse", nil)
+		if tt.header != "" {
+			req.Header.Set("Authorization", tt.header)
+		}
+		rec := &statusRecorder{header: http.Header{}}
+		requireBearer(tt.token, ok).ServeHTTP(rec, req)
+		if rec.status != tt.want {
+			t.Errorf("token=%q header=%q: status %d, want %d", tt.token, tt.header, rec.status, tt.want)
+		}
+	}
+}
+
+// statusRecorder is a minimal ResponseWriter that records the status code.
+type statusRecorder struct {
+	header http.Header
+	status int
+}
+
+func (r *statusRecorder) Header() http.Header         { return r.header }
+func (r *statusRecorder) Write(b []byte) (int, error) { return len(b), nil }
+func (r *statusRecorder) WriteHeader(status int)      { r.status = status }
+
+func TestServeHTTP_SessionAndShutdown(t *testing.T) {
+	pctx := newProjectContext(t, map[string][]string{"acme": {"# v1"}})
+	s := NewProjectServer(pctx)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	ready := make(chan string, 1)
+	done := make(chan error, 1)
+	go func() {
+		done <- s.ServeHTTP(ctx, HTTPConfig{
+			Addr:  "127.0.0.1:0",
+			Token: "s3cret",
+			Ready: func(endpoint string) { ready <- endpoint },
+		})
+	}()
+
+	var endpoint string
+	select {
+	case endpoint = <-ready:
+	case err := <-done:
+		t.Fatalf("ServeHTTP() returned early: %v", err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("server did not become ready")
+	}
+
+	// Unauthenticated clients are rejected
+	resp, err := http.Get(endpoint)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusUnauthorized {
+		t.Errorf("unauthenticated status = %d", resp.StatusCode)
+	}
+
+	// An authenticated client gets an SSE stream announcing its message endpoint
+	req, _ := http.NewRequest(http.MethodGet, endpoint, nil)
+	req.Header.Set("Authorization", "Bearer s3cret")
+	stream, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer stream.Body.Close()
+
+	messageURL := readEndpointEvent(t, stream.Body)
+
+	body := `{"jsonrpc":"2.0","id":1,"method":"tools/list"}`
+	post, _ := http.NewRequest(http.MethodPost, messageURL, strings.NewReader(body))
+	post.Header.Set("Authorization", "Bearer s3cret")
+	post.Header.Set("Content-Type", "application/json")
+	resp, err = http.DefaultClient.Do(post)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var out strings.Builder
+	bufio.NewReader(resp.Body).WriteTo(&out)
+	resp.Body.Close()
+	if !strings.Contains(out.String(), "list_applications") {
+		t.Errorf("tools/list over HTTP missing project tools: %s", out.String())
+	}
+
+	// Cancelling the context shuts down gracefully, even with a client connected
+	cancel()
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Errorf("ServeHTTP() error = %v", err)
+		}
+	case <-time.After(10 * time.Second):
+		t.Fatal("server did not shut down")
+	}
+}
+
+// readEndpointEvent returns the message endpoint announced on an SSE stream.
+func readEndpointEvent(t *testing.T, body io.Reader) string {
+	t.Helper()
+	reader := bufio.NewReader(body)
+	for {
+		line, err := reader.ReadString('\n')
+		if err != nil {
+			t.Fatalf("reading SSE stream: %v", err)
+		}
+		if strings.HasPrefix(line, "data: ") {
+			return strings.TrimSpace(strings.TrimPrefix(line, "data: "))
+		}
+	}
+}
+
+// startHTTP serves s with cfg and returns the ready endpoint; the server
+// stops when the test ends.
+func startHTTP(t *testing.T, s *Server, cfg HTTPConfig) string {
+	t.Helper()
+	ctx, cancel := context.WithCancel(context.Background())
+	ready := make(chan string, 1)
+	done := make(chan error, 1)
+	cfg.Ready = func(endpoint string) { ready <- endpoint }
+	go func() { done <- s.ServeHTTP(ctx, cfg) }()
+	t.Cleanup(func() {
+		cancel()
+		<-done
+	})
+
+	select {
+	case endpoint := <-ready:
+		return endpoint
+	case err := <-done:
+		t.Fatalf("ServeHTTP() returned early: %v", err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("server did not become ready")
+	}
+	return ""
+}
+
+func TestServeHTTP_WildcardBind(t *testing.T) {
+	s := NewProjectServer(newProjectContext(t, map[string][]string{"acme": {"# v1"}}))
+	endpoint := startHTTP(t, s, HTTPConfig{Addr: ":0"})
+	if !strings.HasPrefix(endpoint, "http://localhost:") {
+		t.Errorf("ready endpoint = %q, want a localhost URL", endpoint)
+	}
+
+	stream, err := http.Get(endpoint)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer stream.Body.Close()
+
+	// The unreachable wildcard address must not leak into the endpoint; a
+	// relative one resolves against whatever host the client used
+	messageURL := readEndpointEvent(t, stream.Body)
+	if !strings.HasPrefix(messageURL, "/message?sessionId=") {
+		t.Fatalf("endpoint event = %q, want a relative message endpoint", messageURL)
+	}
+	resolved, err := stream.Request.URL.Parse(messageURL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	body := `{"jsonrpc":"2.0","id":1,"method":"ping"}`
+	resp, err := http.Post(resolved.String(), "application/json", strings.NewReader(body))
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		t.Errorf("message post status = %d", resp.StatusCode)
+	}
+}
+
+func TestServeHTTP_BaseURL(t *testing.T) {
+	s := NewProjectServer(newProjectContext(t, map[string][]string{"acme": {"# v1"}}))
+	endpoint := startHTTP(t, s, HTTPConfig{Addr: "127.0.0.1:0", BaseURL: "https://cv.example.com"})
+	if endpoint != "https://cv.example.com/sse" {
+		t.Errorf("ready endpoint = %q", endpoint)
+	}
+
+	for _, bad := range []string{"cv.example.com", "ftp://cv.example.com", "https://cv.example.com?x=1"} {
+		if err := s.ServeHTTP(context.Background(), HTTPConfig{Addr: "127.0.0.1:0", BaseURL: bad}); err == nil {
+			t.Errorf("ServeHTTP(BaseURL: %q) succeeded, want error", bad)
+		}
+	}
+}
@@ -0,0 +1,131 @@
+package mcp
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/richq/m2cv/internal/application"
+	"github.com/richq/m2cv/internal/config"
+	"github.com/richq/m2cv/internal/executor"
+)
+
+// ProjectContext describes the m2cv project exposed by serve-mcp. Unlike
+// InteractiveContext it is not tied to one application: every folder under
+// ApplicationsDir is reachable through tool arguments and resource URIs.
+type ProjectContext struct {
+	// ProjectDir is the directory containing m2cv.yml and node_modules
+	ProjectDir string
+	// ApplicationsDir holds one folder per job application
+	ApplicationsDir string
+	// BaseCVPath is the resolved path of the base CV markdown
+	BaseCVPath string
+	// Theme is the default JSON Resume theme for PDF export
+	Theme string
+	// ThemeOptions are the project's theme options for PDF export
+	ThemeOptions config.ThemeOptions
+	// Models resolves the Claude model and parameters per command
+	Models config.ModelSettings
+	// ForApplication, when set, returns the context in effect for one
+	// application folder: the project's config layered with the folder's
+	// application.yml. Only its BaseCVPath, Theme, ThemeOptions and Models
+	// are used. Nil applies the fields above to every application.
+	ForApplication func(appDir string) (*ProjectContext, error)
+	// Executor runs Claude for the optimize tool. Defaults to NewClaudeExecutor().
+	Executor executor.ClaudeExecutor
+	// ToolVersion and ToolCommit identify the m2cv build (for provenance)
+	ToolVersion string
+	ToolCommit  string
+}
+
+// appDir validates an application name and returns its folder. Names must
+// be a single path element so clients cannot escape the applications directory.
+func (p *ProjectContext) appDir(name string) (string, error) {
+	if name == "" {
+		return "", fmt.Errorf("application name is required")
+	}
+	if name != filepath.Base(name) || name == "." || name == ".." {
+		return "", fmt.Errorf("invalid application name: %q", name)
+	}
+
+	dir := filepath.Join(p.ApplicationsDir, name)
+	info, err := os.Stat(dir)
+	if err != nil || !info.IsDir() {
+		return "", fmt.Errorf("application not found: %s", name)
+	}
+	return dir, nil
+}
+
+// settings returns the context whose BaseCVPath, Theme, ThemeOptions and
+// Models apply to appDir.
+func (p *ProjectContext) settings(appDir string) (*ProjectContext, error) {
+	if p.ForApplication == nil {
+		return p, nil
+	}
+	return p.ForApplication(appDir)
+}
+
+// applicationContext loads the inputs of one application into an
+// InteractiveContext so the single-application tool handlers can be reused.
+func (p *ProjectContext) applicationContext(name string) (*InteractiveContext, error) {
+	appDir, err := p.appDir(name)
+	if err != nil {
+		return nil, err
+	}
+	settings, err := p.settings(appDir)
+	if err != nil {
+		return nil, err
+	}
+
+	baseCV, err := os.ReadFile(settings.BaseCVPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read base CV at %s: %w", settings.BaseCVPath, err)
+	}
+
+	jdPath, err := application.FindJobDescription(appDir)
+	if err != nil {
+		return nil, err
+	}
+	jobDescription, err := os.ReadFile(jdPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read job description at %s: %w", jdPath, err)
+	}
+
+	return &InteractiveContext{
+		ApplicationDir:     appDir,
+		ProjectDir:         p.ProjectDir,
+		Theme:              settings.Theme,
+		ThemeOptions:       settings.ThemeOptions,
+		BaseCV:             string(baseCV),
+		BaseCVPath:         settings.BaseCVPath,
+		JobDescription:     string(jobDescription),
+		JobDescriptionPath: jdPath,
+		Model:              settings.Models.Resolve("optimize", "interactive").Model,
+		Models:             settings.Models,
+		ToolVersion:        p.ToolVersion,
+		ToolCommit:         p.ToolCommit,
+	}, nil
+}
+
+// NewProjectServer creates an MCP server exposing every application in the
+// project: resources for job descriptions, CV versions and resume.json,
+// tools covering the apply/optimize/generate workflow and status updates,
+// and prompts with the tailoring templates.
+func NewProjectServer(pctx *ProjectContext) *Server {
+	mcpServer := server.NewMCPServer(
+		"m2cv",
+		"1.0.0",
+		server.WithToolCapabilities(false),
+		server.WithResourceCapabilities(false, false),
+		server.WithPromptCapabilities(false),
+	)
+
+	addProjectTools(mcpServer, pctx)
+	addProjectResources(mcpServer, pctx)
+	addProjectPrompts(mcpServer, pctx)
+
+	return &Server{
+		mcpServer: mcpServer,
+	}
+}
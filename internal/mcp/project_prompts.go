@@ -0,0 +1,106 @@
+package mcp
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/richq/m2cv/internal/assets"
+	"github.com/richq/m2cv/internal/scoring"
+)
+
+// promptHandler is the handler signature used by every m2cv prompt.
+type promptHandler = func(ctx context.Context, request mcp.GetPromptRequest) (*mcp.GetPromptResult, error)
+
+// addProjectPrompts registers the tailoring prompt templates.
+func addProjectPrompts(s *server.MCPServer, pctx *ProjectContext) {
+	s.AddPrompt(mcp.NewPrompt("tailor_cv",
+		mcp.WithPromptDescription("Tailor the base CV to an application's job description, using m2cv's optimize template"),
+		mcp.WithArgument("application",
+			mcp.RequiredArgument(),
+			mcp.ArgumentDescription("Application folder name"),
+		),
+		mcp.WithArgument("ats",
+			mcp.ArgumentDescription("Set to 'true' for the ATS-friendly template"),
+		),
+	), TailorCVPromptHandler(pctx))
+
+	s.AddPrompt(mcp.NewPrompt("improve_version",
+		mcp.WithPromptDescription("Review an optimized CV version against its job description's keyword gaps and revise it"),
+		mcp.WithArgument("application",
+			mcp.RequiredArgument(),
+			mcp.ArgumentDescription("Application folder name"),
+		),
+		mcp.WithArgument("version",
+			mcp.ArgumentDescription("Version number to review (defaults to the latest)"),
+		),
+	), ImproveVersionPromptHandler(pctx))
+}
+
+// TailorCVPromptHandler fills the optimize (or optimize-ats) template with
+// the base CV and the application's job description.
+func TailorCVPromptHandler(pctx *ProjectContext) promptHandler {
+	return func(ctx context.Context, request mcp.GetPromptRequest) (*mcp.GetPromptResult, error) {
+		name := request.Params.Arguments["application"]
+		ictx, err := pctx.applicationContext(name)
+		if err != nil {
+			return nil, err
+		}
+
+		promptName := "optimize"
+		if strings.EqualFold(request.Params.Arguments["ats"], "true") {
+			promptName = "optimize-ats"
+		}
+		template, err := assets.GetPrompt(promptName)
+		if err != nil {
+			return nil, err
+		}
+
+		text := strings.ReplaceAll(template, "{{.BaseCV}}", ictx.BaseCV)
+		text = strings.ReplaceAll(text, "{{.JobDescription}}", ictx.JobDescription)
+		text += fmt.Sprintf("\n\nWhen the tailored CV is ready, save it with the write_optimized_resume tool (application: %q).", name)
+
+		return mcp.NewGetPromptResult(
+			fmt.Sprintf("Tailor the base CV for %s", name),
+			[]mcp.PromptMessage{mcp.NewPromptMessage(mcp.RoleUser, mcp.NewTextContent(text))},
+		), nil
+	}
+}
+
+// ImproveVersionPromptHandler builds a revision prompt from a version, the
+// job description and the keywords the version is missing.
+func ImproveVersionPromptHandler(pctx *ProjectContext) promptHandler {
+	return func(ctx context.Context, request mcp.GetPromptRequest) (*mcp.GetPromptResult, error) {
+		name := request.Params.Arguments["application"]
+		ictx, err := pctx.applicationContext(name)
+		if err != nil {
+			return nil, err
+		}
+
+		path, err := resolveVersion(ictx.ApplicationDir, request.Params.Arguments["version"])
+		if err != nil {
+			return nil, err
+		}
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", path, err)
+		}
+		score := scoring.Score(string(content), ictx.JobDescription)
+
+		var b strings.Builder
+		fmt.Fprintf(&b, "Review %s for the %s application.\n\n", filepath.Base(path), name)
+		fmt.Fprintf(&b, "It covers %d%% of the job description's top keywords. Missing: %s.\n\n", score.Score, strings.Join(score.Missing, ", "))
+		b.WriteString("Suggest targeted edits that close the relevant gaps without inventing experience. ")
+		fmt.Fprintf(&b, "Once the user agrees, save the revision with the write_optimized_resume tool (application: %q).\n\n", name)
+		fmt.Fprintf(&b, "---\nCURRENT VERSION:\n%s\n\n---\nJOB DESCRIPTION:\n%s\n", content, ictx.JobDescription)
+
+		return mcp.NewGetPromptResult(
+			fmt.Sprintf("Improve %s of %s", filepath.Base(path), name),
+			[]mcp.PromptMessage{mcp.NewPromptMessage(mcp.RoleUser, mcp.NewTextContent(b.String()))},
+		), nil
+	}
+}
@@ -0,0 +1,65 @@
+package mcp
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// getPrompt invokes a prompt handler and returns the text of its message.
+func getPrompt(t *testing.T, handler promptHandler, args map[string]string) (string, error) {
+	t.Helper()
+	var request mcp.GetPromptRequest
+	request.Params.Arguments = args
+
+	result, err := handler(context.Background(), request)
+	if err != nil {
+		return "", err
+	}
+	return result.Messages[0].Content.(mcp.TextContent).Text, nil
+}
+
+func TestTailorCVPromptHandler(t *testing.T) {
+	pctx := newProjectContext(t, map[string][]string{"acme": nil})
+
+	text, err := getPrompt(t, TailorCVPromptHandler(pctx), map[string]string{"application": "acme"})
+	if err != nil {
+		t.Fatalf("tailor_cv error = %v", err)
+	}
+	for _, want := range []string{"# Base CV", "Kubernetes", `write_optimized_resume tool (application: "acme")`} {
+		if !strings.Contains(text, want) {
+			t.Errorf("prompt missing %q:\n%s", want, text)
+		}
+	}
+	if strings.Contains(text, "{{.") {
+		t.Errorf("prompt has unfilled placeholders:\n%s", text)
+	}
+
+	ats, err := getPrompt(t, TailorCVPromptHandler(pctx), map[string]string{"application": "acme", "ats": "true"})
+	if err != nil {
+		t.Fatalf("tailor_cv ats error = %v", err)
+	}
+	if ats == text {
+		t.Error("ats=true should use the ATS template")
+	}
+
+	if _, err := getPrompt(t, TailorCVPromptHandler(pctx), map[string]string{"application": "missing"}); err == nil {
+		t.Error("unknown application should fail")
+	}
+}
+
+func TestImproveVersionPromptHandler(t *testing.T) {
+	pctx := newProjectContext(t, map[string][]string{"acme": {"Skills: Go, Kubernetes"}})
+
+	text, err := getPrompt(t, ImproveVersionPromptHandler(pctx), map[string]string{"application": "acme"})
+	if err != nil {
+		t.Fatalf("improve_version error = %v", err)
+	}
+	for _, want := range []string{"optimized-cv-1.md", "66%", "Missing: terraform", "Skills: Go, Kubernetes"} {
+		if !strings.Contains(text, want) {
+			t.Errorf("prompt missing %q:\n%s", want, text)
+		}
+	}
+}
@@ -0,0 +1,171 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/richq/m2cv/internal/application"
+)
+
+// Resource URIs served by the project server.
+const (
+	applicationsURI        = "m2cv://applications"
+	baseCVURI              = "m2cv://base-cv"
+	jobDescriptionTemplate = "m2cv://applications/{name}/job-description"
+	versionTemplate        = "m2cv://applications/{name}/versions/{version}"
+	resumeJSONTemplate     = "m2cv://applications/{name}/resume.json"
+)
+
+// addProjectResources registers the project resources and resource templates.
+func addProjectResources(s *server.MCPServer, pctx *ProjectContext) {
+	s.AddResource(mcp.NewResource(applicationsURI, "Applications",
+		mcp.WithResourceDescription("Every application with its status, optimized versions and PDF state"),
+		mcp.WithMIMEType("application/json"),
+	), ApplicationsResourceHandler(pctx))
+
+	s.AddResource(mcp.NewResource(baseCVURI, "Base CV",
+		mcp.WithResourceDescription("The base CV in markdown, before any tailoring"),
+		mcp.WithMIMEType("text/markdown"),
+	), BaseCVResourceHandler(pctx))
+
+	s.AddResourceTemplate(mcp.NewResourceTemplate(jobDescriptionTemplate, "Job description",
+		mcp.WithTemplateDescription("The job description of an application"),
+		mcp.WithTemplateMIMEType("text/plain"),
+	), JobDescriptionResourceHandler(pctx))
+
+	s.AddResourceTemplate(mcp.NewResourceTemplate(versionTemplate, "Optimized CV version",
+		mcp.WithTemplateDescription("An optimized CV version by number, or 'latest'"),
+		mcp.WithTemplateMIMEType("text/markdown"),
+	), VersionResourceHandler(pctx))
+
+	s.AddResourceTemplate(mcp.NewResourceTemplate(resumeJSONTemplate, "JSON Resume",
+		mcp.WithTemplateDescription("The resume.json produced by the last generate run"),
+		mcp.WithTemplateMIMEType("application/json"),
+	), ResumeJSONResourceHandler(pctx))
+}
+
+// resourceHandler is the handler signature used by every m2cv resource.
+type resourceHandler = func(ctx context.Context, request mcp.ReadResourceRequest) ([]mcp.ResourceContents, error)
+
+// ApplicationsResourceHandler returns the application summaries as JSON.
+func ApplicationsResourceHandler(pctx *ProjectContext) resourceHandler {
+	return func(ctx context.Context, request mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
+		summaries, err := application.Summarize(pctx.ApplicationsDir)
+		if err != nil {
+			return nil, err
+		}
+		data, err := json.MarshalIndent(summaries, "", "  ")
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal applications: %w", err)
+		}
+		return textResource(request.Params.URI, "application/json", string(data)), nil
+	}
+}
+
+// BaseCVResourceHandler returns the base CV.
+func BaseCVResourceHandler(pctx *ProjectContext) resourceHandler {
+	return func(ctx context.Context, request mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
+		return readFileResource(request.Params.URI, "text/markdown", pctx.BaseCVPath)
+	}
+}
+
+// JobDescriptionResourceHandler returns an application's job description.
+func JobDescriptionResourceHandler(pctx *ProjectContext) resourceHandler {
+	return func(ctx context.Context, request mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
+		appDir, err := pctx.appDir(uriArg(request, "name"))
+		if err != nil {
+			return nil, err
+		}
+		jdPath, err := application.FindJobDescription(appDir)
+		if err != nil {
+			return nil, err
+		}
+		return readFileResource(request.Params.URI, "text/plain", jdPath)
+	}
+}
+
+// VersionResourceHandler returns an optimized CV version.
+func VersionResourceHandler(pctx *ProjectContext) resourceHandler {
+	return func(ctx context.Context, request mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
+		appDir, err := pctx.appDir(uriArg(request, "name"))
+		if err != nil {
+			return nil, err
+		}
+		path, err := resolveVersion(appDir, uriArg(request, "version"))
+		if err != nil {
+			return nil, err
+		}
+		return readFileResource(request.Params.URI, "text/markdown", path)
+	}
+}
+
+// ResumeJSONResourceHandler returns an application's resume.json.
+func ResumeJSONResourceHandler(pctx *ProjectContext) resourceHandler {
+	return func(ctx context.Context, request mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
+		appDir, err := pctx.appDir(uriArg(request, "name"))
+		if err != nil {
+			return nil, err
+		}
+		return readFileResource(request.Params.URI, "application/json", filepath.Join(appDir, "resume.json"))
+	}
+}
+
+// uriArg returns a variable matched from a resource template URI.
+// Template matches arrive as string slices.
+func uriArg(request mcp.ReadResourceRequest, name string) string {
+	switch v := request.Params.Arguments[name].(type) {
+	case string:
+		return v
+	case []string:
+		if len(v) > 0 {
+			return v[0]
+		}
+	}
+	return ""
+}
+
+// resolveVersion maps a version number or "latest" to an existing file path.
+func resolveVersion(appDir, version string) (string, error) {
+	if version == "" || version == "latest" {
+		latest, err := application.LatestVersionPath(appDir)
+		if err != nil {
+			return "", fmt.Errorf("failed to find latest version: %w", err)
+		}
+		if latest == "" {
+			return "", fmt.Errorf("no optimized versions yet")
+		}
+		return latest, nil
+	}
+
+	n, err := strconv.Atoi(version)
+	if err != nil || n < 1 {
+		return "", fmt.Errorf("invalid version %q: use a positive number or 'latest'", version)
+	}
+	path := application.VersionPath(appDir, n)
+	if _, err := os.Stat(path); err != nil {
+		return "", fmt.Errorf("version %d not found", n)
+	}
+	return path, nil
+}
+
+// readFileResource reads path into a single text resource.
+func readFileResource(uri, mimeType, path string) ([]mcp.ResourceContents, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	return textResource(uri, mimeType, string(content)), nil
+}
+
+// textResource wraps text as the contents of a resource read.
+func textResource(uri, mimeType, text string) []mcp.ResourceContents {
+	return []mcp.ResourceContents{
+		mcp.TextResourceContents{URI: uri, MIMEType: mimeType, Text: text},
+	}
+}
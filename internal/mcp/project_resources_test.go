@@ -0,0 +1,79 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// readResource sends a resources/read request through the project server
+// so the URI template routing is exercised.
+func readResource(t *testing.T, s *Server, uri string) (string, string) {
+	t.Helper()
+	msg := `{"jsonrpc":"2.0","id":1,"method":"resources/read","params":{"uri":"` + uri + `"}}`
+	resp := s.mcpServer.HandleMessage(context.Background(), json.RawMessage(msg))
+
+	switch r := resp.(type) {
+	case mcp.JSONRPCResponse:
+		result, ok := r.Result.(mcp.ReadResourceResult)
+		if !ok {
+			t.Fatalf("unexpected result type %T", r.Result)
+		}
+		return result.Contents[0].(mcp.TextResourceContents).Text, ""
+	case mcp.JSONRPCError:
+		return "", r.Error.Message
+	}
+	t.Fatalf("unexpected response type %T", resp)
+	return "", ""
+}
+
+func TestProjectResources(t *testing.T) {
+	pctx := newProjectContext(t, map[string][]string{"acme": {"# v1", "# v2"}})
+	s := NewProjectServer(pctx)
+
+	tests := []struct {
+		uri  string
+		want string
+	}{
+		{"m2cv://applications", `"name": "acme"`},
+		{"m2cv://base-cv", "# Base CV"},
+		{"m2cv://applications/acme/job-description", "Kubernetes"},
+		{"m2cv://applications/acme/versions/1", "# v1"},
+		{"m2cv://applications/acme/versions/latest", "# v2"},
+	}
+	for _, tt := range tests {
+		text, errMsg := readResource(t, s, tt.uri)
+		if errMsg != "" {
+			t.Errorf("%s: error %s", tt.uri, errMsg)
+			continue
+		}
+		if !strings.Contains(text, tt.want) {
+			t.Errorf("%s = %q, want to contain %q", tt.uri, text, tt.want)
+		}
+	}
+
+	for _, uri := range []string{
+		"m2cv://applications/acme/versions/9",
+		"m2cv://applications/acme/resume.json",
+		"m2cv://applications/missing/job-description",
+	} {
+		if _, errMsg := readResource(t, s, uri); errMsg == "" {
+			t.Errorf("%s should fail", uri)
+		}
+	}
+}
+
+func TestResolveVersion(t *testing.T) {
+	ictx := newToolsetContext(t, "# v1")
+	for _, bad := range []string{"0", "-1", "two", "2"} {
+		if _, err := resolveVersion(ictx.ApplicationDir, bad); err == nil {
+			t.Errorf("resolveVersion(%q) should fail", bad)
+		}
+	}
+	if _, err := resolveVersion(t.TempDir(), "latest"); err == nil || !strings.Contains(err.Error(), "no optimized versions") {
+		t.Errorf("resolveVersion(latest) on empty dir = %v", err)
+	}
+}
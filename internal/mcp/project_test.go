@@ -0,0 +1,76 @@
+package mcp
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// newProjectContext creates a project with a base CV and the given
+// applications, each with a job description and optional versions.
+func newProjectContext(t *testing.T, apps map[string][]string) *ProjectContext {
+	t.Helper()
+	dir := t.TempDir()
+	baseCVPath := filepath.Join(dir, "base-cv.md")
+	if err := os.WriteFile(baseCVPath, []byte("# Base CV"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	applicationsDir := filepath.Join(dir, "applications")
+	for name, versions := range apps {
+		appDir := filepath.Join(applicationsDir, name)
+		if err := os.MkdirAll(appDir, 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filepath.Join(appDir, "job-description.txt"), []byte("Go Go Kubernetes Terraform"), 0644); err != nil {
+			t.Fatal(err)
+		}
+		for i, content := range versions {
+			path := filepath.Join(appDir, "optimized-cv-"+string(rune('1'+i))+".md")
+			if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+
+	return &ProjectContext{
+		ProjectDir:      dir,
+		ApplicationsDir: applicationsDir,
+		BaseCVPath:      baseCVPath,
+		Theme:           "even",
+	}
+}
+
+func TestProjectContext_AppDir(t *testing.T) {
+	pctx := newProjectContext(t, map[string][]string{"acme": nil})
+
+	if dir, err := pctx.appDir("acme"); err != nil || dir != filepath.Join(pctx.ApplicationsDir, "acme") {
+		t.Errorf("appDir(acme) = %q, %v", dir, err)
+	}
+
+	for _, name := range []string{"", ".", "..", "../acme", "acme/../../etc", "missing"} {
+		if _, err := pctx.appDir(name); err == nil {
+			t.Errorf("appDir(%q) should fail", name)
+		}
+	}
+}
+
+func TestProjectContext_ApplicationContext(t *testing.T) {
+	pctx := newProjectContext(t, map[string][]string{"acme": nil})
+
+	ictx, err := pctx.applicationContext("acme")
+	if err != nil {
+		t.Fatalf("applicationContext() error = %v", err)
+	}
+	if ictx.BaseCV != "# Base CV" || !strings.Contains(ictx.JobDescription, "Kubernetes") || ictx.Theme != "even" {
+		t.Errorf("unexpected context: %+v", ictx)
+	}
+
+	if err := os.Remove(ictx.JobDescriptionPath); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := pctx.applicationContext("acme"); err == nil || !strings.Contains(err.Error(), "no .txt file found") {
+		t.Errorf("missing job description error = %v", err)
+	}
+}
@@ -0,0 +1,226 @@
+package mcp
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/richq/m2cv/internal/application"
+	"github.com/richq/m2cv/internal/generator"
+)
+
+// addProjectTools registers the project-wide tools. The single-application
+// tools are reused with an extra "application" argument selecting the folder.
+func addProjectTools(s *server.MCPServer, pctx *ProjectContext) {
+	s.AddTool(NewListApplicationsTool(), ListApplicationsHandler(pctx))
+	s.AddTool(NewApplyTool(), ApplyHandler(pctx))
+	s.AddTool(NewOptimizeTool(), OptimizeHandler(pctx))
+	s.AddTool(NewUpdateStatusTool(), UpdateStatusHandler(pctx))
+
+	s.AddTool(withApplicationArg(NewGeneratePDFTool()), forApplication(pctx, GeneratePDFHandler))
+	s.AddTool(withApplicationArg(NewWriteOptimizedResumeTool()), forApplication(pctx, WriteOptimizedResumeHandler))
+	s.AddTool(withApplicationArg(NewListVersionsTool()), forApplication(pctx, ListVersionsHandler))
+	s.AddTool(withApplicationArg(NewReadVersionTool()), forApplication(pctx, ReadVersionHandler))
+	s.AddTool(withApplicationArg(NewScoreVersionTool()), forApplication(pctx, ScoreVersionHandler))
+	s.AddTool(withApplicationArg(NewDiffVersionsTool()), forApplication(pctx, DiffVersionsHandler))
+}
+
+// withApplicationArg adds the required "application" argument to a
+// single-application tool definition.
+func withApplicationArg(tool mcp.Tool) mcp.Tool {
+	mcp.WithString("application",
+		mcp.Required(),
+		mcp.Description("Application folder name (see list_applications)"),
+	)(&tool)
+	return tool
+}
+
+// forApplication adapts a single-application handler constructor so the
+// "application" argument selects the context it runs with.
+func forApplication(pctx *ProjectContext, newHandler func(*InteractiveContext) toolHandler) toolHandler {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		name, _ := request.Params.Arguments["application"].(string)
+		ictx, err := pctx.applicationContext(name)
+		if err != nil {
+			return newErrorResult(err.Error()), nil
+		}
+		return newHandler(ictx)(ctx, request)
+	}
+}
+
+// NewListApplicationsTool creates the tool definition for listing applications.
+func NewListApplicationsTool() mcp.Tool {
+	return mcp.NewTool("list_applications",
+		mcp.WithDescription("List every job application in the project with its status, optimized versions and whether a PDF has been exported."),
+	)
+}
+
+// ListApplicationsHandler summarizes each application folder.
+func ListApplicationsHandler(pctx *ProjectContext) toolHandler {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		summaries, err := application.Summarize(pctx.ApplicationsDir)
+		if err != nil {
+			return newErrorResult(err.Error()), nil
+		}
+		if len(summaries) == 0 {
+			return mcp.NewToolResultText("No applications yet. Use apply to create one."), nil
+		}
+
+		var b strings.Builder
+		for _, s := range summaries {
+			fmt.Fprintf(&b, "%s: %s, %d version(s)", s.Name, s.Status, len(s.Versions))
+			if s.HasPDF {
+				b.WriteString(", resume.pdf exported")
+			}
+			b.WriteString("\n")
+		}
+		return mcp.NewToolResultText(b.String()), nil
+	}
+}
+
+// NewApplyTool creates the tool definition for creating an application.
+func NewApplyTool() mcp.Tool {
+	return mcp.NewTool("apply",
+		mcp.WithDescription("Create a new job application folder from a job description."),
+		mcp.WithString("name",
+			mcp.Required(),
+			mcp.Description("Short job name, used as the folder name (e.g. acme-backend-engineer)"),
+		),
+		mcp.WithString("job_description",
+			mcp.Required(),
+			mcp.Description("The full job posting text"),
+		),
+	)
+}
+
+// ApplyHandler creates an application folder and saves the job description.
+func ApplyHandler(pctx *ProjectContext) toolHandler {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		name, _ := request.Params.Arguments["name"].(string)
+		content, _ := request.Params.Arguments["job_description"].(string)
+		if strings.TrimSpace(name) == "" {
+			return newErrorResult("missing required parameter: name"), nil
+		}
+		if strings.TrimSpace(content) == "" {
+			return newErrorResult("job posting content is empty"), nil
+		}
+
+		appPath, err := application.Create(pctx.ApplicationsDir, name)
+		if err != nil {
+			return newErrorResult(err.Error()), nil
+		}
+		jdPath := filepath.Join(appPath, application.JobDescriptionFile)
+		if err := os.WriteFile(jdPath, []byte(content), 0644); err != nil {
+			return newErrorResult(fmt.Sprintf("failed to write job description: %v", err)), nil
+		}
+
+		return mcp.NewToolResultText(fmt.Sprintf("Created application %s. Job description saved to: %s", filepath.Base(appPath), jdPath)), nil
+	}
+}
+
+// NewOptimizeTool creates the tool definition for one-shot optimization.
+func NewOptimizeTool() mcp.Tool {
+	return mcp.NewTool("optimize",
+		mcp.WithDescription("Tailor the base CV to an application's job description with Claude and save it as the next optimized version. Takes a while."),
+		mcp.WithString("application",
+			mcp.Required(),
+			mcp.Description("Application folder name (see list_applications)"),
+		),
+		mcp.WithBoolean("ats",
+			mcp.Description("Optimize for Applicant Tracking Systems"),
+		),
+		mcp.WithString("model",
+			mcp.Description("Claude model (defaults to the project model)"),
+		),
+	)
+}
+
+// OptimizeHandler runs the optimize pipeline for an application.
+func OptimizeHandler(pctx *ProjectContext) toolHandler {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		name, _ := request.Params.Arguments["application"].(string)
+		appDir, err := pctx.appDir(name)
+		if err != nil {
+			return newErrorResult(err.Error()), nil
+		}
+		settings, err := pctx.settings(appDir)
+		if err != nil {
+			return newErrorResult(err.Error()), nil
+		}
+
+		model, _ := request.Params.Arguments["model"].(string)
+		ats, _ := request.Params.Arguments["ats"].(bool)
+
+		result, err := generator.Optimize(ctx, generator.OptimizeRequest{
+			AppDir:      appDir,
+			ProjectDir:  pctx.ProjectDir,
+			BaseCVPath:  settings.BaseCVPath,
+			Model:       model,
+			Models:      settings.Models,
+			ATSMode:     ats,
+			Executor:    pctx.Executor,
+			ToolVersion: pctx.ToolVersion,
+			ToolCommit:  pctx.ToolCommit,
+		})
+		if err != nil {
+			return newErrorResult(err.Error()), nil
+		}
+
+		text := fmt.Sprintf("Optimized CV written to: %s", result.OutputPath)
+		for _, warning := range result.Warnings {
+			text += "\nwarning: " + warning
+		}
+		return mcp.NewToolResultText(text), nil
+	}
+}
+
+// NewUpdateStatusTool creates the tool definition for updating an application's status.
+func NewUpdateStatusTool() mcp.Tool {
+	names := make([]string, len(application.Statuses))
+	for i, s := range application.Statuses {
+		names[i] = string(s)
+	}
+
+	return mcp.NewTool("update_status",
+		mcp.WithDescription("Record where an application stands in the hiring process."),
+		mcp.WithString("application",
+			mcp.Required(),
+			mcp.Description("Application folder name (see list_applications)"),
+		),
+		mcp.WithString("status",
+			mcp.Required(),
+			mcp.Description("New status"),
+			mcp.Enum(names...),
+		),
+		mcp.WithString("note",
+			mcp.Description("Optional note, e.g. the interview date"),
+		),
+	)
+}
+
+// UpdateStatusHandler writes status.yml for an application.
+func UpdateStatusHandler(pctx *ProjectContext) toolHandler {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		name, _ := request.Params.Arguments["application"].(string)
+		appDir, err := pctx.appDir(name)
+		if err != nil {
+			return newErrorResult(err.Error()), nil
+		}
+
+		statusArg, _ := request.Params.Arguments["status"].(string)
+		status, err := application.ParseStatus(statusArg)
+		if err != nil {
+			return newErrorResult(err.Error()), nil
+		}
+		note, _ := request.Params.Arguments["note"].(string)
+
+		if _, err := application.WriteStatus(appDir, status, note); err != nil {
+			return newErrorResult(err.Error()), nil
+		}
+		return mcp.NewToolResultText(fmt.Sprintf("%s is now %s", name, status)), nil
+	}
+}
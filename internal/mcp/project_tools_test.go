@@ -0,0 +1,168 @@
+package mcp
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/richq/m2cv/internal/application"
+	"github.com/richq/m2cv/internal/executor"
+	"github.com/richq/m2cv/internal/provenance"
+)
+
+// stubExecutor returns a canned response from Claude and records the prompt.
+type stubExecutor struct {
+	output string
+	prompt string
+}
+
+func (s *stubExecutor) Execute(ctx context.Context, prompt string, opts ...executor.ExecuteOption) (string, error) {
+	s.prompt = prompt
+	return s.output, nil
+}
+
+func (s *stubExecutor) ExecuteInteractive(ctx context.Context, cfg executor.InteractiveConfig) error {
+	return nil
+}
+
+func TestListApplicationsHandler(t *testing.T) {
+	pctx := newProjectContext(t, map[string][]string{"acme": {"# v1", "# v2"}, "globex": nil})
+	if _, err := application.WriteStatus(filepath.Join(pctx.ApplicationsDir, "acme"), application.StatusApplied, ""); err != nil {
+		t.Fatal(err)
+	}
+
+	text, isErr := callTool(t, ListApplicationsHandler(pctx), nil)
+	if isErr {
+		t.Fatalf("unexpected tool error: %s", text)
+	}
+	for _, want := range []string{"acme: applied, 2 version(s)", "globex: draft, 0 version(s)"} {
+		if !strings.Contains(text, want) {
+			t.Errorf("listing missing %q:\n%s", want, text)
+		}
+	}
+
+	empty := newProjectContext(t, nil)
+	if text, _ := callTool(t, ListApplicationsHandler(empty), nil); !strings.Contains(text, "No applications yet") {
+		t.Errorf("unexpected empty listing: %s", text)
+	}
+}
+
+func TestApplyHandler(t *testing.T) {
+	pctx := newProjectContext(t, nil)
+
+	text, isErr := callTool(t, ApplyHandler(pctx), map[string]interface{}{"name": "Acme Backend", "job_description": "We need Go"})
+	if isErr {
+		t.Fatalf("unexpected tool error: %s", text)
+	}
+	content, err := os.ReadFile(filepath.Join(pctx.ApplicationsDir, "acme-backend", application.JobDescriptionFile))
+	if err != nil || string(content) != "We need Go" {
+		t.Errorf("job description not saved: %q, %v", content, err)
+	}
+
+	if text, isErr := callTool(t, ApplyHandler(pctx), map[string]interface{}{"name": "acme-backend", "job_description": "again"}); !isErr || !strings.Contains(text, "already exists") {
+		t.Errorf("duplicate apply = %q (isErr=%v)", text, isErr)
+	}
+	if _, isErr := callTool(t, ApplyHandler(pctx), map[string]interface{}{"name": "empty", "job_description": "  "}); !isErr {
+		t.Error("empty job description should fail")
+	}
+}
+
+func TestOptimizeHandler_Snapshots(t *testing.T) {
+	pctx := newProjectContext(t, map[string][]string{"acme": nil})
+	pctx.Executor = &stubExecutor{output: "# Tailored CV"}
+
+	text, isErr := callTool(t, OptimizeHandler(pctx), map[string]interface{}{"application": "acme"})
+	if isErr {
+		t.Fatalf("unexpected tool error: %s", text)
+	}
+
+	// The snapshots let outdated and optimize --rebase work on this version
+	meta, err := provenance.Read(application.VersionPath(filepath.Join(pctx.ApplicationsDir, "acme"), 1))
+	if err != nil {
+		t.Fatal(err)
+	}
+	baseCV, _ := meta.Input(provenance.RoleBaseCV)
+	for _, sum := range []string{baseCV.SHA256, meta.ModelOutput()} {
+		if _, err := provenance.LoadSnapshot(pctx.ProjectDir, sum); err != nil {
+			t.Errorf("snapshot %.12s: %v", sum, err)
+		}
+	}
+}
+
+func TestProjectHandlers_ApplicationSettings(t *testing.T) {
+	pctx := newProjectContext(t, map[string][]string{"acme": {"# v1"}})
+	stub := &stubExecutor{output: "# Tailored CV"}
+	pctx.Executor = stub
+
+	// An application layer pointing at its own base CV and theme
+	appBaseCV := filepath.Join(t.TempDir(), "acme-base.md")
+	if err := os.WriteFile(appBaseCV, []byte("# Acme base CV"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	var asked string
+	pctx.ForApplication = func(appDir string) (*ProjectContext, error) {
+		asked = appDir
+		actx := *pctx
+		actx.BaseCVPath, actx.Theme = appBaseCV, "flat"
+		return &actx, nil
+	}
+
+	if text, isErr := callTool(t, OptimizeHandler(pctx), map[string]interface{}{"application": "acme"}); isErr {
+		t.Fatalf("unexpected tool error: %s", text)
+	}
+	if asked != filepath.Join(pctx.ApplicationsDir, "acme") || !strings.Contains(stub.prompt, "# Acme base CV") {
+		t.Errorf("optimize should use the application's settings (asked for %q)", asked)
+	}
+
+	ictx, err := pctx.applicationContext("acme")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ictx.Theme != "flat" || ictx.BaseCV != "# Acme base CV" {
+		t.Errorf("application context: theme=%q base CV=%q", ictx.Theme, ictx.BaseCV)
+	}
+}
+
+func TestUpdateStatusHandler(t *testing.T) {
+	pctx := newProjectContext(t, map[string][]string{"acme": nil})
+
+	text, isErr := callTool(t, UpdateStatusHandler(pctx), map[string]interface{}{"application": "acme", "status": "interviewing", "note": "Tuesday 10am"})
+	if isErr {
+		t.Fatalf("unexpected tool error: %s", text)
+	}
+	info, err := application.ReadStatus(filepath.Join(pctx.ApplicationsDir, "acme"))
+	if err != nil || info.Status != application.StatusInterviewing || info.Note != "Tuesday 10am" {
+		t.Errorf("status not written: %+v, %v", info, err)
+	}
+
+	if _, isErr := callTool(t, UpdateStatusHandler(pctx), map[string]interface{}{"application": "acme", "status": "ghosted"}); !isErr {
+		t.Error("invalid status should fail")
+	}
+	if _, isErr := callTool(t, UpdateStatusHandler(pctx), map[string]interface{}{"application": "missing", "status": "offer"}); !isErr {
+		t.Error("unknown application should fail")
+	}
+}
+
+func TestForApplication(t *testing.T) {
+	pctx := newProjectContext(t, map[string][]string{"acme": {"# v1", "# v2"}})
+	handler := forApplication(pctx, ReadVersionHandler)
+
+	if text, _ := callTool(t, handler, map[string]interface{}{"application": "acme", "version": float64(1)}); text != "# v1" {
+		t.Errorf("read_version via project = %q", text)
+	}
+	if text, isErr := callTool(t, handler, map[string]interface{}{"application": "../acme"}); !isErr || !strings.Contains(text, "invalid application name") {
+		t.Errorf("traversal = %q (isErr=%v)", text, isErr)
+	}
+}
+
+func TestWithApplicationArg(t *testing.T) {
+	tool := withApplicationArg(NewReadVersionTool())
+	if _, ok := tool.InputSchema.Properties["application"]; !ok {
+		t.Error("application property missing")
+	}
+	if len(tool.InputSchema.Required) != 1 || tool.InputSchema.Required[0] != "application" {
+		t.Errorf("Required = %v", tool.InputSchema.Required)
+	}
+}
@@ -9,7 +9,9 @@ type Server struct {
 	mcpServer *server.MCPServer
 }
 
-// NewServer creates a new MCP server configured with the write_optimized_resume tool.
+// NewServer creates a new MCP server configured with the interactive optimization tools.
+// Besides write_optimized_resume, the tools let Claude read back earlier versions,
+// inspect the inputs, score keyword coverage, diff versions and export a PDF.
 func NewServer(ctx *InteractiveContext) *Server {
 	mcpServer := server.NewMCPServer(
 		"m2cv",
@@ -17,17 +19,21 @@ func NewServer(ctx *InteractiveContext) *Server {
 		server.WithToolCapabilities(false),
 	)
 
-	// Register the write_optimized_resume tool
-	tool := NewWriteOptimizedResumeTool()
-	handler := WriteOptimizedResumeHandler(ctx.ApplicationDir)
-	mcpServer.AddTool(tool, handler)
+	mcpServer.AddTool(NewWriteOptimizedResumeTool(), WriteOptimizedResumeHandler(ctx))
+	mcpServer.AddTool(NewListVersionsTool(), ListVersionsHandler(ctx))
+	mcpServer.AddTool(NewReadVersionTool(), ReadVersionHandler(ctx))
+	mcpServer.AddTool(NewGetJobDescriptionTool(), GetJobDescriptionHandler(ctx))
+	mcpServer.AddTool(NewGetBaseCVTool(), GetBaseCVHandler(ctx))
+	mcpServer.AddTool(NewScoreVersionTool(), ScoreVersionHandler(ctx))
+	mcpServer.AddTool(NewDiffVersionsTool(), DiffVersionsHandler(ctx))
+	mcpServer.AddTool(NewGeneratePDFTool(), GeneratePDFHandler(ctx))
 
 	return &Server{
 		mcpServer: mcpServer,
 	}
 }
 
-// Serve starts the MCP server on stdio.
+// Serve starts the MCP server on stdio. See ServeHTTP for the HTTP/SSE transport.
 func (s *Server) Serve() error {
 	return server.ServeStdio(s.mcpServer)
 }
@@ -4,9 +4,11 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"time"
 
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/richq/m2cv/internal/application"
+	"github.com/richq/m2cv/internal/provenance"
 )
 
 // NewWriteOptimizedResumeTool creates the tool definition for writing an optimized resume.
@@ -28,9 +30,13 @@ func newErrorResult(message string) *mcp.CallToolResult {
 }
 
 // WriteOptimizedResumeHandler creates a handler function for the write_optimized_resume tool.
-// The handler writes the content to a versioned file in the application directory.
-func WriteOptimizedResumeHandler(appDir string) func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+// The handler writes the content to a versioned file in the application directory
+// and records a provenance sidecar for it.
+func WriteOptimizedResumeHandler(ictx *InteractiveContext) func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	appDir := ictx.ApplicationDir
 	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		startedAt := time.Now().UTC()
+
 		// Extract content from arguments
 		contentArg, ok := request.Params.Arguments["content"]
 		if !ok {
@@ -53,6 +59,46 @@ func WriteOptimizedResumeHandler(appDir string) func(ctx context.Context, reques
 			return newErrorResult(fmt.Sprintf("failed to write file: %v", err)), nil
 		}
 
+		// Record provenance; the version is already written, so a failure here
+		// is reported but not treated as a tool error
+		if err := writeProvenance(ictx, outputPath, startedAt); err != nil {
+			return mcp.NewToolResultText(fmt.Sprintf("Optimized resume written to: %s (warning: %v)", outputPath, err)), nil
+		}
+
 		return mcp.NewToolResultText(fmt.Sprintf("Optimized resume written to: %s", outputPath)), nil
 	}
 }
+
+// writeProvenance records the sidecar metadata for a version written during
+// an interactive session.
+func writeProvenance(ictx *InteractiveContext, outputPath string, startedAt time.Time) error {
+	meta := &provenance.Metadata{
+		Command:     "optimize",
+		ToolVersion: ictx.ToolVersion,
+		ToolCommit:  ictx.ToolCommit,
+		Prompt:      &provenance.Prompt{Name: "interactive", SHA256: ictx.PromptSHA256},
+		Model:       ictx.Model,
+		ATSMode:     ictx.ATSMode,
+		Interactive: true,
+		StartedAt:   startedAt,
+	}
+	meta.AddInput(provenance.RoleBaseCV, ictx.BaseCVPath, []byte(ictx.BaseCV))
+	meta.AddInput(provenance.RoleJobDescription, ictx.JobDescriptionPath, []byte(ictx.JobDescription))
+	if err := meta.AddOutput(outputPath); err != nil {
+		return err
+	}
+	if ictx.ProjectDir != "" {
+		if _, err := provenance.SaveSnapshot(ictx.ProjectDir, []byte(ictx.BaseCV)); err != nil {
+			return err
+		}
+		// The written version as a rebase base, see generator.Optimize
+		output, err := os.ReadFile(outputPath)
+		if err != nil {
+			return err
+		}
+		if _, err := provenance.SaveSnapshot(ictx.ProjectDir, output); err != nil {
+			return err
+		}
+	}
+	return provenance.Write(outputPath, meta)
+}
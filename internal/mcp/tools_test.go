@@ -0,0 +1,66 @@
+package mcp
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/richq/m2cv/internal/provenance"
+)
+
+func TestWriteOptimizedResumeHandler_WritesVersionAndProvenance(t *testing.T) {
+	appDir := t.TempDir()
+	ictx := &InteractiveContext{
+		ApplicationDir:     appDir,
+		BaseCV:             "# Base",
+		BaseCVPath:         "base-cv.md",
+		JobDescription:     "Job",
+		JobDescriptionPath: filepath.Join(appDir, "job.txt"),
+		ATSMode:            true,
+		Model:              "sonnet",
+		ToolVersion:        "v1.0.0",
+	}
+
+	var request mcp.CallToolRequest
+	request.Params.Arguments = map[string]interface{}{"content": "# Tailored"}
+
+	result, err := WriteOptimizedResumeHandler(ictx)(context.Background(), request)
+	if err != nil {
+		t.Fatalf("handler error = %v", err)
+	}
+	if result.IsError {
+		t.Fatalf("handler returned tool error: %+v", result.Content)
+	}
+
+	outputPath := filepath.Join(appDir, "optimized-cv-1.md")
+	data, err := os.ReadFile(outputPath)
+	if err != nil || string(data) != "# Tailored" {
+		t.Fatalf("version not written: %q, %v", string(data), err)
+	}
+
+	meta, err := provenance.Read(outputPath)
+	if err != nil {
+		t.Fatalf("provenance.Read() error = %v", err)
+	}
+	if !meta.Interactive || !meta.ATSMode || meta.Model != "sonnet" || meta.ToolVersion != "v1.0.0" {
+		t.Errorf("unexpected metadata: %+v", meta)
+	}
+	if base, ok := meta.Input(provenance.RoleBaseCV); !ok || base.SHA256 != provenance.HashBytes([]byte("# Base")) {
+		t.Errorf("base CV input = %+v, %v", base, ok)
+	}
+}
+
+func TestWriteOptimizedResumeHandler_MissingContent(t *testing.T) {
+	var request mcp.CallToolRequest
+	request.Params.Arguments = map[string]interface{}{}
+
+	result, err := WriteOptimizedResumeHandler(&InteractiveContext{ApplicationDir: t.TempDir()})(context.Background(), request)
+	if err != nil {
+		t.Fatalf("handler error = %v", err)
+	}
+	if !result.IsError {
+		t.Error("expected tool error for missing content")
+	}
+}
@@ -0,0 +1,280 @@
+package mcp
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/richq/m2cv/internal/application"
+	"github.com/richq/m2cv/internal/diff"
+	"github.com/richq/m2cv/internal/generator"
+	"github.com/richq/m2cv/internal/scoring"
+)
+
+// toolHandler is the handler signature used by every m2cv tool.
+type toolHandler = func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error)
+
+// NewListVersionsTool creates the tool definition for listing optimized CV versions.
+func NewListVersionsTool() mcp.Tool {
+	return mcp.NewTool("list_versions",
+		mcp.WithDescription("List the optimized CV versions saved for this application, oldest first."),
+	)
+}
+
+// ListVersionsHandler lists optimized-cv-N.md files with their sizes.
+func ListVersionsHandler(ictx *InteractiveContext) toolHandler {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		versions, err := application.ListVersions(ictx.ApplicationDir)
+		if err != nil {
+			return newErrorResult(fmt.Sprintf("failed to list versions: %v", err)), nil
+		}
+		if len(versions) == 0 {
+			return mcp.NewToolResultText("No optimized versions yet. Use write_optimized_resume to save one."), nil
+		}
+
+		var b strings.Builder
+		for _, v := range versions {
+			path := application.VersionPath(ictx.ApplicationDir, v)
+			info, err := os.Stat(path)
+			if err != nil {
+				return newErrorResult(fmt.Sprintf("failed to stat %s: %v", path, err)), nil
+			}
+			fmt.Fprintf(&b, "version %d: %s (%d bytes, modified %s)\n",
+				v, filepath.Base(path), info.Size(), info.ModTime().Format("2006-01-02 15:04"))
+		}
+		return mcp.NewToolResultText(b.String()), nil
+	}
+}
+
+// NewReadVersionTool creates the tool definition for reading an optimized CV version.
+func NewReadVersionTool() mcp.Tool {
+	return mcp.NewTool("read_version",
+		mcp.WithDescription("Read an optimized CV version back as markdown."),
+		mcp.WithNumber("version",
+			mcp.Description("Version number to read (defaults to the latest)"),
+		),
+	)
+}
+
+// ReadVersionHandler returns the content of an optimized CV version.
+func ReadVersionHandler(ictx *InteractiveContext) toolHandler {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		path, err := versionArgPath(ictx.ApplicationDir, request, "version")
+		if err != nil {
+			return newErrorResult(err.Error()), nil
+		}
+
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return newErrorResult(fmt.Sprintf("failed to read %s: %v", path, err)), nil
+		}
+		return mcp.NewToolResultText(string(content)), nil
+	}
+}
+
+// NewGetJobDescriptionTool creates the tool definition for reading the job description.
+func NewGetJobDescriptionTool() mcp.Tool {
+	return mcp.NewTool("get_job_description",
+		mcp.WithDescription("Get the job description for this application."),
+	)
+}
+
+// GetJobDescriptionHandler returns the job description loaded for the session.
+func GetJobDescriptionHandler(ictx *InteractiveContext) toolHandler {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		return mcp.NewToolResultText(ictx.JobDescription), nil
+	}
+}
+
+// NewGetBaseCVTool creates the tool definition for reading the base CV.
+func NewGetBaseCVTool() mcp.Tool {
+	return mcp.NewTool("get_base_cv",
+		mcp.WithDescription("Get the user's base CV in markdown, before any tailoring."),
+	)
+}
+
+// GetBaseCVHandler returns the base CV loaded for the session.
+func GetBaseCVHandler(ictx *InteractiveContext) toolHandler {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		return mcp.NewToolResultText(ictx.BaseCV), nil
+	}
+}
+
+// NewScoreVersionTool creates the tool definition for keyword scoring.
+func NewScoreVersionTool() mcp.Tool {
+	return mcp.NewTool("score_version",
+		mcp.WithDescription("Score an optimized CV version against the job description's keywords and list the gaps."),
+		mcp.WithNumber("version",
+			mcp.Description("Version number to score (defaults to the latest)"),
+		),
+	)
+}
+
+// ScoreVersionHandler reports keyword coverage of a version against the job description.
+func ScoreVersionHandler(ictx *InteractiveContext) toolHandler {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		path, err := versionArgPath(ictx.ApplicationDir, request, "version")
+		if err != nil {
+			return newErrorResult(err.Error()), nil
+		}
+
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return newErrorResult(fmt.Sprintf("failed to read %s: %v", path, err)), nil
+		}
+
+		r := scoring.Score(string(content), ictx.JobDescription)
+		return mcp.NewToolResultText(formatScore(filepath.Base(path), r)), nil
+	}
+}
+
+// formatScore renders a keyword score for a tool result.
+func formatScore(name string, r scoring.Result) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s keyword coverage: %d%%\n", name, r.Score)
+	fmt.Fprintf(&b, "Matched (%d): %s\n", len(r.Matched), strings.Join(r.Matched, ", "))
+	fmt.Fprintf(&b, "Missing (%d): %s\n", len(r.Missing), strings.Join(r.Missing, ", "))
+	return b.String()
+}
+
+// NewDiffVersionsTool creates the tool definition for diffing two versions.
+func NewDiffVersionsTool() mcp.Tool {
+	return mcp.NewTool("diff_versions",
+		mcp.WithDescription("Show a unified diff between two optimized CV versions."),
+		mcp.WithNumber("from",
+			mcp.Description("Older version number (defaults to the version before 'to')"),
+		),
+		mcp.WithNumber("to",
+			mcp.Description("Newer version number (defaults to the latest)"),
+		),
+	)
+}
+
+// DiffVersionsHandler returns a unified diff between two versions.
+func DiffVersionsHandler(ictx *InteractiveContext) toolHandler {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		toPath, err := versionArgPath(ictx.ApplicationDir, request, "to")
+		if err != nil {
+			return newErrorResult(err.Error()), nil
+		}
+
+		var fromPath string
+		if _, ok := request.Params.Arguments["from"]; ok {
+			fromPath, err = versionArgPath(ictx.ApplicationDir, request, "from")
+			if err != nil {
+				return newErrorResult(err.Error()), nil
+			}
+		} else {
+			fromPath, err = previousVersionPath(ictx.ApplicationDir, toPath)
+			if err != nil {
+				return newErrorResult(err.Error()), nil
+			}
+		}
+
+		oldText, err := os.ReadFile(fromPath)
+		if err != nil {
+			return newErrorResult(fmt.Sprintf("failed to read %s: %v", fromPath, err)), nil
+		}
+		newText, err := os.ReadFile(toPath)
+		if err != nil {
+			return newErrorResult(fmt.Sprintf("failed to read %s: %v", toPath, err)), nil
+		}
+
+		out := diff.Unified(filepath.Base(fromPath), filepath.Base(toPath), string(oldText), string(newText), 3)
+		if out == "" {
+			out = "No differences."
+		}
+		return mcp.NewToolResultText(out), nil
+	}
+}
+
+// NewGeneratePDFTool creates the tool definition for exporting a PDF.
+func NewGeneratePDFTool() mcp.Tool {
+	return mcp.NewTool("generate_pdf",
+		mcp.WithDescription("Convert an optimized CV version to JSON Resume and export resume.pdf. Takes a while; use it once the user is happy with the content."),
+		mcp.WithNumber("version",
+			mcp.Description("Version number to export (defaults to the latest)"),
+		),
+		mcp.WithString("theme",
+			mcp.Description("JSON Resume theme (defaults to the project theme)"),
+		),
+	)
+}
+
+// GeneratePDFHandler runs the generate pipeline for a version.
+func GeneratePDFHandler(ictx *InteractiveContext) toolHandler {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		cvPath, err := versionArgPath(ictx.ApplicationDir, request, "version")
+		if err != nil {
+			return newErrorResult(err.Error()), nil
+		}
+
+		theme := ictx.Theme
+		if themeArg, ok := request.Params.Arguments["theme"].(string); ok && themeArg != "" {
+			theme = themeArg
+		}
+
+		result, err := generator.Generate(ctx, generator.GenerateRequest{
+			AppDir:       ictx.ApplicationDir,
+			ProjectDir:   ictx.ProjectDir,
+			CVPath:       cvPath,
+			Theme:        theme,
+			ThemeOptions: ictx.ThemeOptions,
+			Models:       ictx.Models,
+			ToolVersion:  ictx.ToolVersion,
+			ToolCommit:   ictx.ToolCommit,
+		})
+		if err != nil {
+			return newErrorResult(err.Error()), nil
+		}
+
+		text := fmt.Sprintf("Exported %s with theme %q:\n  JSON: %s\n  PDF:  %s", filepath.Base(cvPath), theme, result.JSONPath, result.PDFPath)
+		for _, warning := range result.Warnings {
+			text += "\nwarning: " + warning
+		}
+		return mcp.NewToolResultText(text), nil
+	}
+}
+
+// versionArgPath resolves an optional numeric version argument to a file
+// path, defaulting to the latest version.
+func versionArgPath(appDir string, request mcp.CallToolRequest, name string) (string, error) {
+	arg, ok := request.Params.Arguments[name]
+	if !ok {
+		latest, err := application.LatestVersionPath(appDir)
+		if err != nil {
+			return "", fmt.Errorf("failed to find latest version: %v", err)
+		}
+		if latest == "" {
+			return "", fmt.Errorf("no optimized versions yet")
+		}
+		return latest, nil
+	}
+
+	n, ok := arg.(float64)
+	if !ok || n < 1 || n != float64(int(n)) {
+		return "", fmt.Errorf("%s must be a positive whole number", name)
+	}
+	path := application.VersionPath(appDir, int(n))
+	if _, err := os.Stat(path); err != nil {
+		return "", fmt.Errorf("version %d not found", int(n))
+	}
+	return path, nil
+}
+
+// previousVersionPath returns the path of the highest version below the one at path.
+func previousVersionPath(appDir, path string) (string, error) {
+	versions, err := application.ListVersions(appDir)
+	if err != nil {
+		return "", fmt.Errorf("failed to list versions: %v", err)
+	}
+	for i := len(versions) - 1; i > 0; i-- {
+		if application.VersionPath(appDir, versions[i]) == path {
+			return application.VersionPath(appDir, versions[i-1]), nil
+		}
+	}
+	return "", fmt.Errorf("no earlier version to compare %s against", filepath.Base(path))
+}
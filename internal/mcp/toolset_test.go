@@ -0,0 +1,132 @@
+package mcp
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// callTool invokes a handler with the given arguments and returns its text.
+func callTool(t *testing.T, handler toolHandler, args map[string]interface{}) (string, bool) {
+	t.Helper()
+	var request mcp.CallToolRequest
+	request.Params.Arguments = args
+
+	result, err := handler(context.Background(), request)
+	if err != nil {
+		t.Fatalf("handler error = %v", err)
+	}
+	text, ok := result.Content[0].(mcp.TextContent)
+	if !ok {
+		t.Fatalf("unexpected content type %T", result.Content[0])
+	}
+	return text.Text, result.IsError
+}
+
+// newToolsetContext creates an application dir with the given versions.
+func newToolsetContext(t *testing.T, versions ...string) *InteractiveContext {
+	t.Helper()
+	appDir := t.TempDir()
+	for i, content := range versions {
+		path := filepath.Join(appDir, "optimized-cv-"+string(rune('1'+i))+".md")
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return &InteractiveContext{
+		ApplicationDir: appDir,
+		BaseCV:         "# Base CV",
+		JobDescription: "Go Go Kubernetes Terraform",
+	}
+}
+
+func TestListVersionsHandler(t *testing.T) {
+	ictx := newToolsetContext(t, "# v1", "# v2")
+	text, isErr := callTool(t, ListVersionsHandler(ictx), nil)
+	if isErr {
+		t.Fatalf("unexpected tool error: %s", text)
+	}
+	if !strings.Contains(text, "version 1: optimized-cv-1.md") || !strings.Contains(text, "version 2: optimized-cv-2.md") {
+		t.Errorf("unexpected listing:\n%s", text)
+	}
+
+	empty := newToolsetContext(t)
+	if text, _ := callTool(t, ListVersionsHandler(empty), nil); !strings.Contains(text, "No optimized versions") {
+		t.Errorf("unexpected empty listing: %s", text)
+	}
+}
+
+func TestReadVersionHandler(t *testing.T) {
+	ictx := newToolsetContext(t, "# v1", "# v2")
+
+	if text, _ := callTool(t, ReadVersionHandler(ictx), nil); text != "# v2" {
+		t.Errorf("default should read latest, got %q", text)
+	}
+	if text, _ := callTool(t, ReadVersionHandler(ictx), map[string]interface{}{"version": float64(1)}); text != "# v1" {
+		t.Errorf("version 1 = %q", text)
+	}
+
+	for _, bad := range []interface{}{float64(9), float64(0), float64(1.5), "1"} {
+		if text, isErr := callTool(t, ReadVersionHandler(ictx), map[string]interface{}{"version": bad}); !isErr {
+			t.Errorf("version %v should fail, got %q", bad, text)
+		}
+	}
+}
+
+func TestGetInputsHandlers(t *testing.T) {
+	ictx := newToolsetContext(t)
+	if text, _ := callTool(t, GetBaseCVHandler(ictx), nil); text != "# Base CV" {
+		t.Errorf("get_base_cv = %q", text)
+	}
+	if text, _ := callTool(t, GetJobDescriptionHandler(ictx), nil); text != ictx.JobDescription {
+		t.Errorf("get_job_description = %q", text)
+	}
+}
+
+func TestScoreVersionHandler(t *testing.T) {
+	ictx := newToolsetContext(t, "Skills: Go, Kubernetes")
+	text, isErr := callTool(t, ScoreVersionHandler(ictx), nil)
+	if isErr {
+		t.Fatalf("unexpected tool error: %s", text)
+	}
+	for _, want := range []string{"66%", "Matched (2): go, kubernetes", "Missing (1): terraform"} {
+		if !strings.Contains(text, want) {
+			t.Errorf("score output missing %q:\n%s", want, text)
+		}
+	}
+}
+
+func TestDiffVersionsHandler(t *testing.T) {
+	ictx := newToolsetContext(t, "# CV\n- Go\n", "# CV\n- Go\n- Kubernetes\n", "# CV\n- Rust\n")
+
+	text, _ := callTool(t, DiffVersionsHandler(ictx), nil)
+	if !strings.Contains(text, "--- optimized-cv-2.md") || !strings.Contains(text, "+- Rust") {
+		t.Errorf("default diff should compare 2 -> 3:\n%s", text)
+	}
+
+	text, _ = callTool(t, DiffVersionsHandler(ictx), map[string]interface{}{"from": float64(1), "to": float64(2)})
+	if !strings.Contains(text, "+- Kubernetes") {
+		t.Errorf("diff 1 -> 2 missing insertion:\n%s", text)
+	}
+
+	text, _ = callTool(t, DiffVersionsHandler(ictx), map[string]interface{}{"from": float64(1), "to": float64(1)})
+	if text != "No differences." {
+		t.Errorf("identical versions = %q", text)
+	}
+
+	if text, isErr := callTool(t, DiffVersionsHandler(ictx), map[string]interface{}{"to": float64(1)}); !isErr {
+		t.Errorf("diff with no earlier version should fail, got %q", text)
+	}
+}
+
+func TestGeneratePDFHandler_NoVersions(t *testing.T) {
+	ictx := newToolsetContext(t)
+	text, isErr := callTool(t, GeneratePDFHandler(ictx), nil)
+	if !isErr || !strings.Contains(text, "no optimized versions") {
+		t.Errorf("expected no-versions error, got %q (isErr=%v)", text, isErr)
+	}
+}
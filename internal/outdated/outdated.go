@@ -0,0 +1,129 @@
+// Package outdated finds applications whose optimized CV was tailored from
+// an older base CV, and summarizes how the base CV changed since.
+package outdated
+
+import (
+	"errors"
+	"path/filepath"
+	"time"
+
+	"github.com/richq/m2cv/internal/application"
+	"github.com/richq/m2cv/internal/diff"
+	"github.com/richq/m2cv/internal/provenance"
+)
+
+// Application is an application whose optimized CV is outdated.
+type Application struct {
+	// Name is the application folder name.
+	Name string `json:"name"`
+	// LatestPath is the latest optimized CV, which an update starts from.
+	LatestPath string `json:"latest_path"`
+	// TailoredPath is the newest version with a recorded base CV: usually
+	// LatestPath, unless later versions were added by hand.
+	TailoredPath string `json:"tailored_path"`
+	// TailoredAt is when TailoredPath was written.
+	TailoredAt time.Time `json:"tailored_at"`
+	// Edited reports whether the CV was changed by hand since it was
+	// tailored: TailoredPath no longer matches its recorded hash, or
+	// later versions exist.
+	Edited bool `json:"edited"`
+	// ATSMode records whether the CV was tailored for ATS.
+	ATSMode bool `json:"ats_mode"`
+	// BaseSHA256 identifies the base CV it was tailored from.
+	BaseSHA256 string `json:"base_sha256"`
+	// Changes summarize how the base CV changed since, by section. Empty
+	// when no snapshot of the old base CV was kept.
+	Changes []diff.SectionChange `json:"changes,omitempty"`
+	// PreviousBaseCV is the snapshot of the old base CV; nil if none was kept.
+	PreviousBaseCV []byte `json:"-"`
+}
+
+// Find checks the latest optimized CV of every application in
+// applicationsDir against the current baseCV, using the snapshots kept in
+// projectDir to summarize changes. Applications without optimized
+// versions, or whose versions have no recorded base CV (e.g. written
+// before m2cv recorded provenance), can't be checked and are left out.
+func Find(applicationsDir, projectDir string, baseCV []byte) ([]Application, error) {
+	names, err := application.List(applicationsDir)
+	if err != nil {
+		return nil, err
+	}
+
+	current := provenance.HashBytes(baseCV)
+	snapshots := make(map[string][]byte)
+	changes := make(map[string][]diff.SectionChange)
+
+	var found []Application
+	for _, name := range names {
+		app, err := check(filepath.Join(applicationsDir, name))
+		if err != nil {
+			return nil, err
+		}
+		if app == nil || app.BaseSHA256 == current {
+			continue
+		}
+		app.Name = name
+
+		if _, ok := snapshots[app.BaseSHA256]; !ok {
+			previous, err := provenance.LoadSnapshot(projectDir, app.BaseSHA256)
+			if err != nil && !errors.Is(err, provenance.ErrNoSnapshot) {
+				return nil, err
+			}
+			snapshots[app.BaseSHA256] = previous
+			if previous != nil {
+				changes[app.BaseSHA256] = diff.Sections(string(previous), string(baseCV))
+			}
+		}
+		app.PreviousBaseCV = snapshots[app.BaseSHA256]
+		app.Changes = changes[app.BaseSHA256]
+		found = append(found, *app)
+	}
+	return found, nil
+}
+
+// check finds the newest version of the application in appDir with a
+// recorded base CV, or returns nil if there is none.
+func check(appDir string) (*Application, error) {
+	versions, err := application.ListVersions(appDir)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := len(versions) - 1; i >= 0; i-- {
+		path := application.VersionPath(appDir, versions[i])
+		meta, err := provenance.Read(path)
+		if err != nil {
+			continue
+		}
+		base, ok := meta.Input(provenance.RoleBaseCV)
+		if !ok {
+			continue
+		}
+
+		app := &Application{
+			LatestPath:   application.VersionPath(appDir, versions[len(versions)-1]),
+			TailoredPath: path,
+			TailoredAt:   meta.CreatedAt,
+			Edited:       i < len(versions)-1,
+			ATSMode:      meta.ATSMode,
+			BaseSHA256:   base.SHA256,
+		}
+		if !app.Edited {
+			app.Edited = edited(path, meta)
+		}
+		return app, nil
+	}
+	return nil, nil
+}
+
+// edited reports whether path no longer matches the hash recorded when it
+// was written.
+func edited(path string, meta *provenance.Metadata) bool {
+	for _, out := range meta.Outputs {
+		if out.Path == path || filepath.Base(out.Path) == filepath.Base(path) {
+			sum, err := provenance.HashFile(path)
+			return err == nil && sum != out.SHA256
+		}
+	}
+	return false
+}
@@ -0,0 +1,94 @@
+package outdated
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/richq/m2cv/internal/application"
+	"github.com/richq/m2cv/internal/provenance"
+)
+
+const (
+	oldBase = "# Experience\n\n## Developer | Acme\n\n# Skills\n\nGo\n"
+	newBase = "# Experience\n\n## Staff Engineer | Initech\n\n## Developer | Acme\n\n# Skills\n\nGo\n"
+)
+
+// writeVersion writes optimized CV version n, recording base as its base
+// CV unless base is empty.
+func writeVersion(t *testing.T, appDir string, n int, content, base string) string {
+	t.Helper()
+	if err := os.MkdirAll(appDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	path := application.VersionPath(appDir, n)
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if base == "" {
+		return path
+	}
+	meta := &provenance.Metadata{Command: "optimize", ATSMode: true}
+	meta.AddInput(provenance.RoleBaseCV, "base-cv.md", []byte(base))
+	if err := meta.AddOutput(path); err != nil {
+		t.Fatal(err)
+	}
+	if err := provenance.Write(path, meta); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestFind(t *testing.T) {
+	projectDir := t.TempDir()
+	appsDir := filepath.Join(projectDir, "applications")
+	if _, err := provenance.SaveSnapshot(projectDir, []byte(oldBase)); err != nil {
+		t.Fatal(err)
+	}
+
+	// acme: tailored from the old base, then a hand-written version 2
+	acmeV1 := writeVersion(t, filepath.Join(appsDir, "acme"), 1, "# Acme CV", oldBase)
+	acmeV2 := writeVersion(t, filepath.Join(appsDir, "acme"), 2, "# Acme CV, edited", "")
+	// globex: up to date
+	writeVersion(t, filepath.Join(appsDir, "globex"), 1, "# Globex CV", newBase)
+	// initech: no provenance, can't be checked
+	writeVersion(t, filepath.Join(appsDir, "initech"), 1, "# Initech CV", "")
+	// umbrella: tailored from a base with no snapshot
+	writeVersion(t, filepath.Join(appsDir, "umbrella"), 1, "# Umbrella CV", "# Unknown base\n")
+	os.MkdirAll(filepath.Join(appsDir, "empty"), 0755)
+
+	apps, err := Find(appsDir, projectDir, []byte(newBase))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(apps) != 2 || apps[0].Name != "acme" || apps[1].Name != "umbrella" {
+		t.Fatalf("Find() = %+v", apps)
+	}
+
+	acme := apps[0]
+	if acme.LatestPath != acmeV2 || acme.TailoredPath != acmeV1 || !acme.Edited || !acme.ATSMode {
+		t.Errorf("acme = %+v", acme)
+	}
+	if string(acme.PreviousBaseCV) != oldBase || len(acme.Changes) != 1 || acme.Changes[0].String() != `Experience: added "Staff Engineer | Initech"` {
+		t.Errorf("acme changes = %v", acme.Changes)
+	}
+
+	umbrella := apps[1]
+	if umbrella.Edited || umbrella.PreviousBaseCV != nil || umbrella.Changes != nil {
+		t.Errorf("umbrella = %+v", umbrella)
+	}
+}
+
+func TestFind_EditedInPlace(t *testing.T) {
+	projectDir := t.TempDir()
+	appDir := filepath.Join(projectDir, "applications", "acme")
+	path := writeVersion(t, appDir, 1, "# Acme CV", oldBase)
+	if err := os.WriteFile(path, []byte("# Acme CV, tweaked"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	apps, err := Find(filepath.Join(projectDir, "applications"), projectDir, []byte(newBase))
+	if err != nil || len(apps) != 1 || !apps[0].Edited {
+		t.Errorf("Find() = %+v, %v", apps, err)
+	}
+}
@@ -0,0 +1,242 @@
+package preview
+
+import (
+	"bytes"
+	"fmt"
+	"html/template"
+	"net/http"
+	"slices"
+	"strings"
+
+	themepkg "github.com/richq/m2cv/internal/theme"
+)
+
+// Handler returns the preview's HTTP routes:
+//
+//	/                 the resume in ?theme= (default: the configured theme)
+//	/compare          the resume in several ?themes=a,b side by side
+//	/events           server-sent reload and error events
+func (s *Server) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /{$}", s.handlePage)
+	mux.HandleFunc("GET /compare", s.handleCompare)
+	mux.HandleFunc("GET /events", s.handleEvents)
+	return mux
+}
+
+// handlePage renders the resume in the requested theme and injects the
+// reload script and theme switcher.
+func (s *Server) handlePage(w http.ResponseWriter, r *http.Request) {
+	themes, err := s.cfg.Themes()
+	if err != nil {
+		s.writeError(w, http.StatusInternalServerError, err.Error(), "", nil)
+		return
+	}
+
+	theme := r.URL.Query().Get("theme")
+	if theme == "" {
+		theme = s.cfg.Theme
+	}
+	if normalized, err := themepkg.Normalize(theme); err == nil {
+		theme = normalized
+	}
+	embedded := r.URL.Query().Has("embed")
+	toolbar := themes
+	if embedded {
+		toolbar = nil
+	}
+	if !slices.Contains(themes, theme) {
+		s.writeError(w, http.StatusNotFound, fmt.Sprintf("theme %q is not installed. Run: m2cv theme add %s", theme, theme), theme, toolbar)
+		return
+	}
+
+	page, err := s.render(r, theme)
+	if err != nil {
+		s.writeError(w, http.StatusInternalServerError, err.Error(), theme, toolbar)
+		return
+	}
+
+	overlay, err := renderOverlay(theme, toolbar, s.currentError())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.Header().Set("Cache-Control", "no-store")
+	w.Write(injectBeforeBodyEnd(page, overlay))
+}
+
+// render returns the cached HTML for theme, rendering it if needed.
+func (s *Server) render(r *http.Request, theme string) ([]byte, error) {
+	s.mu.Lock()
+	page, ok := s.cache[theme]
+	s.mu.Unlock()
+	if ok {
+		return page, nil
+	}
+
+	page, err := s.cfg.Render(r.Context(), s.jsonPath(), theme)
+	if err != nil {
+		return nil, err
+	}
+	s.mu.Lock()
+	s.cache[theme] = page
+	s.mu.Unlock()
+	return page, nil
+}
+
+// currentError returns the last build error, if any.
+func (s *Server) currentError() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.lastErr
+}
+
+// writeError serves an error page that still reloads on change.
+func (s *Server) writeError(w http.ResponseWriter, code int, message, theme string, themes []string) {
+	overlay, err := renderOverlay(theme, themes, message)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(code)
+	fmt.Fprintf(w, "<!DOCTYPE html><html><head><title>m2cv preview</title></head><body>%s</body></html>", overlay)
+}
+
+// handleCompare shows several themes side by side in iframes.
+func (s *Server) handleCompare(w http.ResponseWriter, r *http.Request) {
+	var themes []string
+	for _, t := range strings.Split(r.URL.Query().Get("themes"), ",") {
+		if t = strings.TrimSpace(t); t != "" {
+			themes = append(themes, t)
+		}
+	}
+	if len(themes) == 0 {
+		installed, err := s.cfg.Themes()
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		themes = installed
+	}
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	if err := compareTemplate.Execute(w, themes); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+// handleEvents streams reload and error events until the client goes away.
+func (s *Server) handleEvents(w http.ResponseWriter, r *http.Request) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "streaming unsupported", http.StatusInternalServerError)
+		return
+	}
+
+	ch := s.subscribe()
+	defer s.unsubscribe(ch)
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	fmt.Fprint(w, ": connected\n\n")
+	flusher.Flush()
+
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case ev := <-ch:
+			fmt.Fprintf(w, "event: %s\n", ev.name)
+			for _, line := range strings.Split(ev.data, "\n") {
+				fmt.Fprintf(w, "data: %s\n", line)
+			}
+			fmt.Fprint(w, "\n")
+			flusher.Flush()
+		}
+	}
+}
+
+// injectBeforeBodyEnd inserts snippet before the last </body>, or appends it.
+func injectBeforeBodyEnd(page, snippet []byte) []byte {
+	i := bytes.LastIndex(bytes.ToLower(page), []byte("</body>"))
+	if i < 0 {
+		return append(append([]byte{}, page...), snippet...)
+	}
+	out := make([]byte, 0, len(page)+len(snippet))
+	out = append(out, page[:i]...)
+	out = append(out, snippet...)
+	return append(out, page[i:]...)
+}
+
+// overlayData feeds overlayTemplate.
+type overlayData struct {
+	Theme  string
+	Themes []string
+	Error  string
+}
+
+// renderOverlay renders the reload script, error banner and theme switcher.
+// The switcher is omitted when themes is empty (embedded in /compare).
+func renderOverlay(theme string, themes []string, errMsg string) ([]byte, error) {
+	var b bytes.Buffer
+	if err := overlayTemplate.Execute(&b, overlayData{Theme: theme, Themes: themes, Error: errMsg}); err != nil {
+		return nil, fmt.Errorf("failed to render overlay: %w", err)
+	}
+	return b.Bytes(), nil
+}
+
+var overlayTemplate = template.Must(template.New("overlay").Parse(`
+<div id="m2cv-error" style="position:fixed;top:0;left:0;right:0;z-index:99999;padding:8px 12px;background:#b00020;color:#fff;font:13px/1.4 monospace;white-space:pre-wrap;{{if not .Error}}display:none{{end}}">{{.Error}}</div>
+{{- if .Themes}}
+<form id="m2cv-switcher" style="position:fixed;bottom:12px;right:12px;z-index:99999;padding:6px 10px;background:#222;color:#fff;border-radius:6px;font:13px sans-serif">
+  theme <select name="theme" onchange="this.form.submit()">
+  {{- range .Themes}}<option value="{{.}}"{{if eq . $.Theme}} selected{{end}}>{{.}}</option>{{end}}
+  </select>
+  <a href="/compare" style="color:#9cf;margin-left:6px">compare</a>
+</form>
+{{- end}}
+<script>
+(function () {
+  var es = new EventSource("/events");
+  es.addEventListener("reload", function () { location.reload(); });
+  es.addEventListener("error", function (e) {
+    if (!e.data) return;
+    var el = document.getElementById("m2cv-error");
+    el.textContent = e.data;
+    el.style.display = "block";
+  });
+})();
+</script>
+`))
+
+var compareTemplate = template.Must(template.New("compare").Parse(`<!DOCTYPE html>
+<html>
+<head>
+<title>m2cv preview: compare themes</title>
+<style>
+  body { margin: 0; font: 13px sans-serif; }
+  .grid { display: flex; height: 100vh; }
+  .pane { flex: 1; display: flex; flex-direction: column; border-right: 1px solid #ccc; min-width: 0; }
+  .pane h2 { margin: 0; padding: 6px 10px; font-size: 13px; background: #222; }
+  .pane h2 a { color: #fff; }
+  iframe { flex: 1; border: 0; width: 100%; }
+</style>
+</head>
+<body>
+<div class="grid">
+{{- range .}}
+  <div class="pane">
+    <h2><a href="/?theme={{.}}">{{.}}</a></h2>
+    <iframe src="/?theme={{.}}&amp;embed=1"></iframe>
+  </div>
+{{- else}}
+  <p style="padding:12px">No themes installed. Run: m2cv theme add even</p>
+{{- end}}
+</div>
+</body>
+</html>
+`))
@@ -0,0 +1,169 @@
+package preview
+
+import (
+	"bufio"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/richq/m2cv/internal/watch"
+)
+
+// watchOptions polls quickly for tests.
+func watchOptions() []watch.Option {
+	return []watch.Option{watch.WithInterval(5 * time.Millisecond), watch.WithDebounce(10 * time.Millisecond)}
+}
+
+// newTestServer serves a preview whose renderer echoes the theme.
+func newTestServer(t *testing.T) (*Server, *httptest.Server, *int) {
+	t.Helper()
+	projectDir, appDir := newTestProject(t, "even", "flat")
+	renders := 0
+	s := New(Config{AppDir: appDir, ProjectDir: projectDir, Theme: "even",
+		Render: func(ctx context.Context, jsonPath, theme string) ([]byte, error) {
+			renders++
+			return []byte("<html><body><h1>" + theme + "</h1></BODY></html>"), nil
+		},
+	})
+	ts := httptest.NewServer(s.Handler())
+	t.Cleanup(ts.Close)
+	return s, ts, &renders
+}
+
+func get(t *testing.T, url string) (int, string) {
+	t.Helper()
+	resp, err := http.Get(url)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	body, _ := io.ReadAll(resp.Body)
+	return resp.StatusCode, string(body)
+}
+
+func TestHandlePage(t *testing.T) {
+	s, ts, renders := newTestServer(t)
+
+	code, body := get(t, ts.URL+"/")
+	if code != http.StatusOK || !strings.Contains(body, "<h1>even</h1>") {
+		t.Fatalf("default theme: %d %s", code, body)
+	}
+	if !strings.Contains(body, `new EventSource("/events")`) || strings.Index(body, "EventSource") > strings.Index(body, "</BODY>") {
+		t.Error("reload script should be injected before </body>")
+	}
+	if !strings.Contains(body, `<option value="flat">flat</option>`) {
+		t.Error("theme switcher should list installed themes")
+	}
+
+	_, body = get(t, ts.URL+"/?theme=flat")
+	if !strings.Contains(body, "<h1>flat</h1>") || !strings.Contains(body, `value="flat" selected`) {
+		t.Errorf("theme parameter not honoured:\n%s", body)
+	}
+
+	_, body = get(t, ts.URL+"/?theme=flat&embed=1")
+	if strings.Contains(body, "m2cv-switcher") {
+		t.Error("embedded pages should not show the switcher")
+	}
+
+	// Renders are cached until a reload
+	get(t, ts.URL+"/")
+	if *renders != 2 {
+		t.Errorf("renders = %d, want 2 (even, flat)", *renders)
+	}
+	s.Reload()
+	get(t, ts.URL+"/")
+	if *renders != 3 {
+		t.Errorf("renders = %d after reload, want 3", *renders)
+	}
+}
+
+func TestHandlePage_Errors(t *testing.T) {
+	s, ts, _ := newTestServer(t)
+
+	code, body := get(t, ts.URL+"/?theme=../../etc")
+	if code != http.StatusNotFound || !strings.Contains(body, "is not installed") {
+		t.Errorf("unknown theme: %d %s", code, body)
+	}
+
+	s.cfg.Render = func(ctx context.Context, jsonPath, theme string) ([]byte, error) {
+		return nil, io.ErrUnexpectedEOF
+	}
+	code, body = get(t, ts.URL+"/?theme=flat")
+	if code != http.StatusInternalServerError || !strings.Contains(body, "unexpected EOF") || !strings.Contains(body, "EventSource") {
+		t.Errorf("render failure should show an error page that still reloads: %d %s", code, body)
+	}
+}
+
+func TestHandlePage_LocalTheme(t *testing.T) {
+	s, ts, _ := newTestServer(t)
+	s.cfg.Themes = func() ([]string, error) {
+		return []string{"even", "file:themes/brand"}, nil
+	}
+
+	code, body := get(t, ts.URL+"/?theme=./themes/brand")
+	if code != http.StatusOK || !strings.Contains(body, "<h1>file:themes/brand</h1>") {
+		t.Errorf("local theme should be rendered by its normalized reference: %d %s", code, body)
+	}
+	if code, _ := get(t, ts.URL+"/?theme=flat"); code != http.StatusNotFound {
+		t.Errorf("themes outside the list should be rejected, got %d", code)
+	}
+}
+
+func TestHandleCompare(t *testing.T) {
+	_, ts, _ := newTestServer(t)
+
+	_, body := get(t, ts.URL+"/compare")
+	if !strings.Contains(body, `src="/?theme=even&amp;embed=1"`) || !strings.Contains(body, `src="/?theme=flat&amp;embed=1"`) {
+		t.Errorf("compare should embed every installed theme:\n%s", body)
+	}
+
+	_, body = get(t, ts.URL+"/compare?themes=flat")
+	if strings.Contains(body, "theme=even") {
+		t.Errorf("compare should honour ?themes=:\n%s", body)
+	}
+}
+
+func TestHandleEvents(t *testing.T) {
+	s, ts, _ := newTestServer(t)
+
+	resp, err := http.Get(ts.URL + "/events")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	if ct := resp.Header.Get("Content-Type"); ct != "text/event-stream" {
+		t.Errorf("Content-Type = %s", ct)
+	}
+
+	reader := bufio.NewReader(resp.Body)
+	if line, _ := reader.ReadString('\n'); !strings.HasPrefix(line, ": connected") {
+		t.Fatalf("first line = %q", line)
+	}
+	reader.ReadString('\n')
+
+	s.fail(io.ErrUnexpectedEOF)
+	var lines []string
+	for len(lines) < 2 {
+		line, err := reader.ReadString('\n')
+		if err != nil {
+			t.Fatal(err)
+		}
+		lines = append(lines, strings.TrimSpace(line))
+	}
+	if lines[0] != "event: error" || lines[1] != "data: unexpected EOF" {
+		t.Errorf("event lines = %q", lines)
+	}
+}
+
+func TestInjectBeforeBodyEnd(t *testing.T) {
+	if got := string(injectBeforeBodyEnd([]byte("<p>no body"), []byte("<x>"))); got != "<p>no body<x>" {
+		t.Errorf("without </body>: %q", got)
+	}
+	if got := string(injectBeforeBodyEnd([]byte("<body></body></html>"), []byte("<x>"))); got != "<body><x></body></html>" {
+		t.Errorf("with </body>: %q", got)
+	}
+}
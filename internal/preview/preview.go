@@ -0,0 +1,313 @@
+// Package preview serves a live HTML rendering of an application's resume.
+// Pages are rendered with resumed in any installed theme, and browsers are
+// told to reload over server-sent events whenever resume.json, the latest
+// optimized CV, the theme or the theme options change.
+package preview
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/richq/m2cv/internal/application"
+	"github.com/richq/m2cv/internal/config"
+	"github.com/richq/m2cv/internal/generator"
+	"github.com/richq/m2cv/internal/provenance"
+	themepkg "github.com/richq/m2cv/internal/theme"
+	"github.com/richq/m2cv/internal/watch"
+)
+
+// Renderer renders resume.json to HTML in a theme.
+type Renderer func(ctx context.Context, jsonPath, theme string) ([]byte, error)
+
+// Converter converts the latest optimized CV to resume.json.
+type Converter func(ctx context.Context) error
+
+// Config describes what to preview.
+type Config struct {
+	// AppDir is the application folder containing resume.json.
+	AppDir string
+	// ProjectDir contains node_modules with resumed and the themes.
+	ProjectDir string
+	// Theme is shown when the page does not ask for one.
+	Theme string
+	// ThemeOptions are the project's theme options. The application's
+	// application.yml is layered over them on every render.
+	ThemeOptions config.ThemeOptions
+	// Render renders HTML. Defaults to resumed render.
+	Render Renderer
+	// Themes lists the themes offered in the switcher. Defaults to the
+	// themes installed in ProjectDir's node_modules.
+	Themes func() ([]string, error)
+	// Convert refreshes resume.json from markdown. Nil disables conversion.
+	Convert Converter
+	// Log receives build messages. Defaults to io.Discard.
+	Log io.Writer
+}
+
+// event is a server-sent event pushed to every open page.
+type event struct {
+	name string
+	data string
+}
+
+// Server renders previews and notifies browsers of changes.
+type Server struct {
+	cfg Config
+
+	mu      sync.Mutex
+	cache   map[string][]byte
+	lastErr string
+	clients map[chan event]struct{}
+}
+
+// New creates a preview server.
+func New(cfg Config) *Server {
+	if cfg.Render == nil {
+		cfg.Render = resumedRenderer(cfg)
+	}
+	if cfg.Themes == nil {
+		cfg.Themes = func() ([]string, error) {
+			return themepkg.Installed(cfg.ProjectDir)
+		}
+	}
+	if cfg.Log == nil {
+		cfg.Log = io.Discard
+	}
+	return &Server{
+		cfg:     cfg,
+		cache:   make(map[string][]byte),
+		clients: make(map[chan event]struct{}),
+	}
+}
+
+// resumedRenderer renders through a temp file with resumed render,
+// applying the current theme options.
+func resumedRenderer(cfg Config) Renderer {
+	return func(ctx context.Context, jsonPath, theme string) ([]byte, error) {
+		exporter, err := generator.NewExporter()
+		if err != nil {
+			return nil, fmt.Errorf("failed to initialize exporter: %w", err)
+		}
+
+		style, err := themepkg.LoadStyle(cfg.ProjectDir, cfg.ThemeOptions, cfg.AppDir)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load theme options: %w", err)
+		}
+		theme, cleanup, err := style.Wrap(cfg.ProjectDir, theme)
+		if err != nil {
+			return nil, fmt.Errorf("failed to apply theme options: %w", err)
+		}
+		defer cleanup()
+
+		tmp, err := os.CreateTemp("", "m2cv-preview-*.html")
+		if err != nil {
+			return nil, fmt.Errorf("failed to create temp file: %w", err)
+		}
+		tmp.Close()
+		defer os.Remove(tmp.Name())
+
+		absJSON, err := filepath.Abs(jsonPath)
+		if err != nil {
+			return nil, fmt.Errorf("failed to resolve %s: %w", jsonPath, err)
+		}
+		if err := exporter.RenderHTML(ctx, absJSON, tmp.Name(), theme, cfg.ProjectDir); err != nil {
+			return nil, err
+		}
+		return os.ReadFile(tmp.Name())
+	}
+}
+
+// stylePaths lists the files holding the application's theme options.
+func (s *Server) stylePaths() []string {
+	return themepkg.StylePaths(s.cfg.ProjectDir, s.cfg.ThemeOptions, s.cfg.AppDir)
+}
+
+// jsonPath is the previewed resume.json.
+func (s *Server) jsonPath() string {
+	return filepath.Join(s.cfg.AppDir, "resume.json")
+}
+
+// NeedsConvert reports whether resume.json is missing or was converted
+// from something other than the latest optimized CV. A resume.json without
+// provenance (e.g. written by hand) is trusted as-is.
+func NeedsConvert(appDir string) (bool, error) {
+	latest, err := application.LatestVersionPath(appDir)
+	if err != nil {
+		return false, fmt.Errorf("failed to find optimized CV: %w", err)
+	}
+	jsonPath := filepath.Join(appDir, "resume.json")
+	if _, err := os.Stat(jsonPath); err != nil {
+		return latest != "", nil
+	}
+	if latest == "" {
+		return false, nil
+	}
+
+	meta, err := provenance.Read(jsonPath)
+	if err != nil {
+		return false, nil
+	}
+	in, ok := meta.Input(provenance.RoleOptimizedCV)
+	if !ok {
+		return false, nil
+	}
+	sum, err := provenance.HashFile(latest)
+	if err != nil {
+		return false, fmt.Errorf("failed to hash %s: %w", latest, err)
+	}
+	return filepath.Base(in.Path) != filepath.Base(latest) || in.SHA256 != sum, nil
+}
+
+// Refresh converts the latest optimized CV if resume.json is stale. Errors
+// are logged and shown in the browser rather than returned.
+func (s *Server) Refresh(ctx context.Context) {
+	if s.cfg.Convert == nil {
+		return
+	}
+	stale, err := NeedsConvert(s.cfg.AppDir)
+	if err != nil {
+		s.fail(err)
+		return
+	}
+	if !stale {
+		return
+	}
+
+	fmt.Fprintf(s.cfg.Log, "[%s] Converting latest CV to resume.json\n", time.Now().Format("15:04:05"))
+	if err := s.cfg.Convert(ctx); err != nil {
+		if ctx.Err() == nil {
+			s.fail(err)
+		}
+		return
+	}
+	s.Reload()
+}
+
+// fail logs err and shows it on every open page.
+func (s *Server) fail(err error) {
+	fmt.Fprintf(s.cfg.Log, "error: %v\n", err)
+	s.mu.Lock()
+	s.lastErr = err.Error()
+	s.mu.Unlock()
+	s.broadcast(event{name: "error", data: err.Error()})
+}
+
+// Reload drops cached renders and tells every open page to reload.
+func (s *Server) Reload() {
+	s.mu.Lock()
+	s.cache = make(map[string][]byte)
+	s.lastErr = ""
+	s.mu.Unlock()
+	s.broadcast(event{name: "reload"})
+}
+
+// subscribe registers a channel that receives every broadcast event.
+func (s *Server) subscribe() chan event {
+	ch := make(chan event, 4)
+	s.mu.Lock()
+	s.clients[ch] = struct{}{}
+	s.mu.Unlock()
+	return ch
+}
+
+// unsubscribe stops delivering events to ch.
+func (s *Server) unsubscribe(ch chan event) {
+	s.mu.Lock()
+	delete(s.clients, ch)
+	s.mu.Unlock()
+}
+
+// broadcast sends ev to every connected page without blocking.
+func (s *Server) broadcast(ev event) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for ch := range s.clients {
+		select {
+		case ch <- ev:
+		default:
+		}
+	}
+}
+
+// Watch rebuilds and reloads on change until ctx is cancelled: a changed
+// optimized CV is converted again, a changed resume.json, theme or theme
+// option file reloads the pages.
+func (s *Server) Watch(ctx context.Context, opts ...watch.Option) error {
+	themeDir := themepkg.Dir(s.cfg.ProjectDir, s.cfg.Theme)
+	paths := func() []string {
+		paths := append([]string{s.jsonPath(), themeDir}, s.stylePaths()...)
+		if latest, err := application.LatestVersionPath(s.cfg.AppDir); err == nil && latest != "" {
+			paths = append(paths, latest)
+		}
+		return paths
+	}
+
+	w := watch.New(paths, opts...)
+	return w.Run(ctx, nil, func(changed []string) {
+		var reload, convert bool
+		for _, p := range changed {
+			if p == s.jsonPath() || p == themeDir || slices.Contains(s.stylePaths(), p) {
+				reload = true
+			} else {
+				convert = true
+			}
+		}
+		if reload {
+			fmt.Fprintf(s.cfg.Log, "[%s] Reloading preview\n", time.Now().Format("15:04:05"))
+			s.Reload()
+		}
+		if convert {
+			s.Refresh(ctx)
+		}
+	})
+}
+
+// ListenAndServe serves the preview on addr until ctx is cancelled, then
+// shuts down gracefully. ready, if set, is called with the page URL once
+// the listener is bound.
+func (s *Server) ListenAndServe(ctx context.Context, addr string, ready func(url string)) error {
+	ln, err := net.Listen("tcp", addr)
+	if err != nil {
+		return fmt.Errorf("failed to listen on %s: %w", addr, err)
+	}
+
+	// Open event streams only end when their request context does, so
+	// requests get a context that is cancelled on shutdown
+	baseCtx, cancelRequests := context.WithCancel(context.Background())
+	defer cancelRequests()
+	httpServer := &http.Server{
+		Handler:     s.Handler(),
+		BaseContext: func(net.Listener) context.Context { return baseCtx },
+	}
+
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- httpServer.Serve(ln)
+	}()
+	if ready != nil {
+		ready("http://" + ln.Addr().String() + "/")
+	}
+
+	select {
+	case err := <-errCh:
+		return fmt.Errorf("preview server failed: %w", err)
+	case <-ctx.Done():
+	}
+
+	cancelRequests()
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := httpServer.Shutdown(shutdownCtx); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return fmt.Errorf("failed to shut down preview server: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,214 @@
+package preview
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/richq/m2cv/internal/provenance"
+)
+
+// newTestProject creates a project with the given themes installed and an
+// application folder, returning the project and application dirs.
+func newTestProject(t *testing.T, themes ...string) (string, string) {
+	t.Helper()
+	projectDir := t.TempDir()
+	for _, theme := range themes {
+		if err := os.MkdirAll(filepath.Join(projectDir, "node_modules", "jsonresume-theme-"+theme), 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	appDir := filepath.Join(projectDir, "applications", "acme")
+	if err := os.MkdirAll(appDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	return projectDir, appDir
+}
+
+func writeFile(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// writeConverted writes resume.json with provenance pointing at cvPath.
+func writeConverted(t *testing.T, appDir, cvPath string) {
+	t.Helper()
+	jsonPath := filepath.Join(appDir, "resume.json")
+	writeFile(t, jsonPath, `{"basics":{"name":"Jane"}}`)
+	content, err := os.ReadFile(cvPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	meta := &provenance.Metadata{Command: "generate"}
+	meta.AddInput(provenance.RoleOptimizedCV, cvPath, content)
+	if err := provenance.Write(jsonPath, meta); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestNeedsConvert(t *testing.T) {
+	_, appDir := newTestProject(t)
+
+	if stale, err := NeedsConvert(appDir); err != nil || stale {
+		t.Errorf("no CV and no resume.json: stale=%v err=%v", stale, err)
+	}
+
+	v1 := filepath.Join(appDir, "optimized-cv-1.md")
+	writeFile(t, v1, "# v1")
+	if stale, _ := NeedsConvert(appDir); !stale {
+		t.Error("missing resume.json should need conversion")
+	}
+
+	writeConverted(t, appDir, v1)
+	if stale, _ := NeedsConvert(appDir); stale {
+		t.Error("resume.json from the latest CV should be fresh")
+	}
+
+	writeFile(t, v1, "# v1 edited")
+	if stale, _ := NeedsConvert(appDir); !stale {
+		t.Error("edited CV should need conversion")
+	}
+
+	writeConverted(t, appDir, v1)
+	writeFile(t, filepath.Join(appDir, "optimized-cv-2.md"), "# v1 edited")
+	if stale, _ := NeedsConvert(appDir); !stale {
+		t.Error("a newer version should need conversion")
+	}
+
+	// Hand-written resume.json without provenance is trusted
+	os.Remove(provenance.SidecarPath(filepath.Join(appDir, "resume.json")))
+	if stale, _ := NeedsConvert(appDir); stale {
+		t.Error("resume.json without provenance should be used as-is")
+	}
+}
+
+func TestServer_Refresh(t *testing.T) {
+	projectDir, appDir := newTestProject(t, "even")
+	v1 := filepath.Join(appDir, "optimized-cv-1.md")
+	writeFile(t, v1, "# v1")
+
+	converted := 0
+	convertErr := errors.New("claude down")
+	s := New(Config{AppDir: appDir, ProjectDir: projectDir, Theme: "even",
+		Convert: func(ctx context.Context) error {
+			converted++
+			if convertErr != nil {
+				return convertErr
+			}
+			writeConverted(t, appDir, v1)
+			return nil
+		},
+	})
+
+	s.Refresh(context.Background())
+	if converted != 1 || s.currentError() != "claude down" {
+		t.Fatalf("failed conversion: converted=%d err=%q", converted, s.currentError())
+	}
+
+	convertErr = nil
+	s.Refresh(context.Background())
+	if converted != 2 || s.currentError() != "" {
+		t.Errorf("successful conversion should clear the error: converted=%d err=%q", converted, s.currentError())
+	}
+
+	s.Refresh(context.Background())
+	if converted != 2 {
+		t.Error("fresh resume.json should not be converted again")
+	}
+}
+
+func TestServer_WatchReloadsAndConverts(t *testing.T) {
+	projectDir, appDir := newTestProject(t, "even")
+	v1 := filepath.Join(appDir, "optimized-cv-1.md")
+	writeFile(t, v1, "# v1")
+	writeConverted(t, appDir, v1)
+
+	converted := make(chan struct{}, 4)
+	s := New(Config{AppDir: appDir, ProjectDir: projectDir, Theme: "even",
+		Render: func(ctx context.Context, jsonPath, theme string) ([]byte, error) { return []byte("x"), nil },
+		Convert: func(ctx context.Context) error {
+			writeConverted(t, appDir, v1)
+			converted <- struct{}{}
+			return nil
+		},
+	})
+	events := s.subscribe()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go s.Watch(ctx, watchOptions()...)
+	time.Sleep(30 * time.Millisecond)
+
+	writeFile(t, v1, "# v1 edited")
+	select {
+	case <-converted:
+	case <-time.After(2 * time.Second):
+		t.Fatal("edited CV was not converted")
+	}
+	expectEvent(t, events, "reload")
+
+	writeFile(t, filepath.Join(projectDir, "node_modules", "jsonresume-theme-even", "index.js"), "x")
+	expectEvent(t, events, "reload")
+
+	// Theme options only re-render, they never convert
+	writeFile(t, filepath.Join(appDir, "application.yml"), "theme_options:\n  css: brand.css\n")
+	expectEvent(t, events, "reload")
+	writeFile(t, filepath.Join(appDir, "brand.css"), "h1 { color: red; }")
+	expectEvent(t, events, "reload")
+	select {
+	case <-converted:
+		t.Error("theme option changes should not convert")
+	default:
+	}
+}
+
+func expectEvent(t *testing.T, events chan event, name string) {
+	t.Helper()
+	select {
+	case ev := <-events:
+		if ev.name != name {
+			t.Errorf("event = %q, want %q", ev.name, name)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatalf("timed out waiting for %s event", name)
+	}
+}
+
+func TestListenAndServe_Shutdown(t *testing.T) {
+	projectDir, appDir := newTestProject(t, "even")
+	s := New(Config{AppDir: appDir, ProjectDir: projectDir, Theme: "even"})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	urls := make(chan string, 1)
+	done := make(chan error, 1)
+	go func() { done <- s.ListenAndServe(ctx, "127.0.0.1:0", func(url string) { urls <- url }) }()
+
+	url := <-urls
+	if !strings.HasPrefix(url, "http://127.0.0.1:") {
+		t.Errorf("url = %s", url)
+	}
+
+	// An open event stream must not block shutdown
+	resp, err := http.Get(url + "events")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+
+	cancel()
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Errorf("ListenAndServe() = %v", err)
+		}
+	case <-time.After(3 * time.Second):
+		t.Fatal("shutdown hung")
+	}
+}
@@ -0,0 +1,192 @@
+// Package progress renders live feedback for long-running Claude calls.
+// On a terminal it draws a spinner with running token counts; otherwise it
+// prints plain periodic status lines suitable for logs and CI.
+package progress
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/richq/m2cv/internal/executor"
+)
+
+// spinnerFrames are the frames drawn by the terminal spinner.
+var spinnerFrames = []string{"⠋", "⠙", "⠹", "⠸", "⠼", "⠴", "⠦", "⠧", "⠇", "⠏"}
+
+// Default intervals for redraws and status lines.
+const (
+	spinnerInterval = 100 * time.Millisecond
+	statusInterval  = 10 * time.Second
+)
+
+// IsTerminal reports whether w is a character device (an interactive terminal).
+func IsTerminal(w io.Writer) bool {
+	f, ok := w.(*os.File)
+	if !ok {
+		return false
+	}
+	fi, err := f.Stat()
+	if err != nil {
+		return false
+	}
+	return fi.Mode()&os.ModeCharDevice != 0
+}
+
+// New returns a reporter that writes progress for label to w.
+// A spinner is used when w is a terminal, periodic status lines otherwise.
+func New(w io.Writer, label string) executor.ProgressReporter {
+	if IsTerminal(w) {
+		return NewSpinner(w, label)
+	}
+	return NewStatusLines(w, label, statusInterval)
+}
+
+// tracker holds the counters shared by all reporters.
+type tracker struct {
+	mu      sync.Mutex
+	started time.Time
+	chars   int
+	tokens  int
+}
+
+// record updates the counters from a stream event.
+func (t *tracker) record(ev executor.StreamEvent) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.chars += len(ev.Text)
+	if ev.OutputTokens > t.tokens {
+		t.tokens = ev.OutputTokens
+	}
+}
+
+// summary formats the elapsed time and counters, e.g. "12s, 340 tokens".
+func (t *tracker) summary() string {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	elapsed := time.Since(t.started).Round(time.Second)
+	if t.tokens > 0 {
+		return fmt.Sprintf("%s, %d tokens", elapsed, t.tokens)
+	}
+	return fmt.Sprintf("%s, %d chars", elapsed, t.chars)
+}
+
+// Spinner draws an animated single-line status on a terminal.
+type Spinner struct {
+	tracker
+	w     io.Writer
+	label string
+	stop  chan struct{}
+	done  sync.WaitGroup
+	once  sync.Once
+}
+
+// NewSpinner creates and starts a terminal spinner.
+func NewSpinner(w io.Writer, label string) *Spinner {
+	s := &Spinner{
+		tracker: tracker{started: time.Now()},
+		w:       w,
+		label:   label,
+		stop:    make(chan struct{}),
+	}
+	s.done.Add(1)
+	go s.run()
+	return s
+}
+
+// run redraws the spinner until stopped.
+func (s *Spinner) run() {
+	defer s.done.Done()
+	ticker := time.NewTicker(spinnerInterval)
+	defer ticker.Stop()
+
+	for frame := 0; ; frame++ {
+		fmt.Fprintf(s.w, "\r\033[K%s %s... (%s)", spinnerFrames[frame%len(spinnerFrames)], s.label, s.summary())
+		select {
+		case <-s.stop:
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Event records a stream event.
+func (s *Spinner) Event(ev executor.StreamEvent) {
+	s.record(ev)
+}
+
+// Done stops the spinner and prints a final line.
+func (s *Spinner) Done(err error) {
+	s.once.Do(func() {
+		close(s.stop)
+		s.done.Wait()
+		status := "done"
+		if err != nil {
+			status = "failed"
+		}
+		fmt.Fprintf(s.w, "\r\033[K%s %s (%s)\n", s.label, status, s.summary())
+	})
+}
+
+// StatusLines prints a plain status line at a fixed interval.
+// It is used when output is not a terminal (pipes, CI logs).
+type StatusLines struct {
+	tracker
+	w        io.Writer
+	label    string
+	interval time.Duration
+	stop     chan struct{}
+	done     sync.WaitGroup
+	once     sync.Once
+}
+
+// NewStatusLines creates and starts a periodic status line reporter.
+func NewStatusLines(w io.Writer, label string, interval time.Duration) *StatusLines {
+	s := &StatusLines{
+		tracker:  tracker{started: time.Now()},
+		w:        w,
+		label:    label,
+		interval: interval,
+		stop:     make(chan struct{}),
+	}
+	fmt.Fprintf(w, "%s...\n", label)
+	s.done.Add(1)
+	go s.run()
+	return s
+}
+
+// run prints a status line every interval until stopped.
+func (s *StatusLines) run() {
+	defer s.done.Done()
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-s.stop:
+			return
+		case <-ticker.C:
+			fmt.Fprintf(s.w, "%s: still running (%s)\n", s.label, s.summary())
+		}
+	}
+}
+
+// Event records a stream event.
+func (s *StatusLines) Event(ev executor.StreamEvent) {
+	s.record(ev)
+}
+
+// Done stops the reporter and prints a final line.
+func (s *StatusLines) Done(err error) {
+	s.once.Do(func() {
+		close(s.stop)
+		s.done.Wait()
+		status := "done"
+		if err != nil {
+			status = "failed"
+		}
+		fmt.Fprintf(s.w, "%s: %s (%s)\n", s.label, status, s.summary())
+	})
+}
@@ -0,0 +1,78 @@
+package progress
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/richq/m2cv/internal/executor"
+)
+
+// syncBuffer is a bytes.Buffer safe for concurrent writes from reporters.
+type syncBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *syncBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *syncBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.String()
+}
+
+func TestNew_NonTerminalUsesStatusLines(t *testing.T) {
+	var buf syncBuffer
+	reporter := New(&buf, "Optimizing")
+	reporter.Done(nil)
+
+	if _, ok := reporter.(*StatusLines); !ok {
+		t.Errorf("New() on a non-terminal = %T, want *StatusLines", reporter)
+	}
+}
+
+func TestStatusLines_PeriodicAndFinal(t *testing.T) {
+	var buf syncBuffer
+	s := NewStatusLines(&buf, "Optimizing", 20*time.Millisecond)
+	s.Event(executor.StreamEvent{Text: "hello", OutputTokens: 42})
+	time.Sleep(70 * time.Millisecond)
+	s.Done(nil)
+	s.Done(nil) // second call is a no-op
+
+	out := buf.String()
+	if !strings.HasPrefix(out, "Optimizing...\n") {
+		t.Errorf("output should start with label, got %q", out)
+	}
+	if !strings.Contains(out, "still running") {
+		t.Errorf("output should contain periodic status, got %q", out)
+	}
+	if !strings.Contains(out, "42 tokens") {
+		t.Errorf("output should report token count, got %q", out)
+	}
+	if strings.Count(out, "Optimizing: done") != 1 {
+		t.Errorf("output should contain exactly one final line, got %q", out)
+	}
+}
+
+func TestSpinner_ReportsFailure(t *testing.T) {
+	var buf syncBuffer
+	s := NewSpinner(&buf, "Converting")
+	s.Event(executor.StreamEvent{Text: "abc"})
+	s.Done(errors.New("boom"))
+
+	out := buf.String()
+	if !strings.Contains(out, "Converting failed") {
+		t.Errorf("output should report failure, got %q", out)
+	}
+	if !strings.Contains(out, "3 chars") {
+		t.Errorf("output should fall back to char count, got %q", out)
+	}
+}
@@ -0,0 +1,204 @@
+// Package provenance records how each generated artifact was produced.
+// A sidecar metadata file (e.g. optimized-cv-3.meta.json) is written next
+// to every optimized CV and export, capturing input hashes, prompt, model,
+// mode, timestamps and the m2cv version.
+package provenance
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// SidecarSuffix is appended to an artifact's name (minus extension) to form
+// its sidecar path.
+const SidecarSuffix = ".meta.json"
+
+// Input roles recorded in Metadata.Inputs.
+const (
+	RoleBaseCV         = "base_cv"
+	RoleJobDescription = "job_description"
+	RoleOptimizedCV    = "optimized_cv"
+)
+
+// File identifies a file by path and content hash.
+type File struct {
+	// Role describes what the file was used for (inputs only).
+	Role string `json:"role,omitempty"`
+	// Path is the file path as it was used by the command.
+	Path string `json:"path"`
+	// SHA256 is the hex-encoded SHA-256 of the file contents.
+	SHA256 string `json:"sha256"`
+}
+
+// Prompt identifies the prompt template used to produce an artifact.
+type Prompt struct {
+	// Name is the embedded prompt name (e.g. "optimize-ats").
+	Name string `json:"name"`
+	// SHA256 is the hex-encoded SHA-256 of the prompt template.
+	SHA256 string `json:"sha256,omitempty"`
+}
+
+// Metadata is the content of a sidecar file.
+type Metadata struct {
+	// Command is the m2cv command that produced the artifact.
+	Command string `json:"command"`
+	// ToolVersion is the m2cv version (from cmd/version.go build info).
+	ToolVersion string `json:"tool_version"`
+	// ToolCommit is the m2cv commit hash, when known.
+	ToolCommit string `json:"tool_commit,omitempty"`
+	// Inputs lists the files the artifact was derived from.
+	Inputs []File `json:"inputs"`
+	// Outputs lists the files produced, with their hashes at creation time.
+	Outputs []File `json:"outputs"`
+	// Prompt is the prompt template used, if any.
+	Prompt *Prompt `json:"prompt,omitempty"`
+	// Model is the Claude model requested (empty means CLI default).
+	Model string `json:"model,omitempty"`
+	// ATSMode records whether ATS optimization was enabled.
+	ATSMode bool `json:"ats_mode"`
+	// Interactive records whether the artifact came from an interactive session.
+	Interactive bool `json:"interactive"`
+	// ModelOutputSHA256 is the hash of Claude's output when the artifact
+	// differs from it, e.g. a rebased CV merged with manual edits.
+	ModelOutputSHA256 string `json:"model_output_sha256,omitempty"`
+	// Theme is the JSON Resume theme used for exports.
+	Theme string `json:"theme,omitempty"`
+	// Steps records the inputs of each build step (e.g. "convert",
+	// "export"), so a step whose inputs are unchanged can be skipped.
+	Steps map[string]Fingerprint `json:"steps,omitempty"`
+	// StartedAt is when the command began producing the artifact.
+	StartedAt time.Time `json:"started_at"`
+	// CreatedAt is when the artifact was written.
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// Fingerprint maps the names of a build step's inputs to their content
+// hashes or values.
+type Fingerprint map[string]string
+
+// Changed returns the sorted names of inputs that differ between f and
+// prev, including inputs only one of them has.
+func (f Fingerprint) Changed(prev Fingerprint) []string {
+	var changed []string
+	for name, value := range f {
+		if old, ok := prev[name]; !ok || old != value {
+			changed = append(changed, name)
+		}
+	}
+	for name := range prev {
+		if _, ok := f[name]; !ok {
+			changed = append(changed, name)
+		}
+	}
+	sort.Strings(changed)
+	return changed
+}
+
+// SetStep records the inputs a build step ran with.
+func (m *Metadata) SetStep(name string, inputs Fingerprint) {
+	if m.Steps == nil {
+		m.Steps = make(map[string]Fingerprint)
+	}
+	m.Steps[name] = inputs
+}
+
+// HashBytes returns the hex-encoded SHA-256 of data.
+func HashBytes(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+// HashFile returns the hex-encoded SHA-256 of the file at path.
+func HashFile(path string) (string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+	return HashBytes(data), nil
+}
+
+// NewFile describes a file whose contents are already in memory.
+func NewFile(role, path string, content []byte) File {
+	return File{Role: role, Path: path, SHA256: HashBytes(content)}
+}
+
+// AddInput records an input file whose contents are already in memory.
+func (m *Metadata) AddInput(role, path string, content []byte) {
+	m.Inputs = append(m.Inputs, NewFile(role, path, content))
+}
+
+// AddOutput hashes the file at path and records it as an output.
+func (m *Metadata) AddOutput(path string) error {
+	sum, err := HashFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to hash output %s: %w", path, err)
+	}
+	m.Outputs = append(m.Outputs, File{Path: path, SHA256: sum})
+	return nil
+}
+
+// Input returns the first recorded input with the given role.
+func (m *Metadata) Input(role string) (File, bool) {
+	for _, f := range m.Inputs {
+		if f.Role == role {
+			return f, true
+		}
+	}
+	return File{}, false
+}
+
+// ModelOutput returns the hash of Claude's output for the artifact: the
+// recorded model output, or the first output's hash.
+func (m *Metadata) ModelOutput() string {
+	if m.ModelOutputSHA256 != "" {
+		return m.ModelOutputSHA256
+	}
+	if len(m.Outputs) > 0 {
+		return m.Outputs[0].SHA256
+	}
+	return ""
+}
+
+// SidecarPath returns the sidecar path for an artifact, replacing the
+// artifact's extension: optimized-cv-3.md -> optimized-cv-3.meta.json.
+func SidecarPath(artifactPath string) string {
+	return strings.TrimSuffix(artifactPath, filepath.Ext(artifactPath)) + SidecarSuffix
+}
+
+// Write stamps CreatedAt (if unset) and writes the sidecar for artifactPath.
+func Write(artifactPath string, m *Metadata) error {
+	if m.CreatedAt.IsZero() {
+		m.CreatedAt = time.Now().UTC()
+	}
+
+	data, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal metadata: %w", err)
+	}
+
+	if err := os.WriteFile(SidecarPath(artifactPath), append(data, '\n'), 0644); err != nil {
+		return fmt.Errorf("failed to write metadata: %w", err)
+	}
+	return nil
+}
+
+// Read loads the sidecar for artifactPath.
+func Read(artifactPath string) (*Metadata, error) {
+	data, err := os.ReadFile(SidecarPath(artifactPath))
+	if err != nil {
+		return nil, err
+	}
+
+	var m Metadata
+	if err := json.Unmarshal(data, &m); err != nil {
+		return nil, fmt.Errorf("invalid metadata %s: %w", SidecarPath(artifactPath), err)
+	}
+	return &m, nil
+}
@@ -0,0 +1,160 @@
+package provenance
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestSidecarPath(t *testing.T) {
+	tests := []struct {
+		artifact string
+		want     string
+	}{
+		{"applications/acme/optimized-cv-3.md", "applications/acme/optimized-cv-3.meta.json"},
+		{"applications/acme/resume.pdf", "applications/acme/resume.meta.json"},
+		{"noext", "noext.meta.json"},
+	}
+
+	for _, tt := range tests {
+		if got := SidecarPath(tt.artifact); got != tt.want {
+			t.Errorf("SidecarPath(%q) = %q, want %q", tt.artifact, got, tt.want)
+		}
+	}
+}
+
+func TestWriteAndRead(t *testing.T) {
+	dir := t.TempDir()
+	artifact := filepath.Join(dir, "optimized-cv-1.md")
+	if err := os.WriteFile(artifact, []byte("# CV"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	meta := &Metadata{
+		Command:     "optimize",
+		ToolVersion: "v1.2.3",
+		Prompt:      &Prompt{Name: "optimize", SHA256: HashBytes([]byte("template"))},
+		Model:       "sonnet",
+		ATSMode:     true,
+		StartedAt:   time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC),
+	}
+	meta.AddInput(RoleBaseCV, "base-cv.md", []byte("base"))
+	if err := meta.AddOutput(artifact); err != nil {
+		t.Fatalf("AddOutput() error = %v", err)
+	}
+	if err := Write(artifact, meta); err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+
+	got, err := Read(artifact)
+	if err != nil {
+		t.Fatalf("Read() error = %v", err)
+	}
+	if got.Command != "optimize" || got.ToolVersion != "v1.2.3" || got.Model != "sonnet" || !got.ATSMode {
+		t.Errorf("metadata not round-tripped: %+v", got)
+	}
+	if got.CreatedAt.IsZero() {
+		t.Error("Write should stamp CreatedAt")
+	}
+	if got.Prompt == nil || got.Prompt.Name != "optimize" {
+		t.Errorf("prompt not round-tripped: %+v", got.Prompt)
+	}
+
+	base, ok := got.Input(RoleBaseCV)
+	if !ok || base.SHA256 != HashBytes([]byte("base")) {
+		t.Errorf("base CV input = %+v, %v", base, ok)
+	}
+	if _, ok := got.Input(RoleJobDescription); ok {
+		t.Error("Input should report missing roles")
+	}
+	if len(got.Outputs) != 1 || got.Outputs[0].SHA256 != HashBytes([]byte("# CV")) {
+		t.Errorf("outputs = %+v", got.Outputs)
+	}
+}
+
+func TestRead_Missing(t *testing.T) {
+	_, err := Read(filepath.Join(t.TempDir(), "resume.pdf"))
+	if !os.IsNotExist(err) {
+		t.Errorf("Read() error = %v, want not-exist", err)
+	}
+}
+
+func TestAddOutput_Missing(t *testing.T) {
+	meta := &Metadata{}
+	if err := meta.AddOutput(filepath.Join(t.TempDir(), "missing.pdf")); err == nil {
+		t.Error("AddOutput() should fail for a missing file")
+	}
+}
+
+func TestHashFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "f.txt")
+	if err := os.WriteFile(path, []byte("abc"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	got, err := HashFile(path)
+	if err != nil {
+		t.Fatalf("HashFile() error = %v", err)
+	}
+	// SHA-256 of "abc"
+	want := "ba7816bf8f01cfea414140de5dae2223b00361a396177a9cb410ff61f20015ad"
+	if got != want {
+		t.Errorf("HashFile() = %s, want %s", got, want)
+	}
+}
+
+func TestFingerprintChanged(t *testing.T) {
+	prev := Fingerprint{"cv": "a", "model": "sonnet", "args": "--x"}
+	cur := Fingerprint{"cv": "b", "model": "sonnet", "prompt": "p"}
+
+	got := cur.Changed(prev)
+	want := []string{"args", "cv", "prompt"}
+	if len(got) != len(want) {
+		t.Fatalf("Changed() = %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("Changed() = %v, want %v", got, want)
+		}
+	}
+	if changed := cur.Changed(cur); len(changed) != 0 {
+		t.Errorf("Changed(self) = %v", changed)
+	}
+}
+
+func TestModelOutput(t *testing.T) {
+	meta := &Metadata{Outputs: []File{{Path: "optimized-cv-2.md", SHA256: "merged"}}}
+	if got := meta.ModelOutput(); got != "merged" {
+		t.Errorf("ModelOutput() = %q, want the output hash", got)
+	}
+	meta.ModelOutputSHA256 = "claude"
+	if got := meta.ModelOutput(); got != "claude" {
+		t.Errorf("ModelOutput() = %q, want the recorded model output", got)
+	}
+	if got := (&Metadata{}).ModelOutput(); got != "" {
+		t.Errorf("ModelOutput() without outputs = %q", got)
+	}
+}
+
+func TestSnapshots(t *testing.T) {
+	projectDir := t.TempDir()
+	sum, err := SaveSnapshot(projectDir, []byte("# Base CV"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sum != HashBytes([]byte("# Base CV")) {
+		t.Errorf("SaveSnapshot() = %s", sum)
+	}
+	if again, err := SaveSnapshot(projectDir, []byte("# Base CV")); err != nil || again != sum {
+		t.Errorf("saving twice = %s, %v", again, err)
+	}
+
+	data, err := LoadSnapshot(projectDir, sum)
+	if err != nil || string(data) != "# Base CV" {
+		t.Errorf("LoadSnapshot() = %q, %v", data, err)
+	}
+	if _, err := LoadSnapshot(projectDir, HashBytes([]byte("other"))); !errors.Is(err, ErrNoSnapshot) {
+		t.Errorf("missing snapshot error = %v", err)
+	}
+}
@@ -0,0 +1,66 @@
+package provenance
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// SnapshotDir is where snapshots are kept, relative to the project
+// directory. Snapshots are named by the SHA-256 recorded in the sidecars,
+// so the base CV an optimized version was derived from can be compared
+// with the current one after it changed, and Claude's original output can
+// be recovered after a version was edited by hand.
+const SnapshotDir = ".m2cv/snapshots"
+
+// ErrNoSnapshot is returned when no snapshot exists for a hash.
+var ErrNoSnapshot = errors.New("no snapshot")
+
+// snapshotPath returns where the snapshot for sum is stored.
+func snapshotPath(projectDir, sum string) string {
+	return filepath.Join(projectDir, filepath.FromSlash(SnapshotDir), sum+".md")
+}
+
+// SaveSnapshot stores content under its hash in projectDir's snapshot
+// directory and returns the hash. Existing snapshots are left alone.
+func SaveSnapshot(projectDir string, content []byte) (string, error) {
+	sum := HashBytes(content)
+	path := snapshotPath(projectDir, sum)
+	if _, err := os.Stat(path); err == nil {
+		return sum, nil
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return "", fmt.Errorf("failed to create snapshot directory: %w", err)
+	}
+
+	// Write to a temp file first so a concurrent reader never sees a partial snapshot
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".snapshot-*")
+	if err != nil {
+		return "", fmt.Errorf("failed to write snapshot: %w", err)
+	}
+	_, err = tmp.Write(content)
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Rename(tmp.Name(), path)
+	}
+	if err != nil {
+		os.Remove(tmp.Name())
+		return "", fmt.Errorf("failed to write snapshot: %w", err)
+	}
+	return sum, nil
+}
+
+// LoadSnapshot returns the snapshot with the given hash, or ErrNoSnapshot.
+func LoadSnapshot(projectDir, sum string) ([]byte, error) {
+	data, err := os.ReadFile(snapshotPath(projectDir, sum))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("%w of base CV %.12s", ErrNoSnapshot, sum)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read snapshot: %w", err)
+	}
+	return data, nil
+}
@@ -0,0 +1,118 @@
+// Package scoring measures how well a CV covers the keywords of a job
+// description. It is a cheap, deterministic heuristic used to spot gaps
+// between versions; it does not call Claude.
+package scoring
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// DefaultMaxKeywords is the number of job description keywords scored.
+const DefaultMaxKeywords = 30
+
+// Result is the keyword coverage of a CV against a job description.
+type Result struct {
+	// Score is the percentage (0-100) of keywords found in the CV.
+	Score int
+	// Matched lists keywords present in the CV, most frequent first.
+	Matched []string
+	// Missing lists keywords absent from the CV, most frequent first.
+	Missing []string
+}
+
+// tokenPattern matches words including common tech punctuation
+// (c++, c#, node.js, ci/cd).
+var tokenPattern = regexp.MustCompile(`[a-z0-9][a-z0-9+#./-]*[a-z0-9+#]|[a-z0-9]`)
+
+// stopwords are frequent words that carry no signal in job descriptions.
+var stopwords = map[string]bool{}
+
+func init() {
+	for _, w := range strings.Fields(`a about above across after again all also am an and any are as at be
+		because been before being below between both but by can could did do does doing down during each
+		etc few for from further had has have having he her here hers him his how i if in into is it its
+		itself just me more most my no nor not now of off on once only or other our ours out over own same
+		she should so some such than that the their theirs them then there these they this those through
+		to too under until up very was we were what when where which while who whom why will with would
+		you your yours able ability work working works role team teams join looking including within
+		well new strong experience years year plus must like using used use help make want based per us
+		company candidate candidates position responsibilities requirements required preferred ideal
+		opportunity skills knowledge excellent good great`) {
+		stopwords[w] = true
+	}
+}
+
+// Keywords extracts up to max distinct keywords from text, ordered by
+// frequency (ties broken alphabetically).
+func Keywords(text string, max int) []string {
+	counts := make(map[string]int)
+	for _, tok := range tokenize(text) {
+		counts[tok]++
+	}
+
+	keywords := make([]string, 0, len(counts))
+	for k := range counts {
+		keywords = append(keywords, k)
+	}
+	sort.Slice(keywords, func(i, j int) bool {
+		if counts[keywords[i]] != counts[keywords[j]] {
+			return counts[keywords[i]] > counts[keywords[j]]
+		}
+		return keywords[i] < keywords[j]
+	})
+
+	if len(keywords) > max {
+		keywords = keywords[:max]
+	}
+	return keywords
+}
+
+// Score measures the coverage of the job description's top keywords in cv.
+func Score(cv, jobDescription string) Result {
+	keywords := Keywords(jobDescription, DefaultMaxKeywords)
+
+	present := make(map[string]bool)
+	for _, tok := range tokenize(cv) {
+		present[tok] = true
+	}
+
+	var r Result
+	for _, k := range keywords {
+		if present[k] {
+			r.Matched = append(r.Matched, k)
+		} else {
+			r.Missing = append(r.Missing, k)
+		}
+	}
+	if len(keywords) > 0 {
+		r.Score = len(r.Matched) * 100 / len(keywords)
+	}
+	return r
+}
+
+// tokenize lowercases text and returns its non-stopword tokens.
+// Single characters and bare numbers are dropped; trailing sentence
+// punctuation is trimmed.
+func tokenize(text string) []string {
+	var tokens []string
+	for _, tok := range tokenPattern.FindAllString(strings.ToLower(text), -1) {
+		tok = strings.TrimRight(tok, "./-")
+		if len(tok) < 2 || stopwords[tok] || isNumber(tok) {
+			continue
+		}
+		tokens = append(tokens, tok)
+	}
+	return tokens
+}
+
+// isNumber reports whether s consists only of digits.
+func isNumber(s string) bool {
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,57 @@
+package scoring
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestKeywords(t *testing.T) {
+	jd := "We are looking for a Go engineer. Go, Kubernetes and CI/CD experience required. Kubernetes is a plus. Node.js welcome."
+
+	got := Keywords(jd, 3)
+	want := []string{"go", "kubernetes", "ci/cd"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Keywords() = %v, want %v", got, want)
+	}
+
+	all := Keywords(jd, 100)
+	for _, stop := range []string{"we", "are", "for", "a", "looking", "required"} {
+		for _, k := range all {
+			if k == stop {
+				t.Errorf("stopword %q should be excluded", stop)
+			}
+		}
+	}
+	found := false
+	for _, k := range all {
+		if k == "node.js" {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("tech token node.js should be kept, got %v", all)
+	}
+}
+
+func TestScore(t *testing.T) {
+	jd := "Go Go Go Kubernetes Kubernetes Terraform"
+	cv := "# Skills\n- Go\n- Kubernetes\n"
+
+	r := Score(cv, jd)
+	if r.Score != 66 {
+		t.Errorf("Score = %d, want 66", r.Score)
+	}
+	if !reflect.DeepEqual(r.Matched, []string{"go", "kubernetes"}) {
+		t.Errorf("Matched = %v", r.Matched)
+	}
+	if !reflect.DeepEqual(r.Missing, []string{"terraform"}) {
+		t.Errorf("Missing = %v", r.Missing)
+	}
+}
+
+func TestScore_EmptyJobDescription(t *testing.T) {
+	r := Score("# CV", "")
+	if r.Score != 0 || len(r.Matched) != 0 || len(r.Missing) != 0 {
+		t.Errorf("empty job description should score 0 with no keywords, got %+v", r)
+	}
+}
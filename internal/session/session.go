@@ -0,0 +1,239 @@
+// Package session records interactive optimize sessions so they can be
+// reviewed and resumed. Each application keeps a log of sessions and a copy
+// of every transcript in its sessions/ folder; the transcripts themselves are
+// written by the claude CLI under its own config directory.
+package session
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Dir is the folder inside an application that holds session files.
+const Dir = "sessions"
+
+// LogFile is the append-only session log inside Dir.
+const LogFile = "sessions.jsonl"
+
+// ErrNoSession is returned when an application has no recorded session.
+var ErrNoSession = errors.New("no interactive session recorded")
+
+// Record describes one interactive run. Resuming a session appends a new
+// record with the same ID.
+type Record struct {
+	ID        string    `json:"id"`
+	WorkDir   string    `json:"work_dir"`
+	Model     string    `json:"model,omitempty"`
+	ATSMode   bool      `json:"ats_mode,omitempty"`
+	Resumed   bool      `json:"resumed,omitempty"`
+	StartedAt time.Time `json:"started_at"`
+	EndedAt   time.Time `json:"ended_at"`
+	// Transcript is the saved copy relative to the application folder,
+	// empty if claude's transcript could not be found.
+	Transcript string `json:"transcript,omitempty"`
+}
+
+// NewID returns a new session ID in the UUID form claude expects.
+func NewID() string {
+	return uuid.NewString()
+}
+
+// Append adds a record to the application's session log.
+func Append(appDir string, rec *Record) error {
+	dir := filepath.Join(appDir, Dir)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return fmt.Errorf("failed to create sessions folder: %w", err)
+	}
+
+	data, err := json.Marshal(rec)
+	if err != nil {
+		return fmt.Errorf("failed to marshal session record: %w", err)
+	}
+
+	f, err := os.OpenFile(filepath.Join(dir, LogFile), os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return fmt.Errorf("failed to open session log: %w", err)
+	}
+	defer f.Close()
+
+	if _, err := f.Write(append(data, '\n')); err != nil {
+		return fmt.Errorf("failed to write session record: %w", err)
+	}
+	return nil
+}
+
+// Load reads every record from the application's session log, oldest first.
+// A missing log yields no records.
+func Load(appDir string) ([]Record, error) {
+	f, err := os.Open(filepath.Join(appDir, Dir, LogFile))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to open session log: %w", err)
+	}
+	defer f.Close()
+
+	var records []Record
+	scanner := bufio.NewScanner(f)
+	for line := 1; scanner.Scan(); line++ {
+		if strings.TrimSpace(scanner.Text()) == "" {
+			continue
+		}
+		var rec Record
+		if err := json.Unmarshal(scanner.Bytes(), &rec); err != nil {
+			return nil, fmt.Errorf("%s line %d: %w", LogFile, line, err)
+		}
+		records = append(records, rec)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read session log: %w", err)
+	}
+	return records, nil
+}
+
+// Latest returns the most recent session record for the application.
+func Latest(appDir string) (*Record, error) {
+	records, err := Load(appDir)
+	if err != nil {
+		return nil, err
+	}
+	if len(records) == 0 {
+		return nil, ErrNoSession
+	}
+	return &records[len(records)-1], nil
+}
+
+// ClaudeTranscriptPath returns where the claude CLI stores the transcript of
+// session id started in workDir: <config>/projects/<workDir with every
+// non-alphanumeric character replaced by '-'>/<id>.jsonl. The config
+// directory is $CLAUDE_CONFIG_DIR, or ~/.claude.
+func ClaudeTranscriptPath(workDir, id string) (string, error) {
+	configDir := os.Getenv("CLAUDE_CONFIG_DIR")
+	if configDir == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return "", fmt.Errorf("failed to find home directory: %w", err)
+		}
+		configDir = filepath.Join(home, ".claude")
+	}
+
+	project := strings.Map(func(r rune) rune {
+		if (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9') {
+			return r
+		}
+		return '-'
+	}, workDir)
+
+	return filepath.Join(configDir, "projects", project, id+".jsonl"), nil
+}
+
+// SaveTranscript copies claude's transcript for rec into the application's
+// sessions folder as <id>.jsonl, renders a readable <id>.md next to it and
+// sets rec.Transcript.
+func SaveTranscript(appDir string, rec *Record) error {
+	src, err := ClaudeTranscriptPath(rec.WorkDir, rec.ID)
+	if err != nil {
+		return err
+	}
+	data, err := os.ReadFile(src)
+	if err != nil {
+		return fmt.Errorf("failed to read claude transcript: %w", err)
+	}
+
+	dir := filepath.Join(appDir, Dir)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return fmt.Errorf("failed to create sessions folder: %w", err)
+	}
+
+	rel := filepath.Join(Dir, rec.ID+".jsonl")
+	if err := os.WriteFile(filepath.Join(appDir, rel), data, 0644); err != nil {
+		return fmt.Errorf("failed to save transcript: %w", err)
+	}
+
+	f, err := os.Create(filepath.Join(dir, rec.ID+".md"))
+	if err != nil {
+		return fmt.Errorf("failed to create readable transcript: %w", err)
+	}
+	defer f.Close()
+	if err := RenderMarkdown(f, strings.NewReader(string(data))); err != nil {
+		return fmt.Errorf("failed to render transcript: %w", err)
+	}
+
+	rec.Transcript = rel
+	return nil
+}
+
+// transcriptLine is the subset of a claude transcript entry that is rendered.
+type transcriptLine struct {
+	Type    string `json:"type"`
+	Message struct {
+		Role    string          `json:"role"`
+		Content json.RawMessage `json:"content"`
+	} `json:"message"`
+}
+
+// contentBlock is one block of a structured message.
+type contentBlock struct {
+	Type  string `json:"type"`
+	Text  string `json:"text"`
+	Name  string `json:"name"`
+	Input any    `json:"input"`
+}
+
+// RenderMarkdown converts a claude JSONL transcript into a readable markdown
+// conversation. Text is kept verbatim; tool calls are summarized by name and
+// tool results, thinking and bookkeeping entries are omitted.
+func RenderMarkdown(w io.Writer, r io.Reader) error {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+	for scanner.Scan() {
+		var line transcriptLine
+		if err := json.Unmarshal(scanner.Bytes(), &line); err != nil {
+			continue // tolerate entries from other CLI versions
+		}
+		if line.Type != "user" && line.Type != "assistant" {
+			continue
+		}
+
+		var parts []string
+		var text string
+		if err := json.Unmarshal(line.Message.Content, &text); err == nil {
+			parts = append(parts, text)
+		} else {
+			var blocks []contentBlock
+			if err := json.Unmarshal(line.Message.Content, &blocks); err != nil {
+				continue
+			}
+			for _, b := range blocks {
+				switch b.Type {
+				case "text":
+					parts = append(parts, b.Text)
+				case "tool_use":
+					parts = append(parts, fmt.Sprintf("_[tool: %s]_", b.Name))
+				}
+			}
+		}
+		if len(parts) == 0 {
+			continue
+		}
+
+		heading := "User"
+		if line.Type == "assistant" {
+			heading = "Claude"
+		}
+		if _, err := fmt.Fprintf(w, "## %s\n\n%s\n\n", heading, strings.TrimSpace(strings.Join(parts, "\n\n"))); err != nil {
+			return err
+		}
+	}
+	return scanner.Err()
+}
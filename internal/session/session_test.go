@@ -0,0 +1,101 @@
+package session
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestAppendLoadLatest(t *testing.T) {
+	appDir := t.TempDir()
+
+	if _, err := Latest(appDir); !errors.Is(err, ErrNoSession) {
+		t.Errorf("Latest() on empty app error = %v, want ErrNoSession", err)
+	}
+
+	first := &Record{ID: NewID(), WorkDir: "/work", StartedAt: time.Now().UTC()}
+	second := &Record{ID: first.ID, WorkDir: "/work", Resumed: true, StartedAt: time.Now().UTC()}
+	for _, rec := range []*Record{first, second} {
+		if err := Append(appDir, rec); err != nil {
+			t.Fatalf("Append() error = %v", err)
+		}
+	}
+
+	records, err := Load(appDir)
+	if err != nil || len(records) != 2 {
+		t.Fatalf("Load() = %d records, %v", len(records), err)
+	}
+	latest, err := Latest(appDir)
+	if err != nil {
+		t.Fatalf("Latest() error = %v", err)
+	}
+	if latest.ID != first.ID || !latest.Resumed {
+		t.Errorf("Latest() = %+v", latest)
+	}
+}
+
+func TestClaudeTranscriptPath(t *testing.T) {
+	t.Setenv("CLAUDE_CONFIG_DIR", "/cfg")
+
+	got, err := ClaudeTranscriptPath("/home/me/job_hunt.2026", "abc")
+	if err != nil {
+		t.Fatalf("ClaudeTranscriptPath() error = %v", err)
+	}
+	want := filepath.Join("/cfg", "projects", "-home-me-job-hunt-2026", "abc.jsonl")
+	if got != want {
+		t.Errorf("ClaudeTranscriptPath() = %s, want %s", got, want)
+	}
+}
+
+const sampleTranscript = `{"type":"summary","summary":"CV chat"}
+{"type":"user","message":{"role":"user","content":"Make it punchier"}}
+{"type":"assistant","message":{"role":"assistant","content":[{"type":"thinking","thinking":"hmm"},{"type":"text","text":"Sure, reading the latest version."},{"type":"tool_use","name":"mcp__m2cv__read_version","input":{}}]}}
+{"type":"user","message":{"role":"user","content":[{"type":"tool_result","content":"# CV"}]}}
+not json at all
+`
+
+func TestRenderMarkdown(t *testing.T) {
+	var b strings.Builder
+	if err := RenderMarkdown(&b, strings.NewReader(sampleTranscript)); err != nil {
+		t.Fatalf("RenderMarkdown() error = %v", err)
+	}
+	got := b.String()
+
+	want := "## User\n\nMake it punchier\n\n## Claude\n\nSure, reading the latest version.\n\n_[tool: mcp__m2cv__read_version]_\n\n"
+	if got != want {
+		t.Errorf("RenderMarkdown() =\n%q\nwant\n%q", got, want)
+	}
+}
+
+func TestSaveTranscript(t *testing.T) {
+	configDir := t.TempDir()
+	t.Setenv("CLAUDE_CONFIG_DIR", configDir)
+	appDir := t.TempDir()
+
+	rec := &Record{ID: NewID(), WorkDir: "/work/project"}
+	if err := SaveTranscript(appDir, rec); err == nil {
+		t.Error("SaveTranscript() should fail when claude wrote no transcript")
+	}
+
+	src, _ := ClaudeTranscriptPath(rec.WorkDir, rec.ID)
+	if err := os.MkdirAll(filepath.Dir(src), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(src, []byte(sampleTranscript), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := SaveTranscript(appDir, rec); err != nil {
+		t.Fatalf("SaveTranscript() error = %v", err)
+	}
+	if rec.Transcript != filepath.Join(Dir, rec.ID+".jsonl") {
+		t.Errorf("Transcript = %q", rec.Transcript)
+	}
+	md, err := os.ReadFile(filepath.Join(appDir, Dir, rec.ID+".md"))
+	if err != nil || !strings.Contains(string(md), "Make it punchier") {
+		t.Errorf("readable transcript = %q, %v", md, err)
+	}
+}
@@ -0,0 +1,277 @@
+package theme
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+
+	"github.com/richq/m2cv/internal/config"
+	"github.com/richq/m2cv/internal/executor"
+)
+
+// ErrDefaultTheme is returned when removing the project's default theme.
+var ErrDefaultTheme = errors.New("cannot remove the default theme")
+
+// Info describes a theme known to the project.
+type Info struct {
+	// Ref is the theme reference as used in m2cv.yml.
+	Ref string `json:"ref"`
+	// Package is the npm package name (empty for local themes).
+	Package string `json:"package,omitempty"`
+	// Path is the resolved directory of a local theme.
+	Path string `json:"path,omitempty"`
+	// Configured is true if the theme is listed in m2cv.yml.
+	Configured bool `json:"configured"`
+	// Default is true for the project's default theme.
+	Default bool `json:"default"`
+	// Installed is true if the package is in node_modules, or for a local
+	// theme if its directory exists.
+	Installed bool `json:"installed"`
+}
+
+// Manager installs and removes themes with npm and keeps the config's
+// theme list in sync. Config changes are made in memory; callers save.
+type Manager struct {
+	projectDir string
+	npm        executor.NPMExecutor
+}
+
+// NewManager creates a Manager for the project in projectDir.
+func NewManager(projectDir string, npm executor.NPMExecutor) *Manager {
+	return &Manager{projectDir: projectDir, npm: npm}
+}
+
+// Available returns the themes that can be rendered right now: installed
+// npm themes plus configured local themes whose directory exists.
+func Available(projectDir string, cfg *config.Config) ([]string, error) {
+	refs, err := Installed(projectDir)
+	if err != nil {
+		return nil, err
+	}
+	for _, ref := range append([]string{cfg.DefaultTheme}, cfg.Themes...) {
+		ref = normalizeOrSelf(ref)
+		if IsLocal(ref) && !slices.Contains(refs, ref) && IsInstalled(projectDir, ref) {
+			refs = append(refs, ref)
+		}
+	}
+	return refs, nil
+}
+
+// ProjectDir returns the project directory the manager works in.
+func (m *Manager) ProjectDir() string {
+	return m.projectDir
+}
+
+// List returns the configured themes (default first, then m2cv.yml order)
+// followed by installed themes that are not configured.
+func (m *Manager) List(cfg *config.Config) ([]Info, error) {
+	var infos []Info
+	seen := make(map[string]bool)
+	add := func(ref string, configured bool) {
+		if ref == "" || seen[ref] {
+			return
+		}
+		seen[ref] = true
+		info := Info{
+			Ref:        ref,
+			Package:    Package(ref),
+			Configured: configured,
+			Default:    ref == normalizeOrSelf(cfg.DefaultTheme),
+			Installed:  IsInstalled(m.projectDir, ref),
+		}
+		if IsLocal(ref) {
+			info.Path = LocalPath(m.projectDir, ref)
+		}
+		infos = append(infos, info)
+	}
+
+	add(normalizeOrSelf(cfg.DefaultTheme), true)
+	for _, ref := range cfg.Themes {
+		add(normalizeOrSelf(ref), true)
+	}
+
+	installed, err := Installed(m.projectDir)
+	if err != nil {
+		return nil, err
+	}
+	for _, ref := range installed {
+		add(ref, false)
+	}
+	return infos, nil
+}
+
+// Add installs themes (optionally pinned with "@version") and adds them to
+// cfg.Themes. Local themes must exist; their own dependencies are
+// installed if their package.json declares any. With makeDefault the first
+// theme becomes the default. It returns the normalized references.
+func (m *Manager) Add(ctx context.Context, cfg *config.Config, specs []string, makeDefault bool) ([]string, error) {
+	refs, packages, err := parseSpecs(specs)
+	if err != nil {
+		return nil, err
+	}
+	for _, ref := range refs {
+		if IsLocal(ref) && !IsInstalled(m.projectDir, ref) {
+			return nil, fmt.Errorf("theme directory not found: %s", LocalPath(m.projectDir, ref))
+		}
+	}
+
+	if len(packages) > 0 {
+		if err := m.npm.Install(ctx, m.projectDir, packages...); err != nil {
+			return nil, fmt.Errorf("failed to install themes: %w", err)
+		}
+	}
+	if err := m.installLocalDeps(ctx, refs); err != nil {
+		return nil, err
+	}
+
+	for _, ref := range refs {
+		if !containsRef(cfg.Themes, ref) {
+			cfg.Themes = append(cfg.Themes, ref)
+		}
+	}
+	if makeDefault || cfg.DefaultTheme == "" {
+		cfg.DefaultTheme = refs[0]
+	}
+	return refs, nil
+}
+
+// Remove uninstalls themes and drops them from cfg.Themes. The default
+// theme can't be removed; choose another default first.
+func (m *Manager) Remove(ctx context.Context, cfg *config.Config, refs []string) ([]string, error) {
+	normalized, packages, err := parseSpecs(refs)
+	if err != nil {
+		return nil, err
+	}
+	for _, ref := range normalized {
+		if ref == normalizeOrSelf(cfg.DefaultTheme) {
+			return nil, fmt.Errorf("%w %q; set another default with 'm2cv theme add --default <theme>' first", ErrDefaultTheme, ref)
+		}
+	}
+
+	// Local theme directories belong to the user and are left alone
+	if len(packages) > 0 {
+		if err := m.npm.Uninstall(ctx, m.projectDir, packages...); err != nil {
+			return nil, fmt.Errorf("failed to uninstall themes: %w", err)
+		}
+	}
+
+	cfg.Themes = slices.DeleteFunc(cfg.Themes, func(ref string) bool {
+		return slices.Contains(normalized, normalizeOrSelf(ref))
+	})
+	return normalized, nil
+}
+
+// Update updates the given themes, or every configured theme if none are
+// given, to the newest versions package.json allows. Local themes get
+// their dependencies reinstalled.
+func (m *Manager) Update(ctx context.Context, cfg *config.Config, refs []string) ([]string, error) {
+	if len(refs) == 0 {
+		infos, err := m.List(cfg)
+		if err != nil {
+			return nil, err
+		}
+		for _, info := range infos {
+			if info.Configured {
+				refs = append(refs, info.Ref)
+			}
+		}
+		if len(refs) == 0 {
+			return nil, errors.New("no themes configured")
+		}
+	}
+
+	normalized, packages, err := parseSpecs(refs)
+	if err != nil {
+		return nil, err
+	}
+	if len(packages) > 0 {
+		if err := m.npm.Update(ctx, m.projectDir, packages...); err != nil {
+			return nil, fmt.Errorf("failed to update themes: %w", err)
+		}
+	}
+	if err := m.installLocalDeps(ctx, normalized); err != nil {
+		return nil, err
+	}
+	return normalized, nil
+}
+
+// installLocalDeps runs npm install inside local theme directories whose
+// package.json declares dependencies, so resumed can load them.
+func (m *Manager) installLocalDeps(ctx context.Context, refs []string) error {
+	for _, ref := range refs {
+		if !IsLocal(ref) {
+			continue
+		}
+		dir := LocalPath(m.projectDir, ref)
+		if !hasDependencies(dir) {
+			continue
+		}
+		if err := m.npm.Install(ctx, dir); err != nil {
+			return fmt.Errorf("failed to install dependencies of %s: %w", ref, err)
+		}
+	}
+	return nil
+}
+
+// hasDependencies reports whether dir/package.json declares dependencies.
+func hasDependencies(dir string) bool {
+	data, err := os.ReadFile(filepath.Join(dir, "package.json"))
+	if err != nil {
+		return false
+	}
+	var pkg struct {
+		Dependencies map[string]string `json:"dependencies"`
+	}
+	return json.Unmarshal(data, &pkg) == nil && len(pkg.Dependencies) > 0
+}
+
+// parseSpecs normalizes theme specs and returns their references and the
+// npm arguments for the non-local ones (package@version when a version is
+// given).
+func parseSpecs(specs []string) ([]string, []string, error) {
+	if len(specs) == 0 {
+		return nil, nil, errors.New("no themes given")
+	}
+
+	var refs, packages []string
+	for _, spec := range specs {
+		ref, version := SplitVersion(spec)
+		ref, err := Normalize(ref)
+		if err != nil {
+			return nil, nil, err
+		}
+		refs = append(refs, ref)
+		if IsLocal(ref) {
+			continue
+		}
+		pkg := Package(ref)
+		if version != "" {
+			pkg += "@" + version
+		}
+		packages = append(packages, pkg)
+	}
+	return refs, packages, nil
+}
+
+// containsRef reports whether refs contains ref under any spelling.
+func containsRef(refs []string, ref string) bool {
+	for _, r := range refs {
+		if normalizeOrSelf(r) == ref {
+			return true
+		}
+	}
+	return false
+}
+
+// normalizeOrSelf normalizes a reference read from config, leaving
+// invalid ones untouched so they still show up in listings.
+func normalizeOrSelf(ref string) string {
+	if normalized, err := Normalize(ref); err == nil {
+		return normalized
+	}
+	return ref
+}
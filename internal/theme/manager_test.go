@@ -0,0 +1,223 @@
+package theme
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/richq/m2cv/internal/config"
+)
+
+// fakeNPM records npm calls and simulates node_modules.
+type fakeNPM struct {
+	calls [][]string
+	err   error
+}
+
+func (f *fakeNPM) record(dir, command string, packages []string) error {
+	f.calls = append(f.calls, append([]string{command}, packages...))
+	if f.err != nil {
+		return f.err
+	}
+	for _, pkg := range packages {
+		ref, _ := SplitVersion(pkg)
+		path := filepath.Join(dir, "node_modules", ref)
+		if command == "uninstall" {
+			os.RemoveAll(path)
+		} else if err := os.MkdirAll(path, 0755); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (f *fakeNPM) Install(ctx context.Context, dir string, packages ...string) error {
+	return f.record(dir, "install", packages)
+}
+
+func (f *fakeNPM) Uninstall(ctx context.Context, dir string, packages ...string) error {
+	return f.record(dir, "uninstall", packages)
+}
+
+func (f *fakeNPM) Update(ctx context.Context, dir string, packages ...string) error {
+	return f.record(dir, "update", packages)
+}
+
+func (f *fakeNPM) CheckInstalled(ctx context.Context, dir string, pkg string) (bool, error) {
+	return false, nil
+}
+
+func (f *fakeNPM) Init(ctx context.Context, dir string) error {
+	return nil
+}
+
+func TestManager_AddListRemove(t *testing.T) {
+	projectDir := t.TempDir()
+	npm := &fakeNPM{}
+	m := NewManager(projectDir, npm)
+	cfg := &config.Config{DefaultTheme: "even", Themes: []string{"even", "stackoverflow"}}
+	ctx := context.Background()
+
+	refs, err := m.Add(ctx, cfg, []string{"jsonresume-theme-even", "@acme/jsonresume-theme-brand@^2"}, false)
+	if err != nil {
+		t.Fatalf("Add() error = %v", err)
+	}
+	if !reflect.DeepEqual(refs, []string{"even", "@acme/jsonresume-theme-brand"}) {
+		t.Errorf("refs = %v", refs)
+	}
+	if !reflect.DeepEqual(npm.calls[0], []string{"install", "jsonresume-theme-even", "@acme/jsonresume-theme-brand@^2"}) {
+		t.Errorf("npm call = %v", npm.calls[0])
+	}
+	if !reflect.DeepEqual(cfg.Themes, []string{"even", "stackoverflow", "@acme/jsonresume-theme-brand"}) {
+		t.Errorf("Themes = %v (even must not be duplicated)", cfg.Themes)
+	}
+
+	// An installed but unconfigured theme is listed last
+	if err := os.MkdirAll(filepath.Join(projectDir, "node_modules", "jsonresume-theme-flat"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	infos, err := m.List(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []Info{
+		{Ref: "even", Package: "jsonresume-theme-even", Configured: true, Default: true, Installed: true},
+		{Ref: "stackoverflow", Package: "jsonresume-theme-stackoverflow", Configured: true},
+		{Ref: "@acme/jsonresume-theme-brand", Package: "@acme/jsonresume-theme-brand", Configured: true, Installed: true},
+		{Ref: "flat", Package: "jsonresume-theme-flat", Installed: true},
+	}
+	if !reflect.DeepEqual(infos, want) {
+		t.Errorf("List() =\n%+v\nwant\n%+v", infos, want)
+	}
+
+	if _, err := m.Remove(ctx, cfg, []string{"jsonresume-theme-even"}); !errors.Is(err, ErrDefaultTheme) {
+		t.Errorf("removing the default should fail, got %v", err)
+	}
+
+	if _, err := m.Remove(ctx, cfg, []string{"@acme/jsonresume-theme-brand"}); err != nil {
+		t.Fatalf("Remove() error = %v", err)
+	}
+	if !reflect.DeepEqual(cfg.Themes, []string{"even", "stackoverflow"}) {
+		t.Errorf("Themes after remove = %v", cfg.Themes)
+	}
+	if IsInstalled(projectDir, "@acme/jsonresume-theme-brand") {
+		t.Error("theme should be uninstalled")
+	}
+}
+
+func TestManager_AddDefaultAndErrors(t *testing.T) {
+	npm := &fakeNPM{}
+	m := NewManager(t.TempDir(), npm)
+	cfg := &config.Config{}
+	ctx := context.Background()
+
+	if _, err := m.Add(ctx, cfg, []string{"Bad Theme"}, false); err == nil {
+		t.Error("invalid theme should fail")
+	}
+	if len(npm.calls) != 0 {
+		t.Error("npm must not run for invalid themes")
+	}
+
+	if _, err := m.Add(ctx, cfg, []string{"flat"}, false); err != nil {
+		t.Fatal(err)
+	}
+	if cfg.DefaultTheme != "flat" {
+		t.Errorf("first theme should become the default, got %q", cfg.DefaultTheme)
+	}
+	if _, err := m.Add(ctx, cfg, []string{"npm:fancy-resume"}, true); err != nil {
+		t.Fatal(err)
+	}
+	if cfg.DefaultTheme != "npm:fancy-resume" {
+		t.Errorf("--default should switch the default, got %q", cfg.DefaultTheme)
+	}
+
+	npm.err = errors.New("offline")
+	before := append([]string{}, cfg.Themes...)
+	if _, err := m.Add(ctx, cfg, []string{"macchiato"}, false); err == nil {
+		t.Error("npm failure should be returned")
+	}
+	if !reflect.DeepEqual(cfg.Themes, before) {
+		t.Errorf("config must not change when npm fails: %v", cfg.Themes)
+	}
+}
+
+func TestManager_Update(t *testing.T) {
+	npm := &fakeNPM{}
+	m := NewManager(t.TempDir(), npm)
+	cfg := &config.Config{DefaultTheme: "even", Themes: []string{"even", "flat"}}
+
+	if _, err := m.Update(context.Background(), cfg, nil); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(npm.calls[0], []string{"update", "jsonresume-theme-even", "jsonresume-theme-flat"}) {
+		t.Errorf("npm call = %v", npm.calls[0])
+	}
+
+	if _, err := m.Update(context.Background(), &config.Config{}, nil); err == nil {
+		t.Error("update with nothing configured should fail")
+	}
+}
+
+func TestManager_LocalTheme(t *testing.T) {
+	projectDir := t.TempDir()
+	npm := &fakeNPM{}
+	m := NewManager(projectDir, npm)
+	cfg := &config.Config{DefaultTheme: "even", Themes: []string{"even"}}
+	ctx := context.Background()
+
+	if _, err := m.Add(ctx, cfg, []string{"./themes/brand"}, false); err == nil {
+		t.Error("adding a missing directory should fail")
+	}
+
+	dir := filepath.Join(projectDir, "themes", "brand")
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	refs, err := m.Add(ctx, cfg, []string{"./themes/brand"}, false)
+	if err != nil {
+		t.Fatalf("Add() error = %v", err)
+	}
+	if !reflect.DeepEqual(refs, []string{"file:themes/brand"}) {
+		t.Errorf("refs = %v", refs)
+	}
+	if len(npm.calls) != 0 {
+		t.Errorf("npm should not run for a theme without dependencies: %v", npm.calls)
+	}
+
+	// Dependencies of a local theme are installed inside its directory
+	if err := os.WriteFile(filepath.Join(dir, "package.json"), []byte(`{"dependencies": {"handlebars": "^4"}}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := m.Update(ctx, cfg, []string{"file:themes/brand"}); err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+	if !reflect.DeepEqual(npm.calls, [][]string{{"install"}}) {
+		t.Errorf("npm calls = %v", npm.calls)
+	}
+
+	infos, err := m.List(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := infos[1]; got.Ref != "file:themes/brand" || got.Path != dir || got.Package != "" || !got.Installed {
+		t.Errorf("local theme info = %+v", got)
+	}
+
+	available, err := Available(projectDir, cfg)
+	if err != nil || !reflect.DeepEqual(available, []string{"file:themes/brand"}) {
+		t.Errorf("Available() = %v, %v", available, err)
+	}
+
+	if _, err := m.Remove(ctx, cfg, []string{"./themes/brand"}); err != nil {
+		t.Fatalf("Remove() error = %v", err)
+	}
+	if !reflect.DeepEqual(cfg.Themes, []string{"even"}) {
+		t.Errorf("Themes after remove = %v", cfg.Themes)
+	}
+	if _, err := os.Stat(dir); err != nil {
+		t.Error("removing a local theme must not delete its directory")
+	}
+}
@@ -0,0 +1,73 @@
+package theme
+
+import (
+	"bytes"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"text/template"
+
+	"github.com/richq/m2cv/internal/assets"
+)
+
+// namePattern matches names accepted for new local themes.
+var namePattern = regexp.MustCompile(`^[a-z0-9][a-z0-9-]*$`)
+
+// Scaffold writes the starter theme called name into parentDir/name and
+// returns its file: reference. A relative parentDir is resolved against
+// projectDir. The theme directory must not exist yet.
+func Scaffold(projectDir, parentDir, name string) (string, error) {
+	if !namePattern.MatchString(name) {
+		return "", fmt.Errorf("invalid theme name %q: use lowercase letters, digits and dashes", name)
+	}
+
+	dir := filepath.Join(parentDir, name)
+	if !filepath.IsAbs(dir) {
+		dir = filepath.Join(projectDir, dir)
+	}
+	if _, err := os.Stat(dir); err == nil {
+		return "", fmt.Errorf("%s already exists", dir)
+	}
+
+	// Reference the theme relative to the project so m2cv.yml stays portable
+	path := dir
+	if rel, err := filepath.Rel(projectDir, dir); err == nil {
+		path = rel
+	}
+	ref, err := Normalize(FileScheme + filepath.ToSlash(path))
+	if err != nil {
+		return "", err
+	}
+
+	data := struct{ Name, Ref string }{Name: name, Ref: ref}
+	files := assets.ThemeScaffold()
+	err = fs.WalkDir(files, ".", func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		target := filepath.Join(dir, filepath.FromSlash(path))
+		if d.IsDir() {
+			return os.MkdirAll(target, 0755)
+		}
+
+		src, err := fs.ReadFile(files, path)
+		if err != nil {
+			return err
+		}
+		tmpl, err := template.New(path).Parse(string(src))
+		if err != nil {
+			return fmt.Errorf("failed to parse %s: %w", path, err)
+		}
+		var buf bytes.Buffer
+		if err := tmpl.Execute(&buf, data); err != nil {
+			return fmt.Errorf("failed to render %s: %w", path, err)
+		}
+		return os.WriteFile(target, buf.Bytes(), 0644)
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to create theme: %w", err)
+	}
+	return ref, nil
+}
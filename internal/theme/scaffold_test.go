@@ -0,0 +1,43 @@
+package theme
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestScaffold(t *testing.T) {
+	projectDir := t.TempDir()
+
+	ref, err := Scaffold(projectDir, "themes", "brand")
+	if err != nil {
+		t.Fatalf("Scaffold() error = %v", err)
+	}
+	if ref != "file:themes/brand" {
+		t.Errorf("ref = %q", ref)
+	}
+
+	dir := filepath.Join(projectDir, "themes", "brand")
+	for _, name := range []string{"package.json", "index.js", "style.css", "README.md"} {
+		if _, err := os.Stat(filepath.Join(dir, name)); err != nil {
+			t.Errorf("missing %s: %v", name, err)
+		}
+	}
+	pkg, err := os.ReadFile(filepath.Join(dir, "package.json"))
+	if err != nil || !strings.Contains(string(pkg), `"name": "jsonresume-theme-brand"`) {
+		t.Errorf("package.json = %s, %v", pkg, err)
+	}
+	if hasDependencies(dir) {
+		t.Error("the starter theme should not need npm install")
+	}
+
+	if _, err := Scaffold(projectDir, "themes", "brand"); err == nil || !strings.Contains(err.Error(), "already exists") {
+		t.Errorf("scaffolding over an existing theme should fail, got %v", err)
+	}
+	for _, name := range []string{"", "Brand", "../brand", "-x"} {
+		if _, err := Scaffold(projectDir, "themes", name); err == nil {
+			t.Errorf("Scaffold(%q) should fail", name)
+		}
+	}
+}
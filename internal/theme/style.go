@@ -0,0 +1,252 @@
+package theme
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/richq/m2cv/internal/config"
+)
+
+// Style is the resolved set of theme options applied on top of a theme:
+// the project's options with the application's layered over them.
+type Style struct {
+	AccentColor string
+	FontFamily  string
+	FontSize    string
+	Margin      string
+	// CSSFiles are absolute stylesheet paths, injected in order.
+	CSSFiles []string
+	// Meta is merged into the resume's meta.themeOptions.
+	Meta map[string]any
+}
+
+// LoadStyle layers the theme options in appDir's application.yml over the
+// project's. An empty appDir gives the project's options alone.
+func LoadStyle(projectDir string, project config.ThemeOptions, appDir string) (Style, error) {
+	style := Style{}.With(projectDir, project)
+	if appDir == "" {
+		return style, nil
+	}
+	app, err := config.LoadApplication(appDir)
+	if err != nil {
+		return Style{}, err
+	}
+	return style.With(appDir, app.ThemeOptions), nil
+}
+
+// StylePaths lists the files LoadStyle reads, for watching: the
+// application's application.yml and the custom CSS files.
+func StylePaths(projectDir string, project config.ThemeOptions, appDir string) []string {
+	paths := []string{filepath.Join(appDir, config.ApplicationFile)}
+	if style, err := LoadStyle(projectDir, project, appDir); err == nil {
+		paths = append(paths, style.CSSFiles...)
+	} else if project.CSS != "" {
+		// Keep watching the project stylesheet while application.yml is broken
+		paths = append(paths, Style{}.With(projectDir, project).CSSFiles...)
+	}
+	return paths
+}
+
+// With returns s with opts layered on top: set fields replace earlier
+// ones, a CSS file is added after earlier ones (resolved against dir) and
+// meta keys are merged.
+func (s Style) With(dir string, opts config.ThemeOptions) Style {
+	if opts.AccentColor != "" {
+		s.AccentColor = opts.AccentColor
+	}
+	if opts.FontFamily != "" {
+		s.FontFamily = opts.FontFamily
+	}
+	if opts.FontSize != "" {
+		s.FontSize = opts.FontSize
+	}
+	if opts.Margin != "" {
+		s.Margin = opts.Margin
+	}
+	if opts.CSS != "" {
+		path := opts.CSS
+		if !filepath.IsAbs(path) {
+			path = filepath.Join(dir, path)
+		}
+		if abs, err := filepath.Abs(path); err == nil {
+			path = abs
+		}
+		s.CSSFiles = append(append([]string{}, s.CSSFiles...), path)
+	}
+	if len(opts.Meta) > 0 {
+		meta := maps.Clone(s.Meta)
+		if meta == nil {
+			meta = make(map[string]any)
+		}
+		maps.Copy(meta, opts.Meta)
+		s.Meta = meta
+	}
+	return s
+}
+
+// IsZero reports whether s changes nothing, so the theme can be used as-is.
+func (s Style) IsZero() bool {
+	return s.AccentColor == "" && s.FontFamily == "" && s.FontSize == "" &&
+		s.Margin == "" && len(s.CSSFiles) == 0 && len(s.Meta) == 0
+}
+
+// Hash returns a digest of everything s changes about an export: the
+// generated stylesheet, including the custom CSS files' contents, and the
+// meta options.
+func (s Style) Hash() (string, error) {
+	css, err := s.CSS()
+	if err != nil {
+		return "", err
+	}
+	meta, err := json.Marshal(s.Meta)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal theme meta options: %w", err)
+	}
+	sum := sha256.Sum256([]byte(css + "\x00" + string(meta)))
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// CSS returns the stylesheet injected into the theme's HTML: rules for
+// the option fields followed by the custom CSS files.
+func (s Style) CSS() (string, error) {
+	for _, field := range [][2]string{
+		{"accent_color", s.AccentColor},
+		{"font_family", s.FontFamily},
+		{"font_size", s.FontSize},
+		{"margin", s.Margin},
+	} {
+		if strings.ContainsAny(field[1], ";{}<>") {
+			return "", fmt.Errorf("invalid theme_options.%s %q: must be a single CSS value", field[0], field[1])
+		}
+	}
+
+	var b strings.Builder
+	var body []string
+	if s.FontFamily != "" {
+		body = append(body, "font-family: "+s.FontFamily)
+	}
+	if s.FontSize != "" {
+		body = append(body, "font-size: "+s.FontSize)
+	}
+	if len(body) > 0 {
+		fmt.Fprintf(&b, "body { %s; }\n", strings.Join(body, "; "))
+	}
+	if s.AccentColor != "" {
+		fmt.Fprintf(&b, "h1, h2, h3, h4, a { color: %s; }\n", s.AccentColor)
+	}
+
+	for _, path := range s.CSSFiles {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return "", fmt.Errorf("failed to read theme CSS: %w", err)
+		}
+		fmt.Fprintf(&b, "/* %s */\n%s\n", filepath.Base(path), data)
+	}
+	return b.String(), nil
+}
+
+// pageMargin expands a CSS margin shorthand into puppeteer's margin object.
+func pageMargin(shorthand string) map[string]string {
+	v := strings.Fields(shorthand)
+	switch len(v) {
+	case 1:
+		v = []string{v[0], v[0], v[0], v[0]}
+	case 2:
+		v = []string{v[0], v[1], v[0], v[1]}
+	case 3:
+		v = []string{v[0], v[1], v[2], v[1]}
+	case 4:
+	default:
+		return nil
+	}
+	return map[string]string{"top": v[0], "right": v[1], "bottom": v[2], "left": v[3]}
+}
+
+// wrapperSource is a theme that delegates to the real one, merging meta
+// into the resume, injecting CSS before </head> and overriding the PDF
+// margins. Placeholders are JSON literals.
+const wrapperSource = `// Generated by m2cv to apply theme_options; do not edit.
+const theme = require(%s);
+const css = %s;
+const meta = %s;
+const margin = %s;
+
+function withMeta(resume) {
+  if (!meta) {
+    return resume;
+  }
+  const current = resume.meta || {};
+  return { ...resume, meta: { ...current, themeOptions: { ...current.themeOptions, ...meta } } };
+}
+
+function inject(html) {
+  if (!css || typeof html !== 'string') {
+    return html;
+  }
+  const style = '<style data-m2cv-theme-options>\n' + css + '</style>\n';
+  const i = html.search(/<\/head>/i);
+  return i < 0 ? style + html : html.slice(0, i) + style + html.slice(i);
+}
+
+function render(resume) {
+  const html = theme.render(withMeta(resume));
+  return html && typeof html.then === 'function' ? html.then(inject) : inject(html);
+}
+
+const pdfRenderOptions = margin ? { ...theme.pdfRenderOptions, margin } : theme.pdfRenderOptions;
+
+module.exports = { render, pdfRenderOptions };
+`
+
+// Wrap returns a theme reference that renders ref with s applied, and a
+// cleanup func removing it. resumed cannot be told to add CSS, so the
+// reference points at a generated local theme wrapping the real one. A
+// zero style, or a theme that is not installed (so the caller reports it
+// as usual), returns ref unchanged.
+func (s Style) Wrap(projectDir, ref string) (string, func(), error) {
+	noop := func() {}
+	if s.IsZero() || !IsInstalled(projectDir, ref) {
+		return ref, noop, nil
+	}
+
+	css, err := s.CSS()
+	if err != nil {
+		return "", noop, err
+	}
+	themeDir, err := filepath.Abs(Dir(projectDir, ref))
+	if err != nil {
+		return "", noop, fmt.Errorf("failed to resolve theme %s: %w", ref, err)
+	}
+	var margin map[string]string
+	if s.Margin != "" {
+		if margin = pageMargin(s.Margin); margin == nil {
+			return "", noop, fmt.Errorf("invalid theme_options.margin %q: expected 1 to 4 CSS lengths", s.Margin)
+		}
+	}
+
+	literals := make([]any, 0, 4)
+	for _, v := range []any{themeDir, css, s.Meta, margin} {
+		data, err := json.Marshal(v)
+		if err != nil {
+			return "", noop, fmt.Errorf("failed to encode theme options: %w", err)
+		}
+		literals = append(literals, string(data))
+	}
+
+	dir, err := os.MkdirTemp("", "m2cv-theme-*")
+	if err != nil {
+		return "", noop, fmt.Errorf("failed to create theme wrapper: %w", err)
+	}
+	cleanup := func() { os.RemoveAll(dir) }
+	if err := os.WriteFile(filepath.Join(dir, "index.js"), []byte(fmt.Sprintf(wrapperSource, literals...)), 0644); err != nil {
+		cleanup()
+		return "", noop, fmt.Errorf("failed to write theme wrapper: %w", err)
+	}
+	return FileScheme + filepath.ToSlash(dir), cleanup, nil
+}
@@ -0,0 +1,158 @@
+package theme
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/richq/m2cv/internal/config"
+)
+
+func TestLoadStyle_Layers(t *testing.T) {
+	projectDir := t.TempDir()
+	appDir := filepath.Join(projectDir, "applications", "acme")
+	if err := os.MkdirAll(appDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	app := "theme_options:\n  accent_color: red\n  css: acme.css\n  meta:\n    layout: compact\n"
+	if err := os.WriteFile(filepath.Join(appDir, config.ApplicationFile), []byte(app), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	project := config.ThemeOptions{
+		AccentColor: "navy",
+		FontFamily:  "Inter, sans-serif",
+		CSS:         "brand.css",
+		Meta:        map[string]any{"layout": "wide", "colors": "dark"},
+	}
+	style, err := LoadStyle(projectDir, project, appDir)
+	if err != nil {
+		t.Fatalf("LoadStyle() error = %v", err)
+	}
+
+	want := Style{
+		AccentColor: "red",
+		FontFamily:  "Inter, sans-serif",
+		CSSFiles:    []string{filepath.Join(projectDir, "brand.css"), filepath.Join(appDir, "acme.css")},
+		Meta:        map[string]any{"layout": "compact", "colors": "dark"},
+	}
+	if !reflect.DeepEqual(style, want) {
+		t.Errorf("LoadStyle() =\n%+v\nwant\n%+v", style, want)
+	}
+	if project.Meta["layout"] != "wide" {
+		t.Error("layering must not modify the project's options")
+	}
+}
+
+func TestStyle_CSS(t *testing.T) {
+	cssPath := filepath.Join(t.TempDir(), "brand.css")
+	if err := os.WriteFile(cssPath, []byte(".name { letter-spacing: 1px; }"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	style := Style{AccentColor: "#c0392b", FontSize: "10pt", CSSFiles: []string{cssPath}}
+	css, err := style.CSS()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{"body { font-size: 10pt; }", "h1, h2, h3, h4, a { color: #c0392b; }", ".name { letter-spacing: 1px; }"} {
+		if !strings.Contains(css, want) {
+			t.Errorf("CSS() missing %q:\n%s", want, css)
+		}
+	}
+
+	if _, err := (Style{AccentColor: "red; } body { display: none"}).CSS(); err == nil {
+		t.Error("values that break out of the declaration should be rejected")
+	}
+	if _, err := (Style{CSSFiles: []string{filepath.Join(t.TempDir(), "missing.css")}}).CSS(); err == nil {
+		t.Error("a missing CSS file should be reported")
+	}
+}
+
+func TestStyle_Hash(t *testing.T) {
+	cssPath := filepath.Join(t.TempDir(), "brand.css")
+	os.WriteFile(cssPath, []byte("h1 { margin: 0; }"), 0644)
+	style := Style{FontSize: "10pt", CSSFiles: []string{cssPath}, Meta: map[string]any{"compact": true}}
+
+	hash, err := style.Hash()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if again, _ := style.Hash(); again != hash {
+		t.Error("Hash() is not stable")
+	}
+	os.WriteFile(cssPath, []byte("h1 { margin: 1em; }"), 0644)
+	if edited, _ := style.Hash(); edited == hash {
+		t.Error("editing the CSS file did not change the hash")
+	}
+	style.Meta = map[string]any{"compact": false}
+	if meta, _ := style.Hash(); meta == hash {
+		t.Error("changing meta did not change the hash")
+	}
+}
+
+func TestPageMargin(t *testing.T) {
+	if got := pageMargin("10mm 15mm"); !reflect.DeepEqual(got, map[string]string{"top": "10mm", "right": "15mm", "bottom": "10mm", "left": "15mm"}) {
+		t.Errorf("pageMargin() = %v", got)
+	}
+	if got := pageMargin("1 2 3 4 5"); got != nil {
+		t.Errorf("pageMargin() = %v, want nil", got)
+	}
+}
+
+func TestStyle_Wrap(t *testing.T) {
+	projectDir := t.TempDir()
+	themeDir := filepath.Join(projectDir, "themes", "plain")
+	if err := os.MkdirAll(themeDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	source := "module.exports = { render: (r) => '<html><head></head><body>' + JSON.stringify(r.meta) + '</body></html>', pdfRenderOptions: { format: 'A4' } };\n"
+	if err := os.WriteFile(filepath.Join(themeDir, "index.js"), []byte(source), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if ref, _, err := (Style{}).Wrap(projectDir, "file:themes/plain"); err != nil || ref != "file:themes/plain" {
+		t.Errorf("zero style should not wrap, got %q, %v", ref, err)
+	}
+	if ref, _, err := (Style{AccentColor: "red"}).Wrap(projectDir, "flat"); err != nil || ref != "flat" {
+		t.Errorf("missing theme should not wrap, got %q, %v", ref, err)
+	}
+
+	style := Style{AccentColor: "red", Margin: "1cm", Meta: map[string]any{"compact": true}}
+	ref, cleanup, err := style.Wrap(projectDir, "file:themes/plain")
+	if err != nil {
+		t.Fatalf("Wrap() error = %v", err)
+	}
+	wrapperDir := LocalPath(projectDir, ref)
+	if !IsLocal(ref) || !IsInstalled(projectDir, ref) {
+		t.Fatalf("Wrap() = %q, want an existing local theme", ref)
+	}
+	defer func() {
+		cleanup()
+		if _, err := os.Stat(wrapperDir); !os.IsNotExist(err) {
+			t.Error("cleanup should remove the wrapper")
+		}
+	}()
+
+	node, err := exec.LookPath("node")
+	if err != nil {
+		t.Skip("node not available")
+	}
+	script := `import(process.argv[1]).then((m) => console.log(m.render({meta: {x: 1}}) + JSON.stringify(m.pdfRenderOptions)))`
+	out, err := exec.Command(node, "-e", script, Entry(wrapperDir)).CombinedOutput()
+	if err != nil {
+		t.Fatalf("node: %v\n%s", err, out)
+	}
+	for _, want := range []string{
+		"<style data-m2cv-theme-options>\nh1, h2, h3, h4, a { color: red; }\n</style>\n</head>",
+		`{"x":1,"themeOptions":{"compact":true}}`,
+		`"format":"A4","margin":{"bottom":"1cm","left":"1cm","right":"1cm","top":"1cm"}`,
+	} {
+		if !strings.Contains(string(out), want) {
+			t.Errorf("wrapped theme output missing %q:\n%s", want, out)
+		}
+	}
+}
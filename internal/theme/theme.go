@@ -0,0 +1,256 @@
+// Package theme resolves JSON Resume theme references to npm packages and
+// manages the themes installed in a project.
+//
+// A theme reference is what m2cv.yml and --theme flags contain:
+//
+//	even                            -> jsonresume-theme-even
+//	jsonresume-theme-even           -> jsonresume-theme-even
+//	@acme/jsonresume-theme-brand    -> @acme/jsonresume-theme-brand (scoped)
+//	npm:fancy-resume                -> fancy-resume (any other package)
+//	file:themes/brand, ./brand      -> a local theme directory
+//
+// Relative local paths are resolved against the project directory (where
+// m2cv.yml lives), so they work from any working directory.
+package theme
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// PackagePrefix is the npm naming convention for JSON Resume themes.
+const PackagePrefix = "jsonresume-theme-"
+
+// npmScheme marks a reference to a package outside the naming convention.
+const npmScheme = "npm:"
+
+// FileScheme marks a reference to a local theme directory.
+const FileScheme = "file:"
+
+// packagePattern matches valid (lowercase) npm package names.
+var packagePattern = regexp.MustCompile(`^(@[a-z0-9][a-z0-9._~-]*/)?[a-z0-9][a-z0-9._~-]*$`)
+
+// IsLocal reports whether ref points at a local directory rather than an
+// npm package: a file: reference or a path starting with ".", "/" or "~".
+func IsLocal(ref string) bool {
+	return strings.HasPrefix(ref, FileScheme) || strings.HasPrefix(ref, ".") ||
+		strings.HasPrefix(ref, "~") || filepath.IsAbs(ref) || strings.HasPrefix(ref, "/")
+}
+
+// LocalPath returns the absolute directory of a local theme, resolving
+// relative paths against projectDir and "~/" against the home directory.
+func LocalPath(projectDir, ref string) string {
+	path := filepath.FromSlash(strings.TrimPrefix(ref, FileScheme))
+	if rest, ok := strings.CutPrefix(path, "~"+string(filepath.Separator)); ok {
+		if home, err := os.UserHomeDir(); err == nil {
+			path = filepath.Join(home, rest)
+		}
+	}
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(projectDir, path)
+	}
+	if abs, err := filepath.Abs(path); err == nil {
+		path = abs
+	}
+	return path
+}
+
+// Resolve returns what resumed's --theme option needs for ref: the
+// absolute path of a local theme's entry file, or the npm package name.
+// resumed loads themes with import(), which cannot import a directory.
+func Resolve(projectDir, ref string) string {
+	if IsLocal(ref) {
+		return Entry(LocalPath(projectDir, ref))
+	}
+	return Package(ref)
+}
+
+// Entry returns the entry file of the theme in dir: the "main" field of
+// its package.json, or index.js.
+func Entry(dir string) string {
+	main := "index.js"
+	if data, err := os.ReadFile(filepath.Join(dir, "package.json")); err == nil {
+		var pkg struct {
+			Main string `json:"main"`
+		}
+		if json.Unmarshal(data, &pkg) == nil && pkg.Main != "" {
+			main = pkg.Main
+		}
+	}
+	return filepath.Join(dir, filepath.FromSlash(main))
+}
+
+// Package returns the npm package name for a theme reference. Local
+// themes have no package; use Resolve for them.
+func Package(ref string) string {
+	if IsLocal(ref) {
+		return ""
+	}
+	switch {
+	case strings.HasPrefix(ref, npmScheme):
+		return strings.TrimPrefix(ref, npmScheme)
+	case strings.HasPrefix(ref, "@"), strings.HasPrefix(ref, PackagePrefix):
+		return ref
+	}
+	return PackagePrefix + ref
+}
+
+// Ref returns the shortest reference for an npm package: the bare name for
+// jsonresume-theme-* packages, the package itself when scoped, and
+// npm:<package> otherwise.
+func Ref(pkg string) string {
+	switch {
+	case strings.HasPrefix(pkg, PackagePrefix) && len(pkg) > len(PackagePrefix):
+		return strings.TrimPrefix(pkg, PackagePrefix)
+	case strings.HasPrefix(pkg, "@"):
+		return pkg
+	}
+	return npmScheme + pkg
+}
+
+// Normalize validates ref and returns its shortest form, so the same theme
+// is never configured twice under different spellings. Local paths become
+// file: references with a cleaned, slash-separated path.
+func Normalize(ref string) (string, error) {
+	if IsLocal(ref) {
+		path := strings.TrimPrefix(ref, FileScheme)
+		if strings.TrimSpace(path) == "" {
+			return "", fmt.Errorf("invalid theme %q: missing directory path", ref)
+		}
+		return FileScheme + filepath.ToSlash(filepath.Clean(filepath.FromSlash(path))), nil
+	}
+
+	pkg := Package(ref)
+	if pkg == PackagePrefix || !packagePattern.MatchString(pkg) {
+		return "", fmt.Errorf("invalid theme %q: expected a name like \"even\", a package like \"@acme/jsonresume-theme-brand\" or \"npm:<package>\"", ref)
+	}
+	return Ref(pkg), nil
+}
+
+// SplitVersion separates an optional npm version from a reference:
+// "even@1.2.0" -> ("even", "1.2.0"), "@acme/x@^2" -> ("@acme/x", "^2").
+func SplitVersion(spec string) (ref, version string) {
+	if IsLocal(spec) {
+		return spec, ""
+	}
+	i := strings.LastIndex(spec, "@")
+	if i <= 0 || (strings.HasPrefix(spec, npmScheme+"@") && i == len(npmScheme)) {
+		return spec, ""
+	}
+	return spec[:i], spec[i+1:]
+}
+
+// Dir returns where a theme is installed: its node_modules folder in
+// projectDir, or the local theme directory.
+func Dir(projectDir, ref string) string {
+	if IsLocal(ref) {
+		return LocalPath(projectDir, ref)
+	}
+	return filepath.Join(projectDir, "node_modules", filepath.FromSlash(Package(ref)))
+}
+
+// IsInstalled reports whether the theme's package is in node_modules, or
+// for local themes whether the directory exists.
+func IsInstalled(projectDir, ref string) bool {
+	info, err := os.Stat(Dir(projectDir, ref))
+	return err == nil && info.IsDir()
+}
+
+// Version identifies the installed code of a theme, so exports can be
+// redone when it changes: the version in the package's package.json, or
+// for a local theme, which is edited without bumping its version, a hash
+// of its files (node_modules excluded). A missing theme has no version.
+func Version(projectDir, ref string) (string, error) {
+	dir := Dir(projectDir, ref)
+	if !IsLocal(ref) {
+		data, err := os.ReadFile(filepath.Join(dir, "package.json"))
+		if os.IsNotExist(err) {
+			return "", nil
+		}
+		if err != nil {
+			return "", fmt.Errorf("failed to read theme package: %w", err)
+		}
+		var pkg struct {
+			Version string `json:"version"`
+		}
+		if err := json.Unmarshal(data, &pkg); err != nil {
+			return "", fmt.Errorf("invalid package.json for theme %s: %w", ref, err)
+		}
+		return pkg.Version, nil
+	}
+
+	if !IsInstalled(projectDir, ref) {
+		return "", nil
+	}
+	h := sha256.New()
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			if path != dir && (d.Name() == "node_modules" || strings.HasPrefix(d.Name(), ".")) {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		rel, _ := filepath.Rel(dir, path)
+		fmt.Fprintf(h, "%s\x00%d\x00", filepath.ToSlash(rel), len(data))
+		h.Write(data)
+		return nil
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to hash theme %s: %w", ref, err)
+	}
+	return "sha256:" + hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// Installed returns references for the jsonresume-theme-* packages in
+// projectDir's node_modules, including scoped ones, sorted. Packages outside
+// the naming convention can't be recognized and are not listed.
+func Installed(projectDir string) ([]string, error) {
+	modules := filepath.Join(projectDir, "node_modules")
+	entries, err := os.ReadDir(modules)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read node_modules: %w", err)
+	}
+
+	var refs []string
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+		name := entry.Name()
+		if strings.HasPrefix(name, "@") {
+			scoped, err := os.ReadDir(filepath.Join(modules, name))
+			if err != nil {
+				return nil, fmt.Errorf("failed to read %s: %w", name, err)
+			}
+			for _, s := range scoped {
+				if s.IsDir() && strings.HasPrefix(s.Name(), PackagePrefix) {
+					refs = append(refs, name+"/"+s.Name())
+				}
+			}
+			continue
+		}
+		if strings.HasPrefix(name, PackagePrefix) && len(name) > len(PackagePrefix) {
+			refs = append(refs, Ref(name))
+		}
+	}
+	sort.Strings(refs)
+	return refs, nil
+}
@@ -0,0 +1,168 @@
+package theme
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestPackageAndRef(t *testing.T) {
+	tests := []struct {
+		ref, pkg, short string
+	}{
+		{"even", "jsonresume-theme-even", "even"},
+		{"jsonresume-theme-even", "jsonresume-theme-even", "even"},
+		{"@acme/jsonresume-theme-brand", "@acme/jsonresume-theme-brand", "@acme/jsonresume-theme-brand"},
+		{"npm:fancy-resume", "fancy-resume", "npm:fancy-resume"},
+		{"npm:jsonresume-theme-flat", "jsonresume-theme-flat", "flat"},
+	}
+	for _, tt := range tests {
+		if got := Package(tt.ref); got != tt.pkg {
+			t.Errorf("Package(%q) = %q, want %q", tt.ref, got, tt.pkg)
+		}
+		if got, err := Normalize(tt.ref); err != nil || got != tt.short {
+			t.Errorf("Normalize(%q) = %q, %v, want %q", tt.ref, got, err, tt.short)
+		}
+	}
+}
+
+func TestNormalize_Invalid(t *testing.T) {
+	for _, ref := range []string{"", "Even", "file:", "a b", "@scope", "npm:", "jsonresume-theme-", "@/x"} {
+		if got, err := Normalize(ref); err == nil {
+			t.Errorf("Normalize(%q) = %q, want error", ref, got)
+		}
+	}
+}
+
+func TestLocalRefs(t *testing.T) {
+	tests := []struct {
+		ref, normalized string
+	}{
+		{"./brand", "file:brand"},
+		{"file:themes/brand/", "file:themes/brand"},
+		{"../shared/theme", "file:../shared/theme"},
+		{"/opt/themes/brand", "file:/opt/themes/brand"},
+	}
+	for _, tt := range tests {
+		if !IsLocal(tt.ref) {
+			t.Errorf("IsLocal(%q) = false", tt.ref)
+		}
+		if got, err := Normalize(tt.ref); err != nil || got != tt.normalized {
+			t.Errorf("Normalize(%q) = %q, %v, want %q", tt.ref, got, err, tt.normalized)
+		}
+		if got := Package(tt.ref); got != "" {
+			t.Errorf("Package(%q) = %q, want empty", tt.ref, got)
+		}
+	}
+	for _, ref := range []string{"even", "@acme/x", "npm:fancy"} {
+		if IsLocal(ref) {
+			t.Errorf("IsLocal(%q) = true", ref)
+		}
+	}
+
+	projectDir := t.TempDir()
+	if got, want := Resolve(projectDir, "file:themes/brand"), filepath.Join(projectDir, "themes", "brand", "index.js"); got != want {
+		t.Errorf("Resolve() = %q, want %q", got, want)
+	}
+	if got := Resolve(projectDir, "even"); got != "jsonresume-theme-even" {
+		t.Errorf("Resolve(even) = %q", got)
+	}
+	if ref, version := SplitVersion("./brand@2"); ref != "./brand@2" || version != "" {
+		t.Errorf("SplitVersion() = %q, %q; local paths carry no version", ref, version)
+	}
+	if IsInstalled(projectDir, "file:themes/brand") {
+		t.Error("IsInstalled() = true for a missing directory")
+	}
+	if err := os.MkdirAll(filepath.Join(projectDir, "themes", "brand"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if !IsInstalled(projectDir, "file:themes/brand") {
+		t.Error("IsInstalled() = false for an existing directory")
+	}
+	if err := os.WriteFile(filepath.Join(projectDir, "themes", "brand", "package.json"), []byte(`{"main": "lib/theme.js"}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := Resolve(projectDir, "./themes/brand"), filepath.Join(projectDir, "themes", "brand", "lib", "theme.js"); got != want {
+		t.Errorf("Resolve() with main = %q, want %q", got, want)
+	}
+}
+
+func TestSplitVersion(t *testing.T) {
+	tests := []struct {
+		spec, ref, version string
+	}{
+		{"even", "even", ""},
+		{"even@1.2.0", "even", "1.2.0"},
+		{"@acme/jsonresume-theme-brand", "@acme/jsonresume-theme-brand", ""},
+		{"@acme/jsonresume-theme-brand@^2", "@acme/jsonresume-theme-brand", "^2"},
+		{"npm:@acme/x", "npm:@acme/x", ""},
+		{"npm:fancy@latest", "npm:fancy", "latest"},
+	}
+	for _, tt := range tests {
+		ref, version := SplitVersion(tt.spec)
+		if ref != tt.ref || version != tt.version {
+			t.Errorf("SplitVersion(%q) = %q, %q", tt.spec, ref, version)
+		}
+	}
+}
+
+func TestInstalled(t *testing.T) {
+	projectDir := t.TempDir()
+	if refs, err := Installed(projectDir); err != nil || refs != nil {
+		t.Errorf("no node_modules: %v, %v", refs, err)
+	}
+
+	for _, dir := range []string{"jsonresume-theme-flat", "jsonresume-theme-even", "resumed", "@acme/jsonresume-theme-brand", "@acme/other", "jsonresume-theme-"} {
+		if err := os.MkdirAll(filepath.Join(projectDir, "node_modules", dir), 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	refs, err := Installed(projectDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{"@acme/jsonresume-theme-brand", "even", "flat"}
+	if !reflect.DeepEqual(refs, want) {
+		t.Errorf("Installed() = %v, want %v", refs, want)
+	}
+	if !IsInstalled(projectDir, "@acme/jsonresume-theme-brand") || IsInstalled(projectDir, "stackoverflow") {
+		t.Error("IsInstalled() mismatch")
+	}
+}
+
+func TestVersion(t *testing.T) {
+	projectDir := t.TempDir()
+	pkgDir := filepath.Join(projectDir, "node_modules", "jsonresume-theme-even")
+	localDir := filepath.Join(projectDir, "themes", "brand")
+	for _, dir := range []string{pkgDir, filepath.Join(localDir, "node_modules")} {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	os.WriteFile(filepath.Join(pkgDir, "package.json"), []byte(`{"version": "2.1.0"}`), 0644)
+	os.WriteFile(filepath.Join(localDir, "index.js"), []byte("module.exports = {}"), 0644)
+
+	if v, err := Version(projectDir, "even"); err != nil || v != "2.1.0" {
+		t.Errorf("Version(even) = %q, %v", v, err)
+	}
+	if v, err := Version(projectDir, "flat"); err != nil || v != "" {
+		t.Errorf("Version(flat) = %q, %v", v, err)
+	}
+
+	local, err := Version(projectDir, "file:themes/brand")
+	if err != nil || !strings.HasPrefix(local, "sha256:") {
+		t.Fatalf("Version(local) = %q, %v", local, err)
+	}
+	// Dependencies don't count, the theme's own files do
+	os.WriteFile(filepath.Join(localDir, "node_modules", "dep.js"), []byte("x"), 0644)
+	if v, _ := Version(projectDir, "file:themes/brand"); v != local {
+		t.Error("node_modules changed the local theme version")
+	}
+	os.WriteFile(filepath.Join(localDir, "index.js"), []byte("module.exports = {render}"), 0644)
+	if v, _ := Version(projectDir, "file:themes/brand"); v == local {
+		t.Error("editing the local theme did not change its version")
+	}
+}
@@ -0,0 +1,146 @@
+package tui
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/richq/m2cv/internal/executor"
+	"github.com/richq/m2cv/internal/generator"
+)
+
+// jobDoneMsg reports the end of a background pipeline run.
+type jobDoneMsg struct {
+	message  string
+	warnings []string
+	err      error
+}
+
+// progressMsg carries streaming progress from a running job.
+type progressMsg struct {
+	chars  int
+	tokens int
+}
+
+// sender forwards messages into the running program. It is shared by
+// pointer so reporters created from a copy of the model still reach it.
+type sender struct {
+	mu   sync.Mutex
+	send func(tea.Msg)
+}
+
+// Send delivers msg if a program is attached.
+func (s *sender) Send(msg tea.Msg) {
+	s.mu.Lock()
+	send := s.send
+	s.mu.Unlock()
+	if send != nil {
+		send(msg)
+	}
+}
+
+// reporter adapts executor progress events to program messages.
+type reporter struct {
+	sender *sender
+}
+
+// Event forwards text and token counts.
+func (r reporter) Event(ev executor.StreamEvent) {
+	if ev.Text != "" || ev.OutputTokens > 0 {
+		r.sender.Send(progressMsg{chars: len(ev.Text), tokens: ev.OutputTokens})
+	}
+}
+
+// Done is a no-op; completion is reported by jobDoneMsg.
+func (r reporter) Done(err error) {}
+
+// startJob marks the model busy and returns the command running fn with a
+// cancellable context, plus the spinner tick.
+func (m *model) startJob(label string, fn func(ctx context.Context) jobDoneMsg) tea.Cmd {
+	ctx, cancel := context.WithCancel(m.ctx)
+	m.busy = label
+	m.cancelJob = cancel
+	m.chars, m.tokens = 0, 0
+	m.message, m.isErr = "", false
+
+	job := func() tea.Msg {
+		defer cancel()
+		return fn(ctx)
+	}
+	return tea.Batch(m.spinner.Tick, job)
+}
+
+// executeOptions streams progress from Claude into the program.
+func (m *model) executeOptions() []executor.ExecuteOption {
+	return []executor.ExecuteOption{executor.WithProgress(reporter{sender: m.sender})}
+}
+
+// settings returns the config whose BaseCVPath, Theme, ThemeOptions and
+// Models apply to the open application.
+func (m *model) settings() (Config, error) {
+	if m.cfg.ForApplication == nil {
+		return m.cfg, nil
+	}
+	return m.cfg.ForApplication(m.detail.dir)
+}
+
+// optimize runs the optimize pipeline for the open application.
+func (m *model) optimize(ats bool) tea.Cmd {
+	settings, err := m.settings()
+	if err != nil {
+		m.message, m.isErr = err.Error(), true
+		return nil
+	}
+	label := "Optimizing " + m.detail.name
+	if ats {
+		label += " (ATS)"
+	}
+	req := generator.OptimizeRequest{
+		AppDir:         m.detail.dir,
+		ProjectDir:     m.cfg.ProjectDir,
+		BaseCVPath:     settings.BaseCVPath,
+		Models:         settings.Models,
+		ATSMode:        ats,
+		Executor:       m.cfg.Executor,
+		ExecuteOptions: m.executeOptions(),
+		ToolVersion:    m.cfg.ToolVersion,
+		ToolCommit:     m.cfg.ToolCommit,
+	}
+	return m.startJob(label, func(ctx context.Context) jobDoneMsg {
+		result, err := generator.Optimize(ctx, req)
+		if err != nil {
+			return jobDoneMsg{err: err}
+		}
+		return jobDoneMsg{message: "Optimized CV written to " + result.OutputPath, warnings: result.Warnings}
+	})
+}
+
+// generate exports the selected version of the open application as a PDF.
+func (m *model) generate() tea.Cmd {
+	settings, err := m.settings()
+	if err != nil {
+		m.message, m.isErr = err.Error(), true
+		return nil
+	}
+	req := generator.GenerateRequest{
+		AppDir:         m.detail.dir,
+		ProjectDir:     m.cfg.ProjectDir,
+		CVPath:         m.detail.selectedPath(),
+		Theme:          settings.Theme,
+		ThemeOptions:   settings.ThemeOptions,
+		Models:         settings.Models,
+		Executor:       m.cfg.Executor,
+		ExecuteOptions: m.executeOptions(),
+		ToolVersion:    m.cfg.ToolVersion,
+		ToolCommit:     m.cfg.ToolCommit,
+	}
+	label := fmt.Sprintf("Generating PDF for %s v%d", m.detail.name, m.detail.selectedVersion())
+	return m.startJob(label, func(ctx context.Context) jobDoneMsg {
+		result, err := generator.Generate(ctx, req)
+		if err != nil {
+			return jobDoneMsg{err: err}
+		}
+		return jobDoneMsg{message: "PDF written to " + result.PDFPath, warnings: result.Warnings}
+	})
+}
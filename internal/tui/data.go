@@ -0,0 +1,151 @@
+package tui
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/richq/m2cv/internal/application"
+	"github.com/richq/m2cv/internal/diff"
+	"github.com/richq/m2cv/internal/scoring"
+)
+
+// pane is one of the views of an application's detail screen.
+type pane int
+
+const (
+	paneJob pane = iota
+	paneVersion
+	paneDiff
+	paneScore
+	paneCount
+)
+
+// paneNames are the tab labels, indexed by pane.
+var paneNames = [paneCount]string{"Job description", "Version", "Diff", "Score"}
+
+// detail holds everything shown for one application.
+type detail struct {
+	name           string
+	dir            string
+	status         *application.StatusInfo
+	jobDescription string
+	versions       []int
+	// selected indexes versions; -1 when there are none
+	selected int
+	hasPDF   bool
+}
+
+// loadDetail reads an application folder. The latest version is selected.
+func loadDetail(applicationsDir, name string) (*detail, error) {
+	dir := filepath.Join(applicationsDir, name)
+
+	status, err := application.ReadStatus(dir)
+	if err != nil {
+		return nil, err
+	}
+	versions, err := application.ListVersions(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	d := &detail{
+		name:     name,
+		dir:      dir,
+		status:   status,
+		versions: versions,
+		selected: len(versions) - 1,
+	}
+
+	if jdPath, err := application.FindJobDescription(dir); err == nil {
+		content, err := os.ReadFile(jdPath)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read job description: %w", err)
+		}
+		d.jobDescription = string(content)
+	}
+
+	if _, err := os.Stat(filepath.Join(dir, "resume.pdf")); err == nil {
+		d.hasPDF = true
+	}
+	return d, nil
+}
+
+// selectedVersion returns the selected version number, or 0 if there are none.
+func (d *detail) selectedVersion() int {
+	if d.selected < 0 {
+		return 0
+	}
+	return d.versions[d.selected]
+}
+
+// selectedPath returns the path of the selected version, or "" if there are none.
+func (d *detail) selectedPath() string {
+	if d.selected < 0 {
+		return ""
+	}
+	return application.VersionPath(d.dir, d.selectedVersion())
+}
+
+// readVersion returns the content of version n.
+func (d *detail) readVersion(n int) (string, error) {
+	content, err := os.ReadFile(application.VersionPath(d.dir, n))
+	if err != nil {
+		return "", fmt.Errorf("failed to read version %d: %w", n, err)
+	}
+	return string(content), nil
+}
+
+// content renders a pane as plain text for the viewport.
+func (d *detail) content(p pane, width int) string {
+	if p == paneJob {
+		if d.jobDescription == "" {
+			return dimStyle.Render("No job description (.txt) in this application.")
+		}
+		return wrap(d.jobDescription, width)
+	}
+
+	if d.selected < 0 {
+		return dimStyle.Render("No optimized versions yet. Press o to optimize.")
+	}
+	current, err := d.readVersion(d.selectedVersion())
+	if err != nil {
+		return errorStyle.Render(err.Error())
+	}
+
+	switch p {
+	case paneVersion:
+		return wrap(current, width)
+
+	case paneDiff:
+		if d.selected == 0 {
+			return dimStyle.Render("This is the first version; nothing to compare against.")
+		}
+		prev := d.versions[d.selected-1]
+		previous, err := d.readVersion(prev)
+		if err != nil {
+			return errorStyle.Render(err.Error())
+		}
+		out := diff.Unified(
+			filepath.Base(application.VersionPath(d.dir, prev)),
+			filepath.Base(d.selectedPath()),
+			previous, current, 3)
+		if out == "" {
+			return dimStyle.Render("No differences.")
+		}
+		return renderDiff(out)
+
+	case paneScore:
+		if d.jobDescription == "" {
+			return dimStyle.Render("No job description to score against.")
+		}
+		r := scoring.Score(current, d.jobDescription)
+		var b strings.Builder
+		fmt.Fprintf(&b, "Keyword coverage: %d%%\n\n", r.Score)
+		fmt.Fprintf(&b, "%s (%d)\n%s\n\n", addStyle.Render("Matched"), len(r.Matched), wrap(strings.Join(r.Matched, ", "), width))
+		fmt.Fprintf(&b, "%s (%d)\n%s\n", delStyle.Render("Missing"), len(r.Missing), wrap(strings.Join(r.Missing, ", "), width))
+		return b.String()
+	}
+	return ""
+}
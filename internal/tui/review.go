@@ -0,0 +1,343 @@
+package tui
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textarea"
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/richq/m2cv/internal/diff"
+)
+
+// RunReview lets the user accept, reject or edit each hunk of review,
+// recording the decisions in review.Hunks. It reports whether the user
+// chose to write the result; quitting leaves the decisions unused.
+func RunReview(ctx context.Context, title string, review *diff.Review) (bool, error) {
+	m := newReviewModel(title, review)
+	p := tea.NewProgram(m, tea.WithAltScreen(), tea.WithContext(ctx))
+	if _, err := p.Run(); err != nil {
+		return false, err
+	}
+	return m.write, nil
+}
+
+// reviewModel is the bubbletea model of m2cv review.
+type reviewModel struct {
+	title   string
+	review  *diff.Review
+	current int
+
+	// editing is set while the editor replaces the hunk view
+	editing  bool
+	editor   textarea.Model
+	viewport viewport.Model
+
+	write   bool
+	message string
+	isErr   bool
+
+	width  int
+	height int
+}
+
+// newReviewModel starts the review at the first hunk.
+func newReviewModel(title string, review *diff.Review) *reviewModel {
+	m := &reviewModel{
+		title:    title,
+		review:   review,
+		editor:   textarea.New(),
+		viewport: viewport.New(80, 18),
+		width:    80,
+		height:   24,
+	}
+	m.editor.ShowLineNumbers = false
+	m.refresh()
+	return m
+}
+
+// Init implements tea.Model.
+func (m *reviewModel) Init() tea.Cmd {
+	return nil
+}
+
+// hunk returns the hunk being reviewed.
+func (m *reviewModel) hunk() *diff.ReviewHunk {
+	return &m.review.Hunks[m.current]
+}
+
+// refresh renders the current hunk into the viewport.
+func (m *reviewModel) refresh() {
+	m.viewport.SetContent(renderReviewHunk(m.hunk()))
+	m.viewport.GotoTop()
+}
+
+// Update implements tea.Model.
+func (m *reviewModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width, m.height = msg.Width, msg.Height
+		m.viewport.Width = msg.Width
+		m.viewport.Height = max(msg.Height-6, 1)
+		m.editor.SetWidth(msg.Width)
+		m.editor.SetHeight(max(msg.Height-8, 3))
+		return m, nil
+
+	case tea.KeyMsg:
+		if msg.String() == "ctrl+c" {
+			return m, tea.Quit
+		}
+		if m.editing {
+			return m.handleEditKey(msg)
+		}
+		return m.handleKey(msg)
+	}
+	return m, nil
+}
+
+// handleKey handles keys while reviewing hunks.
+func (m *reviewModel) handleKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	m.message, m.isErr = "", false
+	switch msg.String() {
+	case "q", "esc":
+		return m, tea.Quit
+	case "y", "a":
+		m.decide(diff.Accepted)
+	case "n", "r":
+		m.decide(diff.Rejected)
+	case "e":
+		return m, m.startEdit()
+	case "A", "R":
+		choice := diff.Accepted
+		if msg.String() == "R" {
+			choice = diff.Rejected
+		}
+		for i := range m.review.Hunks {
+			if m.review.Hunks[i].Choice == diff.Undecided {
+				m.review.Hunks[i].Choice = choice
+			}
+		}
+		m.message = fmt.Sprintf("Remaining hunks %s. Press w to write.", choice)
+	case "right", "l":
+		m.move(m.current + 1)
+	case "left", "h":
+		m.move(m.current - 1)
+	case "]":
+		m.move(m.sectionStart(1))
+	case "[":
+		m.move(m.sectionStart(-1))
+	case "w":
+		if undecided := m.review.Count(diff.Undecided); undecided > 0 {
+			m.message, m.isErr = fmt.Sprintf("%d hunk(s) undecided: decide them, or press A/R to accept/reject the rest", undecided), true
+			return m, nil
+		}
+		m.write = true
+		return m, tea.Quit
+	default:
+		var cmd tea.Cmd
+		m.viewport, cmd = m.viewport.Update(msg)
+		return m, cmd
+	}
+	return m, nil
+}
+
+// handleEditKey handles keys in the hunk editor: ctrl+s keeps the edit,
+// esc discards it.
+func (m *reviewModel) handleEditKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "ctrl+s":
+		h := m.hunk()
+		h.Choice = diff.Edited
+		h.Replacement = nil
+		if text := m.editor.Value(); text != "" {
+			h.Replacement = strings.Split(text, "\n")
+		}
+		m.editing = false
+		m.editor.Blur()
+		m.next()
+		return m, nil
+	case "esc":
+		m.editing = false
+		m.editor.Blur()
+		m.refresh()
+		return m, nil
+	}
+	var cmd tea.Cmd
+	m.editor, cmd = m.editor.Update(msg)
+	return m, cmd
+}
+
+// startEdit opens the editor on the hunk's new text, or on the previous
+// edit.
+func (m *reviewModel) startEdit() tea.Cmd {
+	h := m.hunk()
+	lines := h.New
+	if h.Choice == diff.Edited {
+		lines = h.Replacement
+	}
+	m.editor.SetValue(strings.Join(lines, "\n"))
+	m.editing = true
+	return m.editor.Focus()
+}
+
+// decide records choice for the current hunk and moves on.
+func (m *reviewModel) decide(choice diff.Choice) {
+	m.hunk().Choice = choice
+	m.next()
+}
+
+// next moves to the next undecided hunk after the current one, wrapping
+// around; it stays put once every hunk is decided.
+func (m *reviewModel) next() {
+	count := len(m.review.Hunks)
+	for i := 1; i <= count; i++ {
+		j := (m.current + i) % count
+		if m.review.Hunks[j].Choice == diff.Undecided {
+			m.move(j)
+			return
+		}
+	}
+	m.refresh()
+	m.message = "All hunks decided. Press w to write the new version, or revisit with ←/→."
+}
+
+// move shows hunk i if it exists.
+func (m *reviewModel) move(i int) {
+	if i < 0 || i >= len(m.review.Hunks) {
+		return
+	}
+	m.current = i
+	m.refresh()
+}
+
+// sectionStart returns the first hunk of the next (dir 1) or previous
+// (dir -1) section, or the current hunk if there is none.
+func (m *reviewModel) sectionStart(dir int) int {
+	hunks := m.review.Hunks
+	section := hunks[m.current].Section
+	i := m.current
+	if dir < 0 {
+		// Back to the start of the current section, then into the previous
+		for i > 0 && hunks[i-1].Section == section {
+			i--
+		}
+		if i == 0 {
+			return m.current
+		}
+		i--
+		for i > 0 && hunks[i-1].Section == hunks[i].Section {
+			i--
+		}
+		return i
+	}
+	for i < len(hunks) && hunks[i].Section == section {
+		i++
+	}
+	if i == len(hunks) {
+		return m.current
+	}
+	return i
+}
+
+// View implements tea.Model.
+func (m *reviewModel) View() string {
+	var b strings.Builder
+	h := m.hunk()
+	b.WriteString(titleStyle.Render(m.title) + fmt.Sprintf("  hunk %d of %d", m.current+1, len(m.review.Hunks)) + "\n")
+	b.WriteString(m.viewSections() + "\n\n")
+
+	if m.editing {
+		b.WriteString(hunkStyle.Render(hunkHeader(h)+" (editing)") + "\n")
+		b.WriteString(m.editor.View() + "\n")
+		b.WriteString(dimStyle.Render("ctrl+s keep edit • esc cancel"))
+		return b.String()
+	}
+
+	b.WriteString(m.viewport.View() + "\n")
+	switch {
+	case m.isErr:
+		b.WriteString(errorStyle.Render(m.message))
+	case m.message != "":
+		b.WriteString(m.message)
+	default:
+		b.WriteString("This hunk: " + renderChoice(h.Choice))
+	}
+	b.WriteString("\n" + dimStyle.Render("y accept • n reject • e edit • A/R accept/reject rest • ←/→ hunk • [/] section • w write • q quit"))
+	return b.String()
+}
+
+// viewSections summarizes the review per section, highlighting the
+// current one: "Experience 1/3" counts decided hunks.
+func (m *reviewModel) viewSections() string {
+	var parts []string
+	current := m.hunk().Section
+	for i := 0; i < len(m.review.Hunks); {
+		section := m.review.Hunks[i].Section
+		total, decided := 0, 0
+		for ; i < len(m.review.Hunks) && m.review.Hunks[i].Section == section; i++ {
+			total++
+			if m.review.Hunks[i].Choice != diff.Undecided {
+				decided++
+			}
+		}
+		part := fmt.Sprintf("%s %d/%d", sectionLabel(section), decided, total)
+		if section == current {
+			part = selectedStyle.Render(part)
+		} else {
+			part = dimStyle.Render(part)
+		}
+		parts = append(parts, part)
+	}
+	return strings.Join(parts, "  ")
+}
+
+// renderReviewHunk renders a hunk with its context, showing an edit in
+// place of the new lines.
+func renderReviewHunk(h *diff.ReviewHunk) string {
+	lines := []string{hunkStyle.Render(hunkHeader(h))}
+	for _, line := range h.Before {
+		lines = append(lines, " "+line)
+	}
+	for _, line := range h.Old {
+		lines = append(lines, delStyle.Render("-"+line))
+	}
+	added := h.New
+	if h.Choice == diff.Edited {
+		added = h.Replacement
+	}
+	for _, line := range added {
+		lines = append(lines, addStyle.Render("+"+line))
+	}
+	for _, line := range h.After {
+		lines = append(lines, " "+line)
+	}
+	return strings.Join(lines, "\n")
+}
+
+// hunkHeader returns a unified diff style header with the section.
+func hunkHeader(h *diff.ReviewHunk) string {
+	return fmt.Sprintf("@@ -%d,%d +%d,%d @@ %s", h.OldStart, len(h.Old), h.NewStart, len(h.New), sectionLabel(h.Section))
+}
+
+// sectionLabel names a section, including the text before the first one.
+func sectionLabel(section string) string {
+	if section == "" {
+		return "(before the first section)"
+	}
+	return section
+}
+
+// renderChoice colors a hunk decision.
+func renderChoice(c diff.Choice) string {
+	switch c {
+	case diff.Accepted:
+		return addStyle.Render(c.String())
+	case diff.Rejected:
+		return delStyle.Render(c.String())
+	case diff.Edited:
+		return hunkStyle.Render(c.String())
+	default:
+		return dimStyle.Render(c.String())
+	}
+}
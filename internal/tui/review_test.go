@@ -0,0 +1,95 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/richq/m2cv/internal/diff"
+)
+
+const (
+	reviewOld = "# Summary\n\nBackend developer.\n\n# Experience\n\n- Built billing\n- Ran on-call\n\n# Skills\n\nGo\n"
+	reviewNew = "# Summary\n\nGo backend developer.\n\n# Experience\n\n- Built billing in Go\n- Ran on-call\n- Led the migration\n\n# Skills\n\nGo, SQL\n"
+)
+
+// newTestReview creates a review model over reviewOld and reviewNew.
+func newTestReview(t *testing.T) *reviewModel {
+	t.Helper()
+	m := newReviewModel("acme: optimized-cv-1.md → optimized-cv-2.md", diff.NewReview(reviewOld, reviewNew))
+	m.Update(tea.WindowSizeMsg{Width: 100, Height: 30})
+	if len(m.review.Hunks) != 4 {
+		t.Fatalf("got %d hunks, want 4", len(m.review.Hunks))
+	}
+	return m
+}
+
+func TestReviewModel_Decisions(t *testing.T) {
+	m := newTestReview(t)
+
+	view := m.View()
+	for _, want := range []string{"hunk 1 of 4", "Summary 0/1", "Experience 0/2", "Skills 0/1", "-Backend developer.", "+Go backend developer."} {
+		if !strings.Contains(view, want) {
+			t.Errorf("view missing %q:\n%s", want, view)
+		}
+	}
+
+	// Accept, reject, then edit the added bullet
+	press(m, "y", "n", "e")
+	if !m.editing || m.editor.Value() != "- Led the migration" {
+		t.Fatalf("editor = %v %q", m.editing, m.editor.Value())
+	}
+	m.editor.SetValue("- Led the database migration")
+	press(m, "ctrl+s")
+
+	// Writing with a hunk undecided is refused
+	if cmd := press(m, "w"); cmd != nil || !m.isErr {
+		t.Errorf("w with undecided hunks should be refused: %q", m.message)
+	}
+	press(m, "R")
+	if cmd := press(m, "w"); cmd == nil || !m.write {
+		t.Fatal("w should write once every hunk is decided")
+	}
+
+	want := "# Summary\n\nGo backend developer.\n\n# Experience\n\n- Built billing\n- Ran on-call\n- Led the database migration\n\n# Skills\n\nGo\n"
+	if got := m.review.Text(); got != want {
+		t.Errorf("Text() =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestReviewModel_Navigation(t *testing.T) {
+	m := newTestReview(t)
+
+	press(m, "]")
+	if m.current != 1 {
+		t.Errorf("] moved to hunk %d, want 1 (Experience)", m.current)
+	}
+	press(m, "]")
+	if m.current != 3 {
+		t.Errorf("] moved to hunk %d, want 3 (Skills)", m.current)
+	}
+	press(m, "[")
+	if m.current != 1 {
+		t.Errorf("[ moved to hunk %d, want 1", m.current)
+	}
+	press(m, "h", "h")
+	if m.current != 0 {
+		t.Errorf("← stopped at hunk %d, want 0", m.current)
+	}
+
+	// Deciding the last hunk wraps to the first undecided one
+	press(m, "]", "]", "a")
+	if m.current != 0 {
+		t.Errorf("after the last hunk, current = %d, want 0", m.current)
+	}
+
+	// Cancelling an edit keeps the hunk undecided
+	press(m, "e", "esc")
+	if m.editing || m.hunk().Choice != diff.Undecided {
+		t.Errorf("esc should cancel the edit: editing %v, choice %s", m.editing, m.hunk().Choice)
+	}
+
+	if cmd := press(m, "q"); cmd == nil || m.write {
+		t.Error("q should quit without writing")
+	}
+}
@@ -0,0 +1,62 @@
+package tui
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/richq/m2cv/internal/application"
+)
+
+var (
+	titleStyle     = lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("12"))
+	selectedStyle  = lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("10"))
+	dimStyle       = lipgloss.NewStyle().Foreground(lipgloss.Color("8"))
+	errorStyle     = lipgloss.NewStyle().Foreground(lipgloss.Color("9"))
+	tabStyle       = lipgloss.NewStyle().Padding(0, 1).Foreground(lipgloss.Color("8"))
+	activeTabStyle = lipgloss.NewStyle().Padding(0, 1).Bold(true).Underline(true)
+
+	addStyle  = lipgloss.NewStyle().Foreground(lipgloss.Color("2"))
+	delStyle  = lipgloss.NewStyle().Foreground(lipgloss.Color("1"))
+	hunkStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("6"))
+)
+
+// statusColors gives each application status a distinct color.
+var statusColors = map[application.Status]lipgloss.Color{
+	application.StatusDraft:        "8",
+	application.StatusApplied:      "12",
+	application.StatusInterviewing: "11",
+	application.StatusOffer:        "10",
+	application.StatusRejected:     "9",
+	application.StatusWithdrawn:    "5",
+}
+
+// renderStatus colors a status name.
+func renderStatus(s application.Status) string {
+	return lipgloss.NewStyle().Foreground(statusColors[s]).Render(string(s))
+}
+
+// renderDiff colors a unified diff line by line.
+func renderDiff(unified string) string {
+	lines := strings.Split(strings.TrimRight(unified, "\n"), "\n")
+	for i, line := range lines {
+		switch {
+		case strings.HasPrefix(line, "+++"), strings.HasPrefix(line, "---"):
+			lines[i] = dimStyle.Render(line)
+		case strings.HasPrefix(line, "@@"):
+			lines[i] = hunkStyle.Render(line)
+		case strings.HasPrefix(line, "+"):
+			lines[i] = addStyle.Render(line)
+		case strings.HasPrefix(line, "-"):
+			lines[i] = delStyle.Render(line)
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// wrap soft-wraps text to width; widths below 10 leave text untouched.
+func wrap(text string, width int) string {
+	if width < 10 {
+		return text
+	}
+	return lipgloss.NewStyle().Width(width).Render(text)
+}
@@ -0,0 +1,428 @@
+// Package tui implements the full-screen m2cv ui: a bubbletea app for
+// browsing applications and running the optimize and generate pipelines.
+package tui
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/spinner"
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/richq/m2cv/internal/application"
+	"github.com/richq/m2cv/internal/config"
+	"github.com/richq/m2cv/internal/executor"
+)
+
+// Config describes the project the UI works on.
+type Config struct {
+	// ApplicationsDir holds one folder per job application
+	ApplicationsDir string
+	// ProjectDir contains node_modules with resumed and the themes
+	ProjectDir string
+	// BaseCVPath is the resolved path of the base CV markdown
+	BaseCVPath string
+	// Theme is the JSON Resume theme for PDF export
+	Theme string
+	// ThemeOptions are the project's theme options for PDF export
+	ThemeOptions config.ThemeOptions
+	// Models resolves the Claude model and parameters per command
+	Models config.ModelSettings
+	// ForApplication, when set, returns the config in effect for one
+	// application folder: the project's layered with the folder's
+	// application.yml. Only its BaseCVPath, Theme, ThemeOptions and Models
+	// are used. Nil applies the fields above to every application.
+	ForApplication func(appDir string) (Config, error)
+	// ToolVersion and ToolCommit identify the m2cv build (for provenance)
+	ToolVersion string
+	ToolCommit  string
+	// Executor runs Claude. Defaults to executor.NewClaudeExecutor().
+	Executor executor.ClaudeExecutor
+}
+
+// Run starts the UI and blocks until the user quits or ctx is cancelled.
+func Run(ctx context.Context, cfg Config) error {
+	m, err := newModel(ctx, cfg)
+	if err != nil {
+		return err
+	}
+
+	p := tea.NewProgram(m, tea.WithAltScreen(), tea.WithContext(ctx))
+	m.sender.send = p.Send
+	_, err = p.Run()
+	return err
+}
+
+// model is the bubbletea model. It is used by pointer so commands and
+// key handlers can update it in place.
+type model struct {
+	ctx    context.Context
+	cfg    Config
+	sender *sender
+
+	apps   []application.Summary
+	cursor int
+
+	// detail is the open application; nil on the list screen
+	detail        *detail
+	pane          pane
+	viewport      viewport.Model
+	pickingStatus bool
+
+	spinner   spinner.Model
+	busy      string
+	cancelJob context.CancelFunc
+	chars     int
+	tokens    int
+
+	message string
+	isErr   bool
+
+	width  int
+	height int
+}
+
+// newModel loads the application list.
+func newModel(ctx context.Context, cfg Config) (*model, error) {
+	m := &model{
+		ctx:      ctx,
+		cfg:      cfg,
+		sender:   &sender{},
+		spinner:  spinner.New(spinner.WithSpinner(spinner.Dot)),
+		viewport: viewport.New(80, 20),
+		width:    80,
+		height:   24,
+	}
+	if err := m.reloadApps(); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// Init implements tea.Model.
+func (m *model) Init() tea.Cmd {
+	return nil
+}
+
+// reloadApps refreshes the application list, keeping the cursor in range.
+func (m *model) reloadApps() error {
+	apps, err := application.Summarize(m.cfg.ApplicationsDir)
+	if err != nil {
+		return err
+	}
+	m.apps = apps
+	if m.cursor >= len(apps) {
+		m.cursor = len(apps) - 1
+	}
+	if m.cursor < 0 {
+		m.cursor = 0
+	}
+	return nil
+}
+
+// openDetail loads an application and shows its detail screen.
+func (m *model) openDetail(name string) {
+	d, err := loadDetail(m.cfg.ApplicationsDir, name)
+	if err != nil {
+		m.setError(err)
+		return
+	}
+	m.detail = d
+	m.refreshViewport(true)
+}
+
+// reloadDetail re-reads the open application, selecting the latest version.
+func (m *model) reloadDetail() {
+	if m.detail == nil {
+		return
+	}
+	m.openDetail(m.detail.name)
+	if err := m.reloadApps(); err != nil {
+		m.setError(err)
+	}
+}
+
+// refreshViewport renders the current pane, optionally scrolling to the top.
+func (m *model) refreshViewport(top bool) {
+	if m.detail == nil {
+		return
+	}
+	m.viewport.SetContent(m.detail.content(m.pane, m.viewport.Width))
+	if top {
+		m.viewport.GotoTop()
+	}
+}
+
+// setError shows err in the message line.
+func (m *model) setError(err error) {
+	m.message, m.isErr = err.Error(), true
+}
+
+// Update implements tea.Model.
+func (m *model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width, m.height = msg.Width, msg.Height
+		m.viewport.Width = msg.Width
+		m.viewport.Height = max(msg.Height-4, 1)
+		m.refreshViewport(false)
+		return m, nil
+
+	case spinner.TickMsg:
+		if m.busy == "" {
+			return m, nil
+		}
+		var cmd tea.Cmd
+		m.spinner, cmd = m.spinner.Update(msg)
+		return m, cmd
+
+	case progressMsg:
+		m.chars += msg.chars
+		if msg.tokens > m.tokens {
+			m.tokens = msg.tokens
+		}
+		return m, nil
+
+	case jobDoneMsg:
+		m.busy, m.cancelJob = "", nil
+		if msg.err != nil {
+			m.setError(msg.err)
+		} else {
+			m.message, m.isErr = msg.message, false
+			for _, w := range msg.warnings {
+				m.message += " (warning: " + w + ")"
+			}
+		}
+		m.reloadDetail()
+		return m, nil
+
+	case tea.KeyMsg:
+		return m.handleKey(msg)
+	}
+	return m, nil
+}
+
+// handleKey dispatches a key press for the current screen.
+func (m *model) handleKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	key := msg.String()
+
+	if key == "ctrl+c" {
+		if m.cancelJob != nil {
+			m.cancelJob()
+			m.message, m.isErr = "Cancelling...", false
+			return m, nil
+		}
+		return m, tea.Quit
+	}
+
+	if m.pickingStatus {
+		return m.handleStatusKey(key)
+	}
+
+	if m.detail == nil {
+		return m.handleListKey(key)
+	}
+	return m.handleDetailKey(msg)
+}
+
+// handleListKey handles keys on the application list.
+func (m *model) handleListKey(key string) (tea.Model, tea.Cmd) {
+	switch key {
+	case "q":
+		return m, tea.Quit
+	case "up", "k":
+		if m.cursor > 0 {
+			m.cursor--
+		}
+	case "down", "j":
+		if m.cursor < len(m.apps)-1 {
+			m.cursor++
+		}
+	case "enter", "right", "l":
+		if len(m.apps) > 0 {
+			m.message = ""
+			m.openDetail(m.apps[m.cursor].Name)
+		}
+	case "r":
+		if err := m.reloadApps(); err != nil {
+			m.setError(err)
+		}
+	}
+	return m, nil
+}
+
+// handleDetailKey handles keys on an application's detail screen.
+func (m *model) handleDetailKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	d := m.detail
+	switch msg.String() {
+	case "q":
+		if m.busy == "" {
+			return m, tea.Quit
+		}
+	case "esc", "backspace":
+		if m.busy == "" {
+			m.detail = nil
+			m.message = ""
+			if err := m.reloadApps(); err != nil {
+				m.setError(err)
+			}
+		}
+	case "tab":
+		m.pane = (m.pane + 1) % paneCount
+		m.refreshViewport(true)
+	case "shift+tab":
+		m.pane = (m.pane + paneCount - 1) % paneCount
+		m.refreshViewport(true)
+	case "1", "2", "3", "4":
+		m.pane = pane(msg.String()[0] - '1')
+		m.refreshViewport(true)
+	case "left", "h", "[":
+		if d.selected > 0 {
+			d.selected--
+			m.refreshViewport(true)
+		}
+	case "right", "l", "]":
+		if d.selected >= 0 && d.selected < len(d.versions)-1 {
+			d.selected++
+			m.refreshViewport(true)
+		}
+	case "s":
+		m.pane = paneScore
+		m.refreshViewport(true)
+	case "t":
+		if m.busy == "" {
+			m.pickingStatus = true
+		}
+	case "o", "a":
+		if m.busy == "" {
+			return m, m.optimize(msg.String() == "a")
+		}
+	case "g":
+		if m.busy == "" {
+			if d.selected < 0 {
+				m.message, m.isErr = "No optimized version to export yet. Press o to optimize.", true
+				return m, nil
+			}
+			return m, m.generate()
+		}
+	case "r":
+		if m.busy == "" {
+			m.reloadDetail()
+		}
+	default:
+		var cmd tea.Cmd
+		m.viewport, cmd = m.viewport.Update(msg)
+		return m, cmd
+	}
+	return m, nil
+}
+
+// handleStatusKey handles the status picker: 1-6 choose, esc cancels.
+func (m *model) handleStatusKey(key string) (tea.Model, tea.Cmd) {
+	m.pickingStatus = false
+	if len(key) != 1 || key[0] < '1' || int(key[0]-'1') >= len(application.Statuses) {
+		return m, nil
+	}
+
+	status := application.Statuses[key[0]-'1']
+	if _, err := application.WriteStatus(m.detail.dir, status, ""); err != nil {
+		m.setError(err)
+		return m, nil
+	}
+	m.message, m.isErr = fmt.Sprintf("%s is now %s", m.detail.name, status), false
+	m.reloadDetail()
+	return m, nil
+}
+
+// View implements tea.Model.
+func (m *model) View() string {
+	var b strings.Builder
+	if m.detail == nil {
+		m.viewList(&b)
+	} else {
+		m.viewDetail(&b)
+	}
+	b.WriteString("\n")
+	b.WriteString(m.viewFooter())
+	return b.String()
+}
+
+// viewList renders the application list.
+func (m *model) viewList(b *strings.Builder) {
+	b.WriteString(titleStyle.Render("m2cv applications") + "\n\n")
+	if len(m.apps) == 0 {
+		b.WriteString(dimStyle.Render("No applications yet. Create one with 'm2cv apply'.") + "\n")
+		return
+	}
+
+	width := 0
+	for _, app := range m.apps {
+		width = max(width, len(app.Name))
+	}
+	for i, app := range m.apps {
+		name := fmt.Sprintf("%-*s", width, app.Name)
+		line := fmt.Sprintf("%s  %-21s  %d version(s)", name, renderStatus(app.Status), len(app.Versions))
+		if app.HasPDF {
+			line += "  pdf"
+		}
+		if i == m.cursor {
+			b.WriteString(selectedStyle.Render("> ") + selectedStyle.Render(name) + strings.TrimPrefix(line, name) + "\n")
+		} else {
+			b.WriteString("  " + line + "\n")
+		}
+	}
+}
+
+// viewDetail renders the open application's header, tabs and pane.
+func (m *model) viewDetail(b *strings.Builder) {
+	d := m.detail
+	header := titleStyle.Render(d.name) + "  [" + renderStatus(d.status.Status) + "]"
+	if d.selected >= 0 {
+		header += fmt.Sprintf("  v%d of %d", d.selectedVersion(), len(d.versions))
+	}
+	if d.hasPDF {
+		header += dimStyle.Render("  pdf")
+	}
+	b.WriteString(header + "\n")
+
+	tabs := make([]string, paneCount)
+	for i, name := range paneNames {
+		if pane(i) == m.pane {
+			tabs[i] = activeTabStyle.Render(name)
+		} else {
+			tabs[i] = tabStyle.Render(name)
+		}
+	}
+	b.WriteString(strings.Join(tabs, "|") + "\n")
+	b.WriteString(m.viewport.View())
+}
+
+// viewFooter renders the status line and key help.
+func (m *model) viewFooter() string {
+	var status string
+	switch {
+	case m.busy != "":
+		progress := fmt.Sprintf("%d chars", m.chars)
+		if m.tokens > 0 {
+			progress = fmt.Sprintf("%d tokens", m.tokens)
+		}
+		status = fmt.Sprintf("%s %s... (%s, ctrl+c to cancel)", m.spinner.View(), m.busy, progress)
+	case m.pickingStatus:
+		parts := make([]string, len(application.Statuses))
+		for i, s := range application.Statuses {
+			parts[i] = fmt.Sprintf("%d %s", i+1, renderStatus(s))
+		}
+		status = "Set status: " + strings.Join(parts, "  ") + dimStyle.Render("  (esc to cancel)")
+	case m.isErr:
+		status = errorStyle.Render(m.message)
+	default:
+		status = m.message
+	}
+
+	help := "↑/↓ select • enter open • r reload • q quit"
+	if m.detail != nil {
+		help = "tab pane • ←/→ version • o optimize • a optimize (ATS) • g PDF • s score • t status • esc back • q quit"
+	}
+	return status + "\n" + dimStyle.Render(help)
+}
@@ -0,0 +1,283 @@
+package tui
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/richq/m2cv/internal/application"
+	"github.com/richq/m2cv/internal/executor"
+	"github.com/richq/m2cv/internal/provenance"
+)
+
+// stubExecutor returns a canned response.
+type stubExecutor struct {
+	output string
+	err    error
+}
+
+func (s *stubExecutor) Execute(ctx context.Context, prompt string, opts ...executor.ExecuteOption) (string, error) {
+	return s.output, s.err
+}
+
+func (s *stubExecutor) ExecuteInteractive(ctx context.Context, cfg executor.InteractiveConfig) error {
+	return nil
+}
+
+// newTestModel creates a project with two applications: acme with two
+// versions and beta with none.
+func newTestModel(t *testing.T, exec executor.ClaudeExecutor) *model {
+	t.Helper()
+	root := t.TempDir()
+	appsDir := filepath.Join(root, "applications")
+	files := map[string]string{
+		"acme/job-description.txt": "Go Kubernetes Terraform",
+		"acme/optimized-cv-1.md":   "# CV\n- Go\n",
+		"acme/optimized-cv-2.md":   "# CV\n- Go\n- Kubernetes\n",
+		"beta/job-description.txt": "Rust",
+	}
+	for name, content := range files {
+		path := filepath.Join(appsDir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	basePath := filepath.Join(root, "base-cv.md")
+	if err := os.WriteFile(basePath, []byte("# Base"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	m, err := newModel(context.Background(), Config{
+		ApplicationsDir: appsDir,
+		ProjectDir:      root,
+		BaseCVPath:      basePath,
+		Executor:        exec,
+	})
+	if err != nil {
+		t.Fatalf("newModel() error = %v", err)
+	}
+	m.Update(tea.WindowSizeMsg{Width: 100, Height: 30})
+	return m
+}
+
+// press sends key presses to the model and returns the last command.
+func press(m tea.Model, keys ...string) tea.Cmd {
+	var cmd tea.Cmd
+	for _, k := range keys {
+		var msg tea.KeyMsg
+		switch k {
+		case "enter":
+			msg = tea.KeyMsg{Type: tea.KeyEnter}
+		case "esc":
+			msg = tea.KeyMsg{Type: tea.KeyEsc}
+		case "tab":
+			msg = tea.KeyMsg{Type: tea.KeyTab}
+		case "down":
+			msg = tea.KeyMsg{Type: tea.KeyDown}
+		case "ctrl+c":
+			msg = tea.KeyMsg{Type: tea.KeyCtrlC}
+		case "ctrl+s":
+			msg = tea.KeyMsg{Type: tea.KeyCtrlS}
+		default:
+			msg = tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(k)}
+		}
+		_, cmd = m.Update(msg)
+	}
+	return cmd
+}
+
+// runJob executes a job command and feeds its jobDoneMsg back to the model.
+func runJob(t *testing.T, m *model, cmd tea.Cmd) jobDoneMsg {
+	t.Helper()
+	if cmd == nil {
+		t.Fatal("expected a job command")
+	}
+	batch, ok := cmd().(tea.BatchMsg)
+	if !ok {
+		t.Fatal("expected a batch of spinner tick and job")
+	}
+	for _, c := range batch {
+		if c == nil {
+			continue
+		}
+		if done, ok := c().(jobDoneMsg); ok {
+			m.Update(done)
+			return done
+		}
+	}
+	t.Fatal("job did not report completion")
+	return jobDoneMsg{}
+}
+
+func TestModel_ListView(t *testing.T) {
+	m := newTestModel(t, &stubExecutor{})
+
+	view := m.View()
+	for _, want := range []string{"acme", "beta", "2 version(s)", "draft"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("list view missing %q:\n%s", want, view)
+		}
+	}
+
+	press(m, "down", "down")
+	if m.cursor != 1 {
+		t.Errorf("cursor = %d, want 1 (clamped)", m.cursor)
+	}
+	press(m, "k")
+	if m.cursor != 0 {
+		t.Errorf("cursor = %d after up, want 0", m.cursor)
+	}
+
+	if cmd := press(m, "q"); cmd == nil {
+		t.Error("q should quit")
+	}
+}
+
+func TestModel_DetailPanesAndVersions(t *testing.T) {
+	m := newTestModel(t, &stubExecutor{})
+	press(m, "enter")
+	if m.detail == nil || m.detail.name != "acme" {
+		t.Fatal("enter should open acme")
+	}
+	if m.detail.selectedVersion() != 2 {
+		t.Errorf("selected version = %d, want latest", m.detail.selectedVersion())
+	}
+	if !strings.Contains(m.View(), "Go Kubernetes Terraform") {
+		t.Error("job description pane should be shown first")
+	}
+
+	press(m, "3")
+	if m.pane != paneDiff || !strings.Contains(m.View(), "+- Kubernetes") {
+		t.Errorf("diff pane should show the change from v1:\n%s", m.View())
+	}
+
+	press(m, "[")
+	if m.detail.selectedVersion() != 1 || !strings.Contains(m.View(), "nothing to compare") {
+		t.Errorf("v1 diff should have nothing to compare:\n%s", m.View())
+	}
+
+	press(m, "tab")
+	if m.pane != paneScore || !strings.Contains(m.View(), "Keyword coverage: 33%") {
+		t.Errorf("score pane for v1:\n%s", m.View())
+	}
+
+	press(m, "esc")
+	if m.detail != nil {
+		t.Error("esc should return to the list")
+	}
+}
+
+func TestModel_StatusPicker(t *testing.T) {
+	m := newTestModel(t, &stubExecutor{})
+	press(m, "enter", "t")
+	if !m.pickingStatus || !strings.Contains(m.View(), "Set status") {
+		t.Fatal("t should open the status picker")
+	}
+
+	press(m, "2")
+	info, err := application.ReadStatus(m.detail.dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.Status != application.StatusApplied {
+		t.Errorf("status = %s, want applied", info.Status)
+	}
+	if !strings.Contains(m.message, "acme is now applied") {
+		t.Errorf("message = %q", m.message)
+	}
+}
+
+func TestModel_Optimize(t *testing.T) {
+	m := newTestModel(t, &stubExecutor{output: "# CV\n- Go\n- Terraform\n"})
+	press(m, "enter")
+
+	cmd := press(m, "o")
+	if m.busy == "" || !strings.Contains(m.View(), "Optimizing acme") {
+		t.Fatalf("optimize should show progress:\n%s", m.View())
+	}
+	if press(m, "g") != nil {
+		t.Error("actions should be ignored while a job runs")
+	}
+
+	done := runJob(t, m, cmd)
+	if done.err != nil {
+		t.Fatalf("optimize failed: %v", done.err)
+	}
+	if m.busy != "" {
+		t.Error("model should be idle after the job")
+	}
+	if m.detail.selectedVersion() != 3 {
+		t.Errorf("selected version = %d, want new version 3", m.detail.selectedVersion())
+	}
+	if !strings.Contains(m.message, "optimized-cv-3.md") {
+		t.Errorf("message = %q", m.message)
+	}
+
+	// The snapshots let outdated and optimize --rebase work on this version
+	meta, err := provenance.Read(filepath.Join(m.detail.dir, "optimized-cv-3.md"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	baseCV, _ := meta.Input(provenance.RoleBaseCV)
+	for _, sum := range []string{baseCV.SHA256, meta.ModelOutput()} {
+		if _, err := provenance.LoadSnapshot(m.cfg.ProjectDir, sum); err != nil {
+			t.Errorf("snapshot %.12s: %v", sum, err)
+		}
+	}
+}
+
+func TestModel_ApplicationSettings(t *testing.T) {
+	m := newTestModel(t, &stubExecutor{output: "# CV\n"})
+	var asked string
+	m.cfg.ForApplication = func(appDir string) (Config, error) {
+		asked = appDir
+		return Config{}, errors.New("broken application.yml")
+	}
+	press(m, "enter")
+
+	if cmd := press(m, "o"); cmd != nil || m.busy != "" {
+		t.Error("optimize should not start without the application's settings")
+	}
+	if asked != m.detail.dir || !m.isErr || !strings.Contains(m.message, "broken application.yml") {
+		t.Errorf("asked for %q, message = %q", asked, m.message)
+	}
+}
+
+func TestModel_JobFailureAndCancel(t *testing.T) {
+	m := newTestModel(t, &stubExecutor{err: errors.New("claude down")})
+	press(m, "enter")
+
+	cmd := press(m, "a")
+	if !strings.Contains(m.busy, "(ATS)") {
+		t.Errorf("busy = %q, want ATS label", m.busy)
+	}
+	if press(m, "ctrl+c") != nil || m.message != "Cancelling..." {
+		t.Error("ctrl+c should cancel the job rather than quit")
+	}
+
+	runJob(t, m, cmd)
+	if !m.isErr || !strings.Contains(m.View(), "claude down") {
+		t.Errorf("failure should be shown:\n%s", m.View())
+	}
+	if cmd := press(m, "ctrl+c"); cmd == nil {
+		t.Error("ctrl+c with no job should quit")
+	}
+}
+
+func TestModel_GenerateWithoutVersions(t *testing.T) {
+	m := newTestModel(t, &stubExecutor{})
+	press(m, "down", "enter")
+	if m.detail.name != "beta" {
+		t.Fatalf("opened %s, want beta", m.detail.name)
+	}
+	if cmd := press(m, "g"); cmd != nil || !strings.Contains(m.message, "No optimized version") {
+		t.Errorf("generate with no versions should explain, got %q", m.message)
+	}
+}
@@ -0,0 +1,149 @@
+// Package usage records Claude token usage and cost per application.
+// Each application folder holds an append-only JSON Lines ledger that
+// can be summed across applications, models and commands.
+package usage
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/richq/m2cv/internal/executor"
+)
+
+// LedgerFile is the name of the usage ledger inside an application folder.
+const LedgerFile = "usage.jsonl"
+
+// Record is a single usage entry for one Claude call.
+type Record struct {
+	Timestamp                time.Time `json:"timestamp"`
+	Application              string    `json:"application"`
+	Command                  string    `json:"command"`
+	Model                    string    `json:"model,omitempty"`
+	SessionID                string    `json:"session_id,omitempty"`
+	InputTokens              int       `json:"input_tokens"`
+	OutputTokens             int       `json:"output_tokens"`
+	CacheCreationInputTokens int       `json:"cache_creation_input_tokens"`
+	CacheReadInputTokens     int       `json:"cache_read_input_tokens"`
+	CostUSD                  float64   `json:"cost_usd"`
+	DurationMS               int64     `json:"duration_ms"`
+}
+
+// NewRecord builds a record for the given application and command from an
+// executor result envelope, stamped with the current time.
+func NewRecord(application, command string, res *executor.Result) Record {
+	return Record{
+		Timestamp:                time.Now().UTC(),
+		Application:              application,
+		Command:                  command,
+		Model:                    res.Model,
+		SessionID:                res.SessionID,
+		InputTokens:              res.InputTokens,
+		OutputTokens:             res.OutputTokens,
+		CacheCreationInputTokens: res.CacheCreationInputTokens,
+		CacheReadInputTokens:     res.CacheReadInputTokens,
+		CostUSD:                  res.CostUSD,
+		DurationMS:               res.DurationMS,
+	}
+}
+
+// Append adds a record to the ledger in appDir, creating it if needed.
+func Append(appDir string, rec Record) error {
+	data, err := json.Marshal(rec)
+	if err != nil {
+		return fmt.Errorf("failed to marshal usage record: %w", err)
+	}
+
+	f, err := os.OpenFile(filepath.Join(appDir, LedgerFile), os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0644)
+	if err != nil {
+		return fmt.Errorf("failed to open usage ledger: %w", err)
+	}
+	defer f.Close()
+
+	if _, err := f.Write(append(data, '\n')); err != nil {
+		return fmt.Errorf("failed to write usage record: %w", err)
+	}
+	return nil
+}
+
+// Load reads all records from the ledger in appDir.
+// Returns an empty slice if the application has no ledger yet.
+func Load(appDir string) ([]Record, error) {
+	f, err := os.Open(filepath.Join(appDir, LedgerFile))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to open usage ledger: %w", err)
+	}
+	defer f.Close()
+
+	var records []Record
+	scanner := bufio.NewScanner(f)
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" {
+			continue
+		}
+		var rec Record
+		if err := json.Unmarshal([]byte(text), &rec); err != nil {
+			return nil, fmt.Errorf("invalid usage record at %s:%d: %w", f.Name(), line, err)
+		}
+		records = append(records, rec)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read usage ledger: %w", err)
+	}
+	return records, nil
+}
+
+// LoadAll reads the ledgers of every application under applicationsDir.
+func LoadAll(applicationsDir string) ([]Record, error) {
+	entries, err := os.ReadDir(applicationsDir)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read applications directory: %w", err)
+	}
+
+	var all []Record
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+		records, err := Load(filepath.Join(applicationsDir, entry.Name()))
+		if err != nil {
+			return nil, err
+		}
+		all = append(all, records...)
+	}
+	return all, nil
+}
+
+// ParseSince parses a --since value relative to now.
+// Accepts a date (2006-01-02), an RFC 3339 timestamp, or a duration
+// such as "36h" or "7d" (days are not supported by time.ParseDuration).
+func ParseSince(value string, now time.Time) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+	if t, err := time.ParseInLocation("2006-01-02", value, time.Local); err == nil {
+		return t, nil
+	}
+	if days, ok := strings.CutSuffix(value, "d"); ok {
+		if n, err := strconv.Atoi(days); err == nil && n >= 0 {
+			return now.AddDate(0, 0, -n), nil
+		}
+	}
+	if d, err := time.ParseDuration(value); err == nil && d >= 0 {
+		return now.Add(-d), nil
+	}
+	return time.Time{}, fmt.Errorf("invalid --since value %q: use a date (2006-01-02), RFC 3339 timestamp, or duration (7d, 24h)", value)
+}
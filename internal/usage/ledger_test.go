@@ -0,0 +1,113 @@
+package usage
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/richq/m2cv/internal/executor"
+)
+
+func TestAppendAndLoad(t *testing.T) {
+	appDir := t.TempDir()
+
+	res := &executor.Result{Model: "sonnet", SessionID: "s1", InputTokens: 10, OutputTokens: 5, CostUSD: 0.01, DurationMS: 900}
+	if err := Append(appDir, NewRecord("acme", "optimize", res)); err != nil {
+		t.Fatalf("Append() error = %v", err)
+	}
+	if err := Append(appDir, NewRecord("acme", "generate", res)); err != nil {
+		t.Fatalf("Append() error = %v", err)
+	}
+
+	records, err := Load(appDir)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if len(records) != 2 {
+		t.Fatalf("got %d records, want 2", len(records))
+	}
+	if records[0].Command != "optimize" || records[1].Command != "generate" {
+		t.Errorf("records out of order: %+v", records)
+	}
+	if records[0].Application != "acme" || records[0].Model != "sonnet" || records[0].InputTokens != 10 {
+		t.Errorf("record fields not preserved: %+v", records[0])
+	}
+}
+
+func TestLoad_MissingLedger(t *testing.T) {
+	records, err := Load(t.TempDir())
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if len(records) != 0 {
+		t.Errorf("got %d records, want 0", len(records))
+	}
+}
+
+func TestLoad_InvalidLine(t *testing.T) {
+	appDir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(appDir, LedgerFile), []byte("{not json}\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	_, err := Load(appDir)
+	if err == nil || !strings.Contains(err.Error(), ":1") {
+		t.Errorf("Load() error = %v, want error with line number", err)
+	}
+}
+
+func TestLoadAll(t *testing.T) {
+	appsDir := t.TempDir()
+	for _, app := range []string{"acme", "globex"} {
+		dir := filepath.Join(appsDir, app)
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := Append(dir, NewRecord(app, "optimize", &executor.Result{})); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// Applications without a ledger are skipped
+	if err := os.MkdirAll(filepath.Join(appsDir, "empty"), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	records, err := LoadAll(appsDir)
+	if err != nil {
+		t.Fatalf("LoadAll() error = %v", err)
+	}
+	if len(records) != 2 {
+		t.Errorf("got %d records, want 2", len(records))
+	}
+}
+
+func TestParseSince(t *testing.T) {
+	now := time.Date(2026, 3, 15, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		value   string
+		want    time.Time
+		wantErr bool
+	}{
+		{value: "7d", want: now.AddDate(0, 0, -7)},
+		{value: "24h", want: now.Add(-24 * time.Hour)},
+		{value: "2026-03-01T00:00:00Z", want: time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)},
+		{value: "2026-03-01", want: time.Date(2026, 3, 1, 0, 0, 0, 0, time.Local)},
+		{value: "last week", wantErr: true},
+		{value: "-3d", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			got, err := ParseSince(tt.value, now)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseSince(%q) error = %v, wantErr %v", tt.value, err, tt.wantErr)
+			}
+			if !tt.wantErr && !got.Equal(tt.want) {
+				t.Errorf("ParseSince(%q) = %v, want %v", tt.value, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,85 @@
+package usage
+
+import (
+	"sort"
+	"time"
+)
+
+// Totals accumulates usage across a set of records.
+type Totals struct {
+	Calls                    int
+	InputTokens              int
+	OutputTokens             int
+	CacheCreationInputTokens int
+	CacheReadInputTokens     int
+	CostUSD                  float64
+	Duration                 time.Duration
+}
+
+// Add accumulates a record into the totals.
+func (t *Totals) Add(rec Record) {
+	t.Calls++
+	t.InputTokens += rec.InputTokens
+	t.OutputTokens += rec.OutputTokens
+	t.CacheCreationInputTokens += rec.CacheCreationInputTokens
+	t.CacheReadInputTokens += rec.CacheReadInputTokens
+	t.CostUSD += rec.CostUSD
+	t.Duration += time.Duration(rec.DurationMS) * time.Millisecond
+}
+
+// Summary groups totals by application, model and command.
+type Summary struct {
+	Total         Totals
+	ByApplication map[string]*Totals
+	ByModel       map[string]*Totals
+	ByCommand     map[string]*Totals
+}
+
+// Summarize sums records at or after since. A zero since includes everything.
+func Summarize(records []Record, since time.Time) Summary {
+	s := Summary{
+		ByApplication: make(map[string]*Totals),
+		ByModel:       make(map[string]*Totals),
+		ByCommand:     make(map[string]*Totals),
+	}
+
+	for _, rec := range records {
+		if !since.IsZero() && rec.Timestamp.Before(since) {
+			continue
+		}
+		s.Total.Add(rec)
+		addTo(s.ByApplication, rec.Application, rec)
+		addTo(s.ByModel, orUnknown(rec.Model), rec)
+		addTo(s.ByCommand, rec.Command, rec)
+	}
+
+	return s
+}
+
+// SortedKeys returns the keys of a totals map in alphabetical order.
+func SortedKeys(m map[string]*Totals) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// addTo accumulates rec into the totals stored under key.
+func addTo(m map[string]*Totals, key string, rec Record) {
+	t, ok := m[key]
+	if !ok {
+		t = &Totals{}
+		m[key] = t
+	}
+	t.Add(rec)
+}
+
+// orUnknown substitutes a placeholder for empty grouping keys.
+func orUnknown(s string) string {
+	if s == "" {
+		return "(default)"
+	}
+	return s
+}
@@ -0,0 +1,47 @@
+package usage
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSummarize(t *testing.T) {
+	base := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	records := []Record{
+		{Timestamp: base, Application: "acme", Command: "optimize", Model: "opus", InputTokens: 100, OutputTokens: 50, CostUSD: 0.5, DurationMS: 1000},
+		{Timestamp: base.Add(time.Hour), Application: "acme", Command: "generate", Model: "haiku", InputTokens: 10, OutputTokens: 5, CostUSD: 0.01},
+		{Timestamp: base.Add(2 * time.Hour), Application: "globex", Command: "optimize", InputTokens: 200, OutputTokens: 80, CostUSD: 0.7},
+	}
+
+	s := Summarize(records, time.Time{})
+	if s.Total.Calls != 3 || s.Total.InputTokens != 310 || s.Total.OutputTokens != 135 {
+		t.Errorf("unexpected totals: %+v", s.Total)
+	}
+	if s.Total.Duration != time.Second {
+		t.Errorf("Duration = %v, want 1s", s.Total.Duration)
+	}
+	if got := s.ByApplication["acme"].Calls; got != 2 {
+		t.Errorf("acme calls = %d, want 2", got)
+	}
+	if got := s.ByCommand["optimize"].CostUSD; got < 1.19 || got > 1.21 {
+		t.Errorf("optimize cost = %v, want 1.2", got)
+	}
+	if _, ok := s.ByModel["(default)"]; !ok {
+		t.Error("records without a model should be grouped under (default)")
+	}
+
+	filtered := Summarize(records, base.Add(30*time.Minute))
+	if filtered.Total.Calls != 2 {
+		t.Errorf("filtered calls = %d, want 2", filtered.Total.Calls)
+	}
+	if _, ok := filtered.ByModel["opus"]; ok {
+		t.Error("records before since should be excluded")
+	}
+}
+
+func TestSortedKeys(t *testing.T) {
+	keys := SortedKeys(map[string]*Totals{"b": {}, "a": {}, "c": {}})
+	if len(keys) != 3 || keys[0] != "a" || keys[1] != "b" || keys[2] != "c" {
+		t.Errorf("SortedKeys() = %v, want [a b c]", keys)
+	}
+}
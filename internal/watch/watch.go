@@ -0,0 +1,191 @@
+// Package watch polls files for content changes. Contents are hashed rather
+// than compared by modification time, so saving a file without changing it
+// does not trigger a rebuild, and edits are debounced so a burst of saves
+// produces a single callback.
+package watch
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+)
+
+// Default polling settings.
+const (
+	DefaultInterval = 250 * time.Millisecond
+	DefaultDebounce = 500 * time.Millisecond
+)
+
+// Snapshot maps each watched path to a hash of its content. Missing paths
+// map to "".
+type Snapshot map[string]string
+
+// Take hashes each path. Directories are hashed recursively (file names
+// and contents), skipping nested node_modules and .git folders.
+func Take(paths []string) Snapshot {
+	s := make(Snapshot, len(paths))
+	for _, p := range paths {
+		s[p] = hashPath(p)
+	}
+	return s
+}
+
+// Changed returns the sorted paths whose hash differs between s and prev,
+// including paths present in only one of them.
+func (s Snapshot) Changed(prev Snapshot) []string {
+	var changed []string
+	for p, h := range s {
+		if old, ok := prev[p]; !ok || old != h {
+			changed = append(changed, p)
+		}
+	}
+	for p := range prev {
+		if _, ok := s[p]; !ok {
+			changed = append(changed, p)
+		}
+	}
+	sort.Strings(changed)
+	return changed
+}
+
+// hashPath hashes a file or directory, returning "" if it cannot be read.
+func hashPath(path string) string {
+	info, err := os.Stat(path)
+	if err != nil {
+		return ""
+	}
+
+	h := sha256.New()
+	if !info.IsDir() {
+		if err := hashFile(h, path); err != nil {
+			return ""
+		}
+		return hex.EncodeToString(h.Sum(nil))
+	}
+
+	// WalkDir visits entries in lexical order, so the hash is stable
+	err = filepath.WalkDir(path, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			if p != path && (d.Name() == "node_modules" || d.Name() == ".git") {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !d.Type().IsRegular() {
+			return nil
+		}
+		rel, _ := filepath.Rel(path, p)
+		io.WriteString(h, rel+"\x00")
+		return hashFile(h, p)
+	})
+	if err != nil {
+		return ""
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// hashFile writes the contents of the file at path into w.
+func hashFile(w io.Writer, path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	_, err = io.Copy(w, f)
+	return err
+}
+
+// Watcher polls a set of paths and reports debounced content changes.
+type Watcher struct {
+	paths    func() []string
+	interval time.Duration
+	debounce time.Duration
+}
+
+// Option configures a Watcher.
+type Option func(*Watcher)
+
+// WithInterval sets how often the paths are polled.
+func WithInterval(d time.Duration) Option {
+	return func(w *Watcher) {
+		w.interval = d
+	}
+}
+
+// WithDebounce sets how long content must stay unchanged before the
+// change is reported.
+func WithDebounce(d time.Duration) Option {
+	return func(w *Watcher) {
+		w.debounce = d
+	}
+}
+
+// New creates a Watcher. paths is called on every poll, so the watched set
+// may change over time (e.g. when a new CV version appears).
+func New(paths func() []string, opts ...Option) *Watcher {
+	w := &Watcher{
+		paths:    paths,
+		interval: DefaultInterval,
+		debounce: DefaultDebounce,
+	}
+	for _, opt := range opts {
+		opt(w)
+	}
+	return w
+}
+
+// Run polls until ctx is cancelled, calling onChange with the changed paths
+// once content differs from the last reported state and has been stable
+// for the debounce period. since is the state to compare against; if nil,
+// a snapshot is taken when Run starts. onChange runs synchronously; edits
+// made while it runs are picked up by the next poll. Run returns nil when
+// ctx is cancelled.
+func (w *Watcher) Run(ctx context.Context, since Snapshot, onChange func(changed []string)) error {
+	last := since
+	if last == nil {
+		last = Take(w.paths())
+	}
+
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	var pending Snapshot
+	var pendingSince time.Time
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+
+		current := Take(w.paths())
+		if len(current.Changed(last)) == 0 {
+			pending = nil
+			continue
+		}
+
+		// Restart the debounce window while content is still moving
+		if pending == nil || len(current.Changed(pending)) > 0 {
+			pending, pendingSince = current, time.Now()
+			if w.debounce > 0 {
+				continue
+			}
+		}
+		if time.Since(pendingSince) < w.debounce {
+			continue
+		}
+
+		changed := current.Changed(last)
+		last, pending = current, nil
+		onChange(changed)
+	}
+}
@@ -0,0 +1,144 @@
+package watch
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func writeFile(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestTake(t *testing.T) {
+	dir := t.TempDir()
+	file := filepath.Join(dir, "cv.md")
+	theme := filepath.Join(dir, "theme")
+	writeFile(t, file, "v1")
+	writeFile(t, filepath.Join(theme, "index.js"), "render()")
+	writeFile(t, filepath.Join(theme, "node_modules", "dep", "x.js"), "a")
+
+	missing := filepath.Join(dir, "missing")
+	before := Take([]string{file, theme, missing})
+	if before[file] == "" || before[theme] == "" {
+		t.Fatalf("existing paths should hash: %v", before)
+	}
+	if before[missing] != "" {
+		t.Errorf("missing path should hash to empty, got %q", before[missing])
+	}
+
+	// Rewriting identical content and touching nested node_modules is not a change
+	writeFile(t, file, "v1")
+	writeFile(t, filepath.Join(theme, "node_modules", "dep", "x.js"), "b")
+	if changed := Take([]string{file, theme, missing}).Changed(before); len(changed) != 0 {
+		t.Errorf("unexpected changes: %v", changed)
+	}
+
+	writeFile(t, filepath.Join(theme, "style.css"), "body{}")
+	writeFile(t, missing, "now here")
+	got := Take([]string{file, theme, missing}).Changed(before)
+	want := []string{missing, theme}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Changed() = %v, want %v", got, want)
+	}
+}
+
+func TestSnapshotChanged_PathSetChanges(t *testing.T) {
+	prev := Snapshot{"a": "1", "b": "2"}
+	cur := Snapshot{"b": "2", "c": "3"}
+	if got := cur.Changed(prev); !reflect.DeepEqual(got, []string{"a", "c"}) {
+		t.Errorf("Changed() = %v", got)
+	}
+}
+
+func TestWatcher_DebouncesBursts(t *testing.T) {
+	dir := t.TempDir()
+	file := filepath.Join(dir, "cv.md")
+	writeFile(t, file, "v1")
+
+	w := New(func() []string { return []string{file} },
+		WithInterval(5*time.Millisecond), WithDebounce(60*time.Millisecond))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	calls := make(chan []string, 10)
+	done := make(chan error, 1)
+	go func() {
+		done <- w.Run(ctx, Take([]string{file}), func(changed []string) { calls <- changed })
+	}()
+
+	// A burst of edits inside the debounce window yields one callback
+	for _, content := range []string{"v2", "v3", "v4"} {
+		writeFile(t, file, content)
+		time.Sleep(15 * time.Millisecond)
+	}
+
+	select {
+	case changed := <-calls:
+		if !reflect.DeepEqual(changed, []string{file}) {
+			t.Errorf("changed = %v", changed)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("no change reported")
+	}
+
+	select {
+	case changed := <-calls:
+		t.Errorf("burst should be reported once, got extra %v", changed)
+	case <-time.After(150 * time.Millisecond):
+	}
+
+	// Saving the already-reported content again is not a change
+	writeFile(t, file, "v4")
+	select {
+	case changed := <-calls:
+		t.Errorf("identical save reported as %v", changed)
+	case <-time.After(150 * time.Millisecond):
+	}
+
+	cancel()
+	if err := <-done; err != nil {
+		t.Errorf("Run() = %v, want nil on cancel", err)
+	}
+}
+
+func TestWatcher_DynamicPaths(t *testing.T) {
+	dir := t.TempDir()
+	v1 := filepath.Join(dir, "optimized-cv-1.md")
+	v2 := filepath.Join(dir, "optimized-cv-2.md")
+	writeFile(t, v1, "one")
+
+	latest := func() []string {
+		if _, err := os.Stat(v2); err == nil {
+			return []string{v2}
+		}
+		return []string{v1}
+	}
+	w := New(latest, WithInterval(5*time.Millisecond), WithDebounce(0))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	calls := make(chan []string, 10)
+	go w.Run(ctx, nil, func(changed []string) { calls <- changed })
+
+	time.Sleep(20 * time.Millisecond)
+	writeFile(t, v2, "two")
+
+	select {
+	case changed := <-calls:
+		if !reflect.DeepEqual(changed, []string{v1, v2}) {
+			t.Errorf("changed = %v, want old and new latest", changed)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("new version not reported")
+	}
+}